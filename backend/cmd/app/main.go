@@ -14,8 +14,10 @@ import (
 	"github.com/Fixsbreaker/event-hub/backend/internal/database"
 	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
 	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
 	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
 	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
 	"github.com/Fixsbreaker/event-hub/backend/internal/worker"
 
 	"github.com/gin-gonic/gin"
@@ -68,17 +70,69 @@ func main() {
 	eventService := service.NewEventService(eventRepo, redisCache)
 	authMW := middleware.Auth(cfg.JWTSecret)
 
-	handler.NewEventHandler(r, eventService, authMW)
+	optionalAuthMW := middleware.OptionalAuth(cfg.JWTSecret)
+	handler.NewEventHandler(r, eventService, authMW, optionalAuthMW)
 
-	// registrations
+	// Complete published events once they have ended
+	completionJob := worker.NewPeriodicJob("complete-ended-events", 5*time.Minute, func(ctx context.Context) error {
+		completed, err := eventService.CompleteEndedEvents(ctx)
+		if completed > 0 {
+			log.Printf("Completed %d ended events", completed)
+		}
+		return err
+	})
+	completionJob.Start()
+	defer completionJob.Stop()
 
-	regRepo := repository.NewRegistrationRepository(dbConn)
-	regService := service.NewRegistrationService(regRepo, eventRepo)
-	handler.NewRegistrationHandler(r, regService, authMW)
+	// categories
 
-	// users
-	userService := service.NewUserService(userRepo)
-	handler.NewUserHandler(r, userService, authMW)
+	categoryRepo := repository.NewCategoryRepository(dbConn)
+	categoryService := service.NewCategoryService(categoryRepo, eventService)
+	handler.NewCategoryHandler(r, categoryService, authMW)
+
+	// ticket types
+
+	ticketRepo := repository.NewTicketTypeRepository(dbConn)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	handler.NewTicketHandler(r, ticketService, authMW)
+
+	// promo codes
+
+	promoRepo := repository.NewPromoCodeRepository(dbConn)
+	promoService := service.NewPromoService(promoRepo, ticketRepo, eventRepo)
+	handler.NewPromoHandler(r, promoService, authMW)
+
+	// payments
+
+	if cfg.PaymentProvider != "fake" {
+		log.Fatalf("unsupported payment provider: %s", cfg.PaymentProvider)
+	}
+	paymentProvider := payment.NewFakeProvider(cfg.PaymentWebhookSecret, cfg.PaymentWebhookURL)
+	orderRepo := repository.NewOrderRepository(dbConn)
+	paymentService := service.NewPaymentService(orderRepo, paymentProvider, cfg.PaymentHoldTime)
+	handler.NewPaymentHandler(r, paymentService, authMW)
+
+	// Release seats of unpaid orders once their hold expires
+	holdExpiryJob := worker.NewPeriodicJob("expire-payment-holds", time.Minute, func(ctx context.Context) error {
+		expired, err := paymentService.ExpireHolds(ctx)
+		if expired > 0 {
+			log.Printf("Released %d expired payment holds", expired)
+		}
+		return err
+	})
+	holdExpiryJob.Start()
+	defer holdExpiryJob.Stop()
+
+	// Send refunds the provider rejected earlier
+	refundRetryJob := worker.NewPeriodicJob("retry-refunds", time.Minute, func(ctx context.Context) error {
+		refunded, err := paymentService.RetryRefunds(ctx)
+		if refunded > 0 {
+			log.Printf("Sent %d outstanding refunds", refunded)
+		}
+		return err
+	})
+	refundRetryJob.Start()
+	defer refundRetryJob.Stop()
 
 	// notifications
 
@@ -91,6 +145,111 @@ func main() {
 	notificationService := service.NewNotificationService(notificationRepo, notifWorkerPool)
 	handler.NewNotificationHandler(r, notificationService, authMW)
 
+	// Publish drafts whose publish_at has come
+	publishScheduler := service.NewPublishScheduler(eventService, notificationService)
+	publishJob := worker.NewPeriodicJob("publish-scheduled-events", time.Minute, func(ctx context.Context) error {
+		published, err := publishScheduler.PublishDue(ctx)
+		if published > 0 {
+			log.Printf("Published %d scheduled events", published)
+		}
+		return err
+	})
+	publishJob.Start()
+	defer publishJob.Stop()
+
+	// registrations
+
+	regRepo := repository.NewRegistrationRepository(dbConn)
+	policyRepo := repository.NewCancellationPolicyRepository(dbConn)
+	formRepo := repository.NewRegistrationFormRepository(dbConn)
+	regService := service.NewRegistrationService(regRepo, eventRepo, ticketRepo, policyRepo, formRepo, paymentService, promoService, notificationService)
+	handler.NewRegistrationHandler(r, regService, authMW)
+
+	// CSV imports of events and registrants, processed in the background
+	importService := service.NewImportService(repository.NewImportJobRepository(dbConn), eventService, eventRepo, regRepo, ticketRepo, userRepo, notificationService)
+	handler.NewImportHandler(r, importService, authMW)
+	defer importService.Wait()
+
+	// analytics dashboards of organizers (cached in Redis)
+	statsService := service.NewStatsService(repository.NewStatsRepository(dbConn), eventRepo, redisCache)
+	handler.NewStatsHandler(r, statsService, authMW)
+
+	// registration transfers
+	transferRepo := repository.NewRegistrationTransferRepository(dbConn)
+	transferService := service.NewTransferService(transferRepo, regRepo, eventRepo, userRepo, notificationService)
+	handler.NewTransferHandler(r, transferService, authMW)
+
+	// invitations to private events
+	invitationRepo := repository.NewEventInvitationRepository(dbConn)
+	invitationService := service.NewInvitationService(invitationRepo, eventRepo, userRepo, notificationService)
+	handler.NewInvitationHandler(r, invitationService, authMW)
+
+	// event teams
+	memberRepo := repository.NewEventMemberRepository(dbConn)
+	memberService := service.NewMemberService(memberRepo, eventRepo, eventService, userRepo, notificationService)
+	handler.NewMemberHandler(r, memberService, authMW)
+
+	// organizations
+	orgRepo := repository.NewOrganizationRepository(dbConn)
+	orgService := service.NewOrganizationService(orgRepo, userRepo)
+	handler.NewOrganizationHandler(r, orgService, authMW)
+
+	// agendas: speakers and sessions
+	sessionRepo := repository.NewSessionRepository(dbConn)
+	sessionService := service.NewSessionService(sessionRepo, eventRepo, eventService)
+	handler.NewSessionHandler(r, sessionService, authMW, optionalAuthMW)
+
+	// event templates
+	templateRepo := repository.NewEventTemplateRepository(dbConn)
+	templateService := service.NewEventTemplateService(templateRepo, eventService)
+	handler.NewEventTemplateHandler(r, templateService, authMW)
+
+	// event files: cover images and attachments
+	var blobStore storage.BlobStore
+	switch cfg.StorageBackend {
+	case "local":
+		localStore, err := storage.NewLocalStore(cfg.StorageLocalDir, cfg.StoragePublicURL, cfg.StorageSigningSecret)
+		if err != nil {
+			log.Fatalf("failed to set up file storage: %v", err)
+		}
+		handler.NewBlobHandler(r, localStore)
+		blobStore = localStore
+	case "s3":
+		s3Store, err := storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+		if err != nil {
+			log.Fatalf("failed to set up file storage: %v", err)
+		}
+		blobStore = s3Store
+	default:
+		log.Fatalf("unsupported storage backend: %s", cfg.StorageBackend)
+	}
+	fileRepo := repository.NewEventFileRepository(dbConn)
+	fileService := service.NewEventFileService(fileRepo, eventRepo, eventService, blobStore,
+		service.UploadLimits{MaxImageSize: cfg.UploadMaxImageSize, MaxAttachmentSize: cfg.UploadMaxAttachSize}, cfg.FileURLTTL)
+	handler.NewEventFileHandler(r, fileService, authMW, optionalAuthMW)
+
+	// Purge deleted events and users once their retention period is over
+	trashPurger := service.NewTrashPurger(repository.NewTrashRepository(dbConn), blobStore, cfg.TrashRetention)
+	purgeJob := worker.NewPeriodicJob("purge-trash", time.Hour, func(ctx context.Context) error {
+		purged, err := trashPurger.PurgeExpired(ctx)
+		if purged.Events+purged.Users+purged.Registrations > 0 {
+			log.Printf("Purged %d events, %d users and %d registrations from the trash", purged.Events, purged.Users, purged.Registrations)
+		}
+		return err
+	})
+	purgeJob.Start()
+	defer purgeJob.Stop()
+
+	// users
+	userService := service.NewUserService(userRepo)
+	handler.NewUserHandler(r, userService, authMW)
+
 	// start server
 
 	// start server
@@ -132,6 +291,7 @@ func main() {
 	// Stop Worker Pool gracefully
 	log.Println("Stopping worker pool...")
 	notifWorkerPool.Stop()
+	holdExpiryJob.Stop()
 
 	log.Println("Server exiting")
 }
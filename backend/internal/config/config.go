@@ -26,6 +26,29 @@ type Config struct {
 	// Redis (optional for now)
 	RedisHost string
 	RedisPort string
+
+	// Payments
+	PaymentProvider      string        // Payment gateway ("fake" runs fully in-process)
+	PaymentWebhookSecret string        // Secret used to sign and verify provider webhooks
+	PaymentWebhookURL    string        // Where the fake provider delivers webhooks (empty = apply in-process)
+	PaymentHoldTime      time.Duration // How long unpaid seats stay held
+
+	// File storage
+	StorageBackend       string // "local" (files on disk, served by the app) or "s3" (S3-compatible object store)
+	StorageLocalDir      string // Directory of the local backend
+	StoragePublicURL     string // Public address of the app, for the signed URLs of the local backend
+	StorageSigningSecret string // Secret signing the URLs of the local backend
+	S3Endpoint           string // e.g. http://localhost:9000 for MinIO
+	S3Region             string
+	S3Bucket             string
+	S3AccessKey          string
+	S3SecretKey          string
+	UploadMaxImageSize   int64         // Largest accepted cover image, in bytes
+	UploadMaxAttachSize  int64         // Largest accepted attachment, in bytes
+	FileURLTTL           time.Duration // How long signed file URLs stay valid
+
+	// Trash
+	TrashRetention time.Duration // How long deleted events and users can be restored before they are purged
 }
 
 func Load() *Config {
@@ -47,6 +70,26 @@ func Load() *Config {
 
 		RedisHost: getEnv("REDIS_HOST", "localhost"),
 		RedisPort: getEnv("REDIS_PORT", "6379"),
+
+		PaymentProvider:      getEnv("PAYMENT_PROVIDER", "fake"),
+		PaymentWebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", "dev-webhook-secret-change-in-production"),
+		PaymentWebhookURL:    getEnv("PAYMENT_WEBHOOK_URL", ""),
+		PaymentHoldTime:      time.Duration(getEnvAsInt("PAYMENT_HOLD_MINUTES", 15)) * time.Minute,
+
+		StorageBackend:       getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:      getEnv("STORAGE_LOCAL_DIR", "uploads"),
+		StoragePublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSigningSecret: getEnv("STORAGE_SIGNING_SECRET", "dev-storage-secret-change-in-production"),
+		S3Endpoint:           getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:             getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:             getEnv("S3_BUCKET", "event-hub"),
+		S3AccessKey:          getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:          getEnv("S3_SECRET_KEY", ""),
+		UploadMaxImageSize:   int64(getEnvAsInt("UPLOAD_MAX_IMAGE_MB", 5)) << 20,
+		UploadMaxAttachSize:  int64(getEnvAsInt("UPLOAD_MAX_ATTACHMENT_MB", 20)) << 20,
+		FileURLTTL:           time.Duration(getEnvAsInt("FILE_URL_TTL_MINUTES", 60)) * time.Minute,
+
+		TrashRetention: time.Duration(getEnvAsInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
 	}
 }
 
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RefundTier grants a refund percentage to cancellations made at least HoursBefore hours before the event starts.
+type RefundTier struct {
+	HoursBefore   int `json:"hours_before"`
+	RefundPercent int `json:"refund_percent"`
+}
+
+// CancellationPolicy defines when attendees may cancel and how much of a paid ticket is refunded.
+// Events without a stored policy use DefaultCancellationPolicy.
+type CancellationPolicy struct {
+	EventID           string       `gorm:"type:uuid;primaryKey" json:"event_id"`
+	AllowCancellation bool         `gorm:"not null" json:"allow_cancellation"`     // false = cancelling is never allowed
+	CutoffHours       int          `gorm:"not null;default:0" json:"cutoff_hours"` // No cancellations within N hours of the start
+	RefundTiers       []RefundTier `gorm:"type:jsonb;serializer:json;not null" json:"refund_tiers"`
+	CreatedAt         time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (CancellationPolicy) TableName() string {
+	return "cancellation_policies"
+}
+
+// UpdateCancellationPolicyRequest represents the input for setting an event's cancellation policy.
+type UpdateCancellationPolicyRequest struct {
+	AllowCancellation *bool        `json:"allow_cancellation" binding:"required"`
+	CutoffHours       int          `json:"cutoff_hours" binding:"min=0"`
+	RefundTiers       []RefundTier `json:"refund_tiers"`
+}
+
+// CancellationQuote is the outcome of evaluating a policy for a cancellation.
+type CancellationQuote struct {
+	RefundPercent int `json:"refund_percent"`
+}
+
+// DefaultCancellationPolicy allows cancelling with a full refund until the event starts.
+func DefaultCancellationPolicy(eventID string) *CancellationPolicy {
+	return &CancellationPolicy{
+		EventID:           eventID,
+		AllowCancellation: true,
+		RefundTiers:       []RefundTier{{HoursBefore: 0, RefundPercent: 100}},
+	}
+}
+
+// Business Logic
+
+// Validate performs business rule validation on the CancellationPolicy entity.
+// Refund tiers are sorted from the earliest (most hours before the start) to the latest.
+func (p *CancellationPolicy) Validate() error {
+	if p.CutoffHours < 0 {
+		return fmt.Errorf("cutoff_hours cannot be negative")
+	}
+	if p.RefundTiers == nil {
+		p.RefundTiers = []RefundTier{}
+	}
+
+	seen := make(map[int]bool, len(p.RefundTiers))
+	for _, tier := range p.RefundTiers {
+		if tier.HoursBefore < 0 {
+			return fmt.Errorf("refund tier hours_before cannot be negative")
+		}
+		if tier.RefundPercent < 0 || tier.RefundPercent > 100 {
+			return fmt.Errorf("refund tier refund_percent must be between 0 and 100")
+		}
+		if seen[tier.HoursBefore] {
+			return fmt.Errorf("duplicate refund tier for %d hours before the event", tier.HoursBefore)
+		}
+		seen[tier.HoursBefore] = true
+	}
+
+	sort.Slice(p.RefundTiers, func(i, j int) bool {
+		return p.RefundTiers[i].HoursBefore > p.RefundTiers[j].HoursBefore
+	})
+	return nil
+}
+
+// Evaluate decides whether a registration may be cancelled at the given time and
+// which refund percentage applies. Rejections are returned as CodedErrors.
+func (p *CancellationPolicy) Evaluate(eventStart, now time.Time) (*CancellationQuote, error) {
+	if !now.Before(eventStart) {
+		return nil, ErrEventAlreadyStarted
+	}
+	if !p.AllowCancellation {
+		return nil, ErrCancellationNotAllowed
+	}
+
+	untilStart := eventStart.Sub(now)
+	if p.CutoffHours > 0 && untilStart < time.Duration(p.CutoffHours)*time.Hour {
+		return nil, NewCodedError(ErrCancellationCutoffPassed.Code,
+			fmt.Sprintf("cancellations close %d hours before the event starts", p.CutoffHours))
+	}
+
+	// Tiers are sorted by HoursBefore descending: the first one reached applies
+	quote := &CancellationQuote{}
+	for _, tier := range p.RefundTiers {
+		if untilStart >= time.Duration(tier.HoursBefore)*time.Hour {
+			quote.RefundPercent = tier.RefundPercent
+			break
+		}
+	}
+	return quote, nil
+}
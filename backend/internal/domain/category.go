@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Category is an admin-managed event classification.
+// Categories form a tree: a category may have a parent, and filtering
+// events by a category also matches events in all of its descendants.
+type Category struct {
+	ID          string     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"` // Unique identifier
+	ParentID    *string    `gorm:"type:uuid;index" json:"parent_id"`                          // Parent category (nil for top-level)
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`                    // Display name
+	Slug        string     `gorm:"type:varchar(100);not null;uniqueIndex" json:"slug"`        // URL-friendly identifier used in filters
+	Description string     `gorm:"type:text" json:"description"`                              // Optional description
+	Children    []Category `gorm:"-" json:"children,omitempty"`                               // Sub-categories (populated for tree responses)
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Category) TableName() string {
+	return "categories"
+}
+
+// Tag is a free-form label attached to events through the event_tags join table.
+type Tag struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	Name      string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"name"` // Normalized (lowercase, trimmed) tag name
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// DTOs
+
+// CreateCategoryRequest represents the input for creating a category (admin only).
+type CreateCategoryRequest struct {
+	Name        string  `json:"name" binding:"required,min=2,max=100"`
+	Slug        string  `json:"slug" binding:"omitempty,max=100"` // Derived from name when empty
+	ParentID    *string `json:"parent_id"`
+	Description string  `json:"description"`
+}
+
+// UpdateCategoryRequest represents the input for updating a category (admin only).
+// Only non-nil fields are updated.
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"` // Empty string moves the category to the top level
+	Description *string `json:"description,omitempty"`
+}
+
+// FacetCount is a single bucket of a facet (e.g. one category or one month).
+type FacetCount struct {
+	Value string `json:"value"`           // Bucket key: category slug, tag name, status or YYYY-MM
+	Label string `json:"label,omitempty"` // Human readable name (categories only)
+	Count int64  `json:"count"`           // Number of events in the bucket
+}
+
+// EventFacets contains per-dimension event counts for the current filter set.
+type EventFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Tags       []FacetCount `json:"tags"`
+	Statuses   []FacetCount `json:"statuses"`
+	Months     []FacetCount `json:"months"`
+	Total      int64        `json:"total"`
+}
+
+// Business Logic
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify converts an arbitrary name into a URL-friendly slug ("Tech & Science" -> "tech-science").
+func Slugify(name string) string {
+	slug := slugInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	return strings.Trim(slug, "-")
+}
+
+// Validate performs business rule validation on the Category entity.
+// An empty slug is derived from the name.
+func (c *Category) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if c.Slug == "" {
+		c.Slug = Slugify(c.Name)
+	}
+	if !slugRegex.MatchString(c.Slug) {
+		return fmt.Errorf("slug may only contain lowercase letters, digits and dashes")
+	}
+	if c.ParentID != nil && *c.ParentID == c.ID && c.ID != "" {
+		return fmt.Errorf("category cannot be its own parent")
+	}
+	return nil
+}
+
+// NormalizeTags lowercases, trims and de-duplicates tag names, dropping empty ones.
+func NormalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		tag := strings.ToLower(strings.TrimSpace(name))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > 50 {
+			return nil, fmt.Errorf("tag %q is too long (max 50 characters)", tag)
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) > 20 {
+		return nil, fmt.Errorf("an event can have at most 20 tags")
+	}
+	return result, nil
+}
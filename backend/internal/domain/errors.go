@@ -0,0 +1,80 @@
+package domain
+
+// CodedError is a business rule violation with a stable, machine-readable code
+// that clients can rely on, in addition to the human-readable message.
+type CodedError struct {
+	Code    string
+	Message string
+}
+
+// NewCodedError creates a new CodedError
+func NewCodedError(code, message string) *CodedError {
+	return &CodedError{Code: code, Message: message}
+}
+
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// Is matches coded errors by code, so errors.Is(err, ErrX) works for errors with custom messages
+func (e *CodedError) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	return ok && t.Code == e.Code
+}
+
+// Cancellation policy errors
+var (
+	ErrCancellationNotAllowed   = NewCodedError("CANCELLATION_NOT_ALLOWED", "this event does not allow cancellations")
+	ErrCancellationCutoffPassed = NewCodedError("CANCELLATION_CUTOFF_PASSED", "the cancellation deadline has passed")
+	ErrEventAlreadyStarted      = NewCodedError("EVENT_ALREADY_STARTED", "registrations cannot be cancelled once the event has started")
+)
+
+// Registration form errors
+var (
+	ErrAnswersLocked = NewCodedError("ANSWERS_LOCKED", "answers can no longer be changed")
+)
+
+// Schedule errors
+var (
+	ErrScheduleConflict = NewCodedError("SCHEDULE_CONFLICT", "you are already attending another event at that time")
+)
+
+// Registration transfer errors
+var (
+	ErrTransfersDisabled = NewCodedError("TRANSFERS_DISABLED", "the organizer does not allow transfers for this event")
+)
+
+// Registration window errors
+var (
+	ErrRegistrationNotOpen = NewCodedError("REGISTRATION_NOT_OPEN", "registration has not opened yet")
+	ErrRegistrationClosed  = NewCodedError("REGISTRATION_CLOSED", "registration has closed")
+)
+
+// Event access errors
+var (
+	ErrInvitationRequired = NewCodedError("INVITATION_REQUIRED", "this event is private: an invitation or access code is required")
+)
+
+// Event lifecycle errors
+var (
+	ErrInvalidStatusTransition = NewCodedError("INVALID_STATUS_TRANSITION", "this status change is not allowed")
+)
+
+// Event update errors
+var (
+	ErrEventVersionMismatch    = NewCodedError("VERSION_MISMATCH", "the event was changed by someone else: reload it and apply your changes again")
+	ErrCapacityBelowSeatsTaken = NewCodedError("CAPACITY_BELOW_SEATS_TAKEN", "the capacity cannot be lower than the seats already taken")
+	ErrSessionsOutsideEvent    = NewCodedError("SESSIONS_OUTSIDE_EVENT", "the new dates would leave sessions of the agenda outside the event")
+)
+
+// Agenda errors
+var (
+	ErrSessionRoomConflict = NewCodedError("SESSION_ROOM_CONFLICT", "the room is already booked at that time")
+	ErrSessionFull         = NewCodedError("SESSION_FULL", "the session is full")
+)
+
+// Upload errors
+var (
+	ErrFileTooLarge        = NewCodedError("FILE_TOO_LARGE", "the file is too large")
+	ErrUnsupportedFileType = NewCodedError("UNSUPPORTED_FILE_TYPE", "this type of file is not accepted")
+)
@@ -1,7 +1,13 @@
 package domain
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,23 +17,42 @@ import (
 // Events can be created by users (organizers) and have different statuses:
 //   - draft: Event is being created, not visible to public
 //   - published: Event is live and accepting registrations
+//   - postponed: Event is on hold until new dates are published
+//   - completed: Event has taken place
 //   - cancelled: Event has been cancelled
 //
+// See event_status.go for the allowed transitions.
+//
 // The entity uses GORM for ORM mapping and includes soft delete support.
 type Event struct {
-	ID            string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`              // Unique identifier (auto-generated UUID)
-	OrganizerID   string         `gorm:"type:uuid;not null;index" json:"organizer_id"`                           // Foreign key to users table
-	Organizer     *User          `gorm:"foreignKey:OrganizerID;constraint:OnDelete:CASCADE" json:"organizer,omitempty"` // Organizer user details (eager loaded when needed)
-	Title         string         `gorm:"type:varchar(255);not null" json:"title"`                                // Event title (min 3 chars)
-	Description   string         `gorm:"type:text" json:"description"`                                           // Detailed event description (optional)
-	StartDatetime time.Time      `gorm:"not null;index" json:"start_datetime"`                                   // Event start date and time (indexed for date range queries)
-	EndDatetime   time.Time      `gorm:"not null" json:"end_datetime"`                                           // Event end date and time
-	Location      string         `gorm:"type:varchar(255);not null" json:"location"`                             // Event venue or location
-	Capacity      int            `gorm:"not null;check:capacity > 0" json:"capacity"`                            // Maximum number of attendees (must be > 0)
-	Status        string         `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`          // Event status: draft, published, cancelled (indexed for filtering)
-	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`                                       // Timestamp when event was created
-	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`                                       // Timestamp of last update
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`                                                         // Soft delete timestamp (null if not deleted)
+	ID                   string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`                     // Unique identifier (auto-generated UUID)
+	OrganizerID          string         `gorm:"type:uuid;not null;index" json:"organizer_id"`                                  // Foreign key to users table
+	Organizer            *User          `gorm:"foreignKey:OrganizerID;constraint:OnDelete:CASCADE" json:"organizer,omitempty"` // Organizer user details (eager loaded when needed)
+	Title                string         `gorm:"type:varchar(255);not null" json:"title"`                                       // Event title (min 3 chars)
+	Description          string         `gorm:"type:text" json:"description"`                                                  // Detailed event description (optional)
+	StartDatetime        time.Time      `gorm:"not null;index" json:"start_datetime"`                                          // Event start date and time (indexed for date range queries)
+	EndDatetime          time.Time      `gorm:"not null" json:"end_datetime"`                                                  // Event end date and time
+	Location             string         `gorm:"type:varchar(255);not null" json:"location"`                                    // Event venue or location
+	Capacity             int            `gorm:"not null;check:capacity > 0" json:"capacity"`                                   // Maximum number of attendees (must be > 0)
+	Status               string         `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`                 // Event status: draft, published, postponed, completed, cancelled (indexed for filtering)
+	CategoryID           *string        `gorm:"type:uuid;index" json:"category_id"`                                            // Optional category (admin-managed taxonomy)
+	Category             *Category      `gorm:"foreignKey:CategoryID" json:"category,omitempty"`                               // Category details (eager loaded when needed)
+	Tags                 []Tag          `gorm:"many2many:event_tags" json:"tags,omitempty"`                                    // Free-form tags (via event_tags join table)
+	RequiresApproval     bool           `gorm:"not null" json:"requires_approval"`                                             // Registrations must be approved by the organizer
+	AllowTransfers       bool           `gorm:"not null" json:"allow_transfers"`                                               // Attendees may hand their seat over to someone else
+	RegistrationOpensAt  *time.Time     `json:"registration_opens_at"`                                                         // Registration opens (nil = as soon as the event is published)
+	RegistrationClosesAt *time.Time     `json:"registration_closes_at"`                                                        // Registration closes (nil = when the event starts)
+	RegistrationState    string         `gorm:"-" json:"registration_state,omitempty"`                                         // Computed: not_open, open, closed or full
+	Visibility           string         `gorm:"type:varchar(20);not null;default:'public'" json:"visibility"`                  // public, unlisted or private
+	Slug                 string         `gorm:"type:varchar(100);uniqueIndex" json:"slug"`                                     // Share link: GET /events/share/:slug
+	AccessCode           string         `gorm:"type:varchar(64)" json:"-"`                                                     // Lets people without an invitation into a private event
+	OrganizationID       *string        `gorm:"type:uuid;index" json:"organization_id"`                                        // Owning organization (optional): its admins manage the event
+	PublishAt            *time.Time     `json:"publish_at"`                                                                    // Scheduled publishing of a draft (nil = published by hand)
+	PublishClaimedUntil  *time.Time     `json:"-"`                                                                             // Lease of the scheduler replica publishing the draft (see EventRepository.ClaimDuePublications)
+	Version              int64          `gorm:"not null;default:1" json:"version"`                                             // Incremented on every change; sent as the ETag, required back in If-Match on updates
+	CreatedAt            time.Time      `gorm:"autoCreateTime" json:"created_at"`                                              // Timestamp when event was created
+	UpdatedAt            time.Time      `gorm:"autoUpdateTime" json:"updated_at"`                                              // Timestamp of last update
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`                                                                // Soft delete timestamp (null if not deleted)
 }
 
 // TableName specifies the database table name for the Event entity.
@@ -41,23 +66,41 @@ func (Event) TableName() string {
 // CreateEventRequest represents the input data for creating a new event.
 // All fields are validated using Gin's binding tags.
 type CreateEventRequest struct {
-	Title         string    `json:"title" binding:"required,min=3"`          // Event title (min 3 characters)
-	Description   string    `json:"description"`                             // Event description (optional)
-	StartDatetime time.Time `json:"start_datetime" binding:"required"`       // Event start date and time
-	EndDatetime   time.Time `json:"end_datetime" binding:"required"`         // Event end date and time
-	Location      string    `json:"location" binding:"required"`             // Event location/venue
-	Capacity      int       `json:"capacity" binding:"required,min=1"`       // Maximum attendees (min 1)
+	Title                string     `json:"title" binding:"required,min=3"`                               // Event title (min 3 characters)
+	Description          string     `json:"description"`                                                  // Event description (optional)
+	StartDatetime        time.Time  `json:"start_datetime" binding:"required"`                            // Event start date and time
+	EndDatetime          time.Time  `json:"end_datetime" binding:"required"`                              // Event end date and time
+	Location             string     `json:"location" binding:"required"`                                  // Event location/venue
+	Capacity             int        `json:"capacity" binding:"required,min=1"`                            // Maximum attendees (min 1)
+	CategoryID           *string    `json:"category_id"`                                                  // Category UUID (optional)
+	Tags                 []string   `json:"tags"`                                                         // Free-form tag names (optional)
+	RequiresApproval     bool       `json:"requires_approval"`                                            // Organizer approves each registration (optional)
+	AllowTransfers       *bool      `json:"allow_transfers"`                                              // Attendees may transfer their seats (optional, default true)
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at"`                                        // Registration opens (optional, default on publish)
+	RegistrationClosesAt *time.Time `json:"registration_closes_at"`                                       // Registration closes (optional, default at event start)
+	Visibility           string     `json:"visibility" binding:"omitempty,oneof=public unlisted private"` // public, unlisted or private (optional, default public)
+	AccessCode           string     `json:"access_code"`                                                  // Admits people without an invitation to a private event (optional)
+	OrganizationID       *string    `json:"organization_id"`                                              // Organization that owns the event; the user must belong to it (optional)
+	PublishAt            *time.Time `json:"publish_at"`                                                   // Publish the draft automatically at this time (optional)
 }
 
 // UpdateEventRequest represents the input data for updating an existing event.
 // All fields are optional pointers - only non-nil fields will be updated.
 type UpdateEventRequest struct {
-	Title         *string    `json:"title,omitempty"`          // Update event title (optional)
-	Description   *string    `json:"description,omitempty"`    // Update description (optional)
-	StartDatetime *time.Time `json:"start_datetime,omitempty"` // Update start datetime (optional)
-	EndDatetime   *time.Time `json:"end_datetime,omitempty"`   // Update end datetime (optional)
-	Location      *string    `json:"location,omitempty"`       // Update location (optional)
-	Capacity      *int       `json:"capacity,omitempty"`       // Update capacity (optional)
+	Title                *string    `json:"title,omitempty"`                  // Update event title (optional)
+	Description          *string    `json:"description,omitempty"`            // Update description (optional)
+	StartDatetime        *time.Time `json:"start_datetime,omitempty"`         // Update start datetime (optional)
+	EndDatetime          *time.Time `json:"end_datetime,omitempty"`           // Update end datetime (optional)
+	Location             *string    `json:"location,omitempty"`               // Update location (optional)
+	Capacity             *int       `json:"capacity,omitempty"`               // Update capacity (optional)
+	CategoryID           *string    `json:"category_id,omitempty"`            // Update category, empty string clears it (optional)
+	Tags                 *[]string  `json:"tags,omitempty"`                   // Replace the tag set (optional)
+	RequiresApproval     *bool      `json:"requires_approval,omitempty"`      // Toggle the approval workflow (optional)
+	AllowTransfers       *bool      `json:"allow_transfers,omitempty"`        // Toggle seat transfers (optional)
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`  // Update when registration opens (optional)
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"` // Update when registration closes (optional)
+	Visibility           *string    `json:"visibility,omitempty"`             // Change the visibility (optional)
+	AccessCode           *string    `json:"access_code,omitempty"`            // Replace the access code, empty string clears it (optional)
 }
 
 // Business Logic
@@ -67,6 +110,9 @@ type UpdateEventRequest struct {
 //   - Title must not be empty
 //   - Capacity must be at least 1
 //   - End time must be after start time
+//   - Registration must open before it closes, and close no later than the event ends
+//   - Visibility must be public, unlisted or private (defaults to public)
+//   - Access code, when set, must be 4-64 characters
 //   - Status defaults to "draft" if not set
 //
 // Returns an error if any validation rule fails.
@@ -80,12 +126,110 @@ func (e *Event) Validate() error {
 	if e.EndDatetime.Before(e.StartDatetime) {
 		return fmt.Errorf("end time must be after start time")
 	}
+	if e.RegistrationClosesAt != nil && e.RegistrationClosesAt.After(e.EndDatetime) {
+		return fmt.Errorf("registration must close before the event ends")
+	}
+	if e.RegistrationOpensAt != nil && !e.RegistrationOpensAt.Before(e.RegistrationDeadline()) {
+		return fmt.Errorf("registration must open before it closes")
+	}
+	if e.Visibility == "" {
+		e.Visibility = EventVisibilityPublic
+	}
+	if !slices.Contains(EventVisibilities, e.Visibility) {
+		return fmt.Errorf("visibility must be one of: %s", strings.Join(EventVisibilities, ", "))
+	}
+	if e.AccessCode != "" && (len(e.AccessCode) < 4 || len(e.AccessCode) > 64) {
+		return fmt.Errorf("access code must be 4-64 characters")
+	}
 	if e.Status == "" {
-		e.Status = "draft"
+		e.Status = EventStatusDraft
 	}
 	return nil
 }
 
+// Event visibility modes
+const (
+	EventVisibilityPublic   = "public"   // listed in GET /events, open to everyone
+	EventVisibilityUnlisted = "unlisted" // reachable only through the share link; anyone with the link can register
+	EventVisibilityPrivate  = "private"  // requires an invitation or the access code
+)
+
+// EventVisibilities lists the valid visibility modes
+var EventVisibilities = []string{EventVisibilityPublic, EventVisibilityUnlisted, EventVisibilityPrivate}
+
+// NewEventSlug builds the share link slug of an event: its title in URL form plus a random
+// suffix, so the link cannot be guessed from the title alone.
+func NewEventSlug(title string) string {
+	slug := Slugify(title)
+	if len(slug) > 80 {
+		slug = strings.TrimRight(slug[:80], "-")
+	}
+	suffix := make([]byte, 5)
+	if _, err := rand.Read(suffix); err != nil {
+		panic(err)
+	}
+	if slug == "" {
+		return hex.EncodeToString(suffix)
+	}
+	return slug + "-" + hex.EncodeToString(suffix)
+}
+
+// AccessCodeMatches reports whether the given code is the event's access code.
+// Events without an access code match nothing.
+func (e *Event) AccessCodeMatches(code string) bool {
+	return e.AccessCode != "" && subtle.ConstantTimeCompare([]byte(e.AccessCode), []byte(code)) == 1
+}
+
+// Registration states, computed from the registration window and the seats taken
+const (
+	RegistrationStateNotOpen = "not_open" // the window has not opened yet
+	RegistrationStateOpen    = "open"     // accepting registrations
+	RegistrationStateClosed  = "closed"   // the window has passed, or the event is not published
+	RegistrationStateFull    = "full"     // within the window, but every seat is taken
+)
+
+// RegistrationStates lists the valid values of the registration_state filter
+var RegistrationStates = []string{RegistrationStateNotOpen, RegistrationStateOpen, RegistrationStateClosed, RegistrationStateFull}
+
+// RegistrationDeadline returns when registration closes: RegistrationClosesAt, or the event start when unset.
+func (e *Event) RegistrationDeadline() time.Time {
+	if e.RegistrationClosesAt != nil {
+		return *e.RegistrationClosesAt
+	}
+	return e.StartDatetime
+}
+
+// CheckRegistrationWindow returns ErrRegistrationNotOpen or ErrRegistrationClosed when
+// registering is not possible at the given time because of the registration window.
+func (e *Event) CheckRegistrationWindow(now time.Time) error {
+	if !now.Before(e.RegistrationDeadline()) {
+		return NewCodedError(ErrRegistrationClosed.Code,
+			fmt.Sprintf("registration closed at %s", e.RegistrationDeadline().UTC().Format(time.RFC3339)))
+	}
+	if e.RegistrationOpensAt != nil && now.Before(*e.RegistrationOpensAt) {
+		return NewCodedError(ErrRegistrationNotOpen.Code,
+			fmt.Sprintf("registration opens at %s", e.RegistrationOpensAt.UTC().Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// ComputeRegistrationState works out the registration state at the given time from the number of seats taken.
+func (e *Event) ComputeRegistrationState(seatsTaken int64, now time.Time) string {
+	if e.Status != EventStatusPublished {
+		return RegistrationStateClosed
+	}
+	if err := e.CheckRegistrationWindow(now); err != nil {
+		if errors.Is(err, ErrRegistrationNotOpen) {
+			return RegistrationStateNotOpen
+		}
+		return RegistrationStateClosed
+	}
+	if seatsTaken >= int64(e.Capacity) {
+		return RegistrationStateFull
+	}
+	return RegistrationStateOpen
+}
+
 // Pagination Structures
 
 // PaginationRequest contains pagination parameters for list queries.
@@ -112,8 +256,8 @@ type EventsResponse struct {
 // All fields are optional and can be combined for complex queries.
 type EventQueryRequest struct {
 	// Pagination
-	Page     int `form:"page" binding:"omitempty,min=1"`              // Page number (default: 1)
-	PageSize int `form:"page_size" binding:"omitempty,min=1,max=20"`  // Items per page (default: 10, max: 20)
+	Page     int `form:"page" binding:"omitempty,min=1"`             // Page number (default: 1)
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=20"` // Items per page (default: 10, max: 20)
 
 	// Date filters
 	StartDateFrom *time.Time `form:"start_date_from" time_format:"2006-01-02"` // Filter events starting after this date
@@ -125,11 +269,23 @@ type EventQueryRequest struct {
 
 	// Text filters
 	Title       string `form:"title"`        // Filter by title (exact match)
-	Status      string `form:"status"`       // Filter by status: draft, published, cancelled
+	Status      string `form:"status"`       // Filter by status: draft, published, postponed, completed, cancelled
 	Location    string `form:"location"`     // Filter by location (partial match)
 	Keyword     string `form:"keyword"`      // Search in title and description (partial match)
 	OrganizerID string `form:"organizer_id"` // Filter by organizer user ID
 
+	// Set by the handler from the authenticated user (not a query parameter): besides public
+	// events, listings include the viewer's own unlisted and private events
+	ViewerID string `form:"-"`
+
+	// Registration filter
+	RegistrationState string `form:"registration_state"` // Filter by computed state: not_open, open, closed, full
+
+	// Classification filters
+	Category string `form:"category"` // Filter by category slug or ID (includes sub-categories)
+	Tags     string `form:"tags"`     // Comma-separated tag names, e.g. "go,backend"
+	TagMode  string `form:"tag_mode"` // How tags are combined: any (default) or all
+
 	// Time-based filters
 	UpcomingOnly bool `form:"upcoming_only"` // Show only future events (start_datetime > now)
 	PastOnly     bool `form:"past_only"`     // Show only past events (end_datetime < now)
@@ -139,3 +295,36 @@ type EventQueryRequest struct {
 	SortOrder string `form:"sort_order"` // Sort direction: asc, desc (default: desc)
 }
 
+// EventSortFields lists the accepted values of EventQueryRequest.SortBy
+var EventSortFields = []string{"start_date", "capacity", "created_at"}
+
+// eventSortColumns maps each sort field to the column it orders by. Only these columns ever reach
+// ORDER BY, so the query parameter can't inject SQL.
+var eventSortColumns = map[string]string{
+	"start_date": "events.start_datetime",
+	"capacity":   "events.capacity",
+	"created_at": "events.created_at",
+}
+
+// SortColumn returns the column the events are ordered by (created_at unless SortBy names another field)
+func (q *EventQueryRequest) SortColumn() string {
+	if column, ok := eventSortColumns[q.SortBy]; ok {
+		return column
+	}
+	return eventSortColumns["created_at"]
+}
+
+// Tag filter modes for EventQueryRequest.TagMode
+const (
+	TagModeAny = "any" // event has at least one of the requested tags (default)
+	TagModeAll = "all" // event has every requested tag
+)
+
+// TagList splits the comma-separated Tags filter into normalized tag names.
+func (q *EventQueryRequest) TagList() []string {
+	if q.Tags == "" {
+		return nil
+	}
+	tags, _ := NormalizeTags(strings.Split(q.Tags, ","))
+	return tags
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"io"
+	"slices"
+	"time"
+)
+
+// Kinds of event files
+const (
+	EventFileKindCover      = "cover"      // the event's cover image (one per event)
+	EventFileKindAttachment = "attachment" // downloads such as PDF agendas and maps
+)
+
+// ThumbnailSize is the longest side, in pixels, of the thumbnails generated for images
+const ThumbnailSize = 320
+
+// fileExtensions maps the accepted content types to the extension of their stored objects
+var fileExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+}
+
+// CoverImageTypes lists the content types accepted as cover images
+var CoverImageTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// AttachmentTypes lists the content types accepted as attachments
+var AttachmentTypes = []string{"image/jpeg", "image/png", "image/gif", "application/pdf"}
+
+// EventFile is a file uploaded to an event: its cover image or an attachment.
+// The content lives in the blob store under StorageKey; images also get a thumbnail.
+type EventFile struct {
+	ID           string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID      string    `gorm:"type:uuid;not null;index" json:"event_id"`
+	Kind         string    `gorm:"type:varchar(20);not null" json:"kind"`
+	Name         string    `gorm:"type:varchar(255);not null" json:"name"` // Original file name
+	ContentType  string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size         int64     `gorm:"not null" json:"size"`
+	StorageKey   string    `gorm:"type:varchar(500);not null" json:"-"`
+	ThumbnailKey string    `gorm:"type:varchar(500)" json:"-"`   // Empty for files that are not images
+	UploadedBy   *string   `gorm:"type:uuid" json:"uploaded_by"` // Nil once the uploader's account is purged
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Signed, expiring download links (in responses)
+	URL          string     `gorm:"-" json:"url,omitempty"`
+	ThumbnailURL string     `gorm:"-" json:"thumbnail_url,omitempty"`
+	URLExpiresAt *time.Time `gorm:"-" json:"url_expires_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (EventFile) TableName() string {
+	return "event_files"
+}
+
+// IsImage reports whether the file is an image (and so has a thumbnail)
+func (f *EventFile) IsImage() bool {
+	return slices.Contains(CoverImageTypes, f.ContentType)
+}
+
+// FileExtension returns the extension of stored objects of a content type
+func FileExtension(contentType string) string {
+	return fileExtensions[contentType]
+}
+
+// EventFiles lists the files of an event
+type EventFiles struct {
+	EventID     string      `json:"event_id"`
+	Cover       *EventFile  `json:"cover"`
+	Attachments []EventFile `json:"attachments"`
+}
+
+// FileUpload is a file received from a client
+type FileUpload struct {
+	Name string
+	Size int64 // As announced by the client
+	Body io.Reader
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// MaxInvitationsPerRequest limits the size of a bulk invite
+const MaxInvitationsPerRequest = 500
+
+// EventInvitation admits the owner of an email address to a private event.
+// The invited person doesn't need an account yet: the invitation applies once
+// they sign up or log in with that address.
+type EventInvitation struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_event_invitations_event_email" json:"event_id"`
+	Email     string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_event_invitations_event_email" json:"email"` // Stored lowercased
+	InvitedBy string    `gorm:"type:uuid;not null" json:"invited_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (EventInvitation) TableName() string {
+	return "event_invitations"
+}
+
+// CreateInvitationsRequest is the body of POST /events/:id/invitations.
+type CreateInvitationsRequest struct {
+	Emails []string `json:"emails" binding:"required,min=1,max=500,dive,email,max=255"`
+}
+
+// BulkInviteResult reports the outcome of a bulk invite.
+type BulkInviteResult struct {
+	Invited        []EventInvitation `json:"invited"`         // Invitations created by this request
+	AlreadyInvited []string          `json:"already_invited"` // Addresses that were invited before
+}
+
+// EventInvitations is the organizer's view of who can get into a private event.
+type EventInvitations struct {
+	Visibility  string            `json:"visibility"`
+	AccessCode  string            `json:"access_code,omitempty"`
+	ShareSlug   string            `json:"share_slug"`
+	Invitations []EventInvitation `json:"invitations"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Event team roles. The event's organizer (Event.OrganizerID) is always an owner;
+// event_members holds everyone else on the team.
+const (
+	EventRoleOwner        = "owner"         // everything, including deleting the event and managing the team
+	EventRoleCoOrganizer  = "co_organizer"  // edits and runs the event
+	EventRoleCheckInStaff = "checkin_staff" // checks in attendees at the door
+	EventRoleViewer       = "viewer"        // read-only access to registrations
+)
+
+// EventRoles lists the valid event team roles
+var EventRoles = []string{EventRoleOwner, EventRoleCoOrganizer, EventRoleCheckInStaff, EventRoleViewer}
+
+// EventAction is an operation on an event restricted to its team
+type EventAction string
+
+const (
+	EventActionView          EventAction = "view"           // see registrants, transfers and the team
+	EventActionCheckIn       EventAction = "check_in"       // check in attendees
+	EventActionManage        EventAction = "manage"         // edit, publish and cancel the event, configure tickets, forms and invitations
+	EventActionManageMembers EventAction = "manage_members" // add and remove team members
+	EventActionDelete        EventAction = "delete"         // delete the event
+	EventActionTransfer      EventAction = "transfer"       // hand the event over to another user or organization
+)
+
+var eventRoleActions = map[string][]EventAction{
+	EventRoleOwner:        {EventActionView, EventActionCheckIn, EventActionManage, EventActionManageMembers, EventActionDelete, EventActionTransfer},
+	EventRoleCoOrganizer:  {EventActionView, EventActionCheckIn, EventActionManage},
+	EventRoleCheckInStaff: {EventActionView, EventActionCheckIn},
+	EventRoleViewer:       {EventActionView},
+}
+
+// eventActionHolders describes who may perform an action, for error messages
+var eventActionHolders = map[EventAction]string{
+	EventActionView:          "the event organizers and their team",
+	EventActionCheckIn:       "the event organizers and check-in staff",
+	EventActionManage:        "the event organizers",
+	EventActionManageMembers: "the event owners",
+	EventActionDelete:        "the event owners",
+	EventActionTransfer:      "the event owners",
+}
+
+// EventRoleAllows reports whether a team role may perform an action. An empty role (not on the team) may do nothing.
+func EventRoleAllows(role string, action EventAction) bool {
+	return slices.Contains(eventRoleActions[role], action)
+}
+
+// EventRolesAllowing lists the team roles that may perform an action, for queries over many events
+func EventRolesAllowing(action EventAction) []string {
+	roles := []string{}
+	for role, actions := range eventRoleActions {
+		if slices.Contains(actions, action) {
+			roles = append(roles, role)
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// Holders describes who may perform the action, e.g. "the event organizers"
+func (a EventAction) Holders() string {
+	return eventActionHolders[a]
+}
+
+// EventMember gives a user a role on an event's team
+type EventMember struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_event_members_event_user" json:"event_id"`
+	UserID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_event_members_event_user" json:"user_id"`
+	Role      string    `gorm:"type:varchar(20);not null" json:"role"`
+	AddedBy   string    `gorm:"type:uuid;not null" json:"added_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (EventMember) TableName() string {
+	return "event_members"
+}
+
+// AddEventMemberRequest is the body of POST /events/:id/members.
+// The user is identified by the email address of their account.
+type AddEventMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner co_organizer checkin_staff viewer"`
+}
+
+// EventTeam is the team of an event: its organizer and the members added to it
+type EventTeam struct {
+	OrganizerID string        `json:"organizer_id"`
+	Members     []EventMember `json:"members"`
+}
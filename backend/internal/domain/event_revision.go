@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Event revision actions. Status changes are recorded under the new status: published, postponed, completed or cancelled.
+const (
+	EventRevisionCreated     = "created"     // created from scratch, a template or a clone
+	EventRevisionUpdated     = "updated"     // details edited
+	EventRevisionReverted    = "reverted"    // details brought back to an earlier version
+	EventRevisionScheduled   = "scheduled"   // publishing scheduled, moved or unscheduled
+	EventRevisionDeleted     = "deleted"     // deleted
+	EventRevisionRestored    = "restored"    // taken back out of the trash
+	EventRevisionTransferred = "transferred" // handed over to another organizer or organization
+)
+
+// EventRevision records one change of an event: who made it, when, and the old and new value of every field it changed.
+// Each revision carries the version of the event it produced, so an event can be brought back to any recorded version.
+type EventRevision struct {
+	ID         string        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID    string        `gorm:"type:uuid;not null;index" json:"event_id"`
+	Version    int64         `gorm:"not null" json:"version"`                            // Version of the event after the change
+	Action     string        `gorm:"type:varchar(20);not null" json:"action"`            // See the EventRevision* constants
+	ActorID    *string       `gorm:"type:uuid" json:"actor_id"`                          // nil for automatic changes
+	Changes    []FieldChange `gorm:"type:jsonb;serializer:json;not null" json:"changes"` // Fields changed, in a fixed order
+	RevertedTo *int64        `json:"reverted_to,omitempty"`                              // Version brought back by a revert
+	CreatedAt  time.Time     `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (EventRevision) TableName() string {
+	return "event_revisions"
+}
+
+// FieldChange is the old and new value of one field of an event, as JSON values: times are RFC 3339 strings
+// and null means unset. Access codes are never recorded in clear.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// RevertEventRequest is the body of POST /events/:id/revert
+type RevertEventRequest struct {
+	Version int64 `json:"version" binding:"required,min=1"` // Version to bring the event's details back to
+}
+
+// maskedAccessCode stands for an access code in revisions
+const maskedAccessCode = "********"
+
+// eventField is a field of an event recorded in revisions. Its value has the shape JSON decoding gives it back
+// (float64 numbers, []any lists), so values read from revisions compare equal to fresh ones.
+type eventField struct {
+	name string
+	get  func(e *Event) any
+	set  func(e *Event, value any) error // nil for fields a revert leaves alone
+}
+
+// eventFields lists the fields recorded in revisions, in order. Reverts leave the status and the publishing
+// schedule to the lifecycle endpoints, ownership to transfers, and can't restore an access code they never saw.
+var eventFields = []eventField{
+	{"title", func(e *Event) any { return e.Title }, setString(func(e *Event) *string { return &e.Title })},
+	{"description", func(e *Event) any { return e.Description }, setString(func(e *Event) *string { return &e.Description })},
+	{"location", func(e *Event) any { return e.Location }, setString(func(e *Event) *string { return &e.Location })},
+	{"start_datetime", func(e *Event) any { return revisionTime(&e.StartDatetime) }, func(e *Event, value any) error {
+		t, err := parseRevisionTime(value)
+		if err != nil || t == nil {
+			return fmt.Errorf("invalid start_datetime in history")
+		}
+		e.StartDatetime = *t
+		return nil
+	}},
+	{"end_datetime", func(e *Event) any { return revisionTime(&e.EndDatetime) }, func(e *Event, value any) error {
+		t, err := parseRevisionTime(value)
+		if err != nil || t == nil {
+			return fmt.Errorf("invalid end_datetime in history")
+		}
+		e.EndDatetime = *t
+		return nil
+	}},
+	{"capacity", func(e *Event) any { return float64(e.Capacity) }, func(e *Event, value any) error {
+		capacity, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("invalid capacity in history")
+		}
+		e.Capacity = int(capacity)
+		return nil
+	}},
+	{"category_id", func(e *Event) any {
+		if e.CategoryID == nil {
+			return nil
+		}
+		return *e.CategoryID
+	}, func(e *Event, value any) error {
+		e.Category = nil
+		if value == nil {
+			e.CategoryID = nil
+			return nil
+		}
+		id, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid category_id in history")
+		}
+		e.CategoryID = &id
+		return nil
+	}},
+	{"tags", func(e *Event) any {
+		names := make([]string, len(e.Tags))
+		for i, tag := range e.Tags {
+			names[i] = tag.Name
+		}
+		slices.Sort(names)
+		values := make([]any, len(names))
+		for i, name := range names {
+			values[i] = name
+		}
+		return values
+	}, func(e *Event, value any) error {
+		values, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("invalid tags in history")
+		}
+		e.Tags = make([]Tag, len(values))
+		for i, v := range values {
+			name, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("invalid tags in history")
+			}
+			e.Tags[i] = Tag{Name: name}
+		}
+		return nil
+	}},
+	{"requires_approval", func(e *Event) any { return e.RequiresApproval }, setBool(func(e *Event) *bool { return &e.RequiresApproval })},
+	{"allow_transfers", func(e *Event) any { return e.AllowTransfers }, setBool(func(e *Event) *bool { return &e.AllowTransfers })},
+	{"registration_opens_at", func(e *Event) any { return revisionTime(e.RegistrationOpensAt) }, setOptionalTime(func(e *Event) **time.Time { return &e.RegistrationOpensAt })},
+	{"registration_closes_at", func(e *Event) any { return revisionTime(e.RegistrationClosesAt) }, setOptionalTime(func(e *Event) **time.Time { return &e.RegistrationClosesAt })},
+	{"visibility", func(e *Event) any { return e.Visibility }, setString(func(e *Event) *string { return &e.Visibility })},
+	{"access_code", func(e *Event) any {
+		if e.AccessCode == "" {
+			return nil
+		}
+		return maskedAccessCode
+	}, nil},
+	{"status", func(e *Event) any { return e.Status }, nil},
+	{"publish_at", func(e *Event) any { return revisionTime(e.PublishAt) }, nil},
+	{"organizer_id", func(e *Event) any { return e.OrganizerID }, nil},
+	{"organization_id", func(e *Event) any {
+		if e.OrganizationID == nil {
+			return nil
+		}
+		return *e.OrganizationID
+	}, nil},
+}
+
+// EventFieldValues returns the values of the fields of an event recorded in revisions, by field name
+func EventFieldValues(e *Event) map[string]any {
+	values := make(map[string]any, len(eventFields))
+	for _, field := range eventFields {
+		values[field.name] = field.get(e)
+	}
+	return values
+}
+
+// DiffEvents returns the fields that differ between two states of an event, in a fixed order.
+// A nil before lists every field set at creation.
+func DiffEvents(before, after *Event) []FieldChange {
+	changes := []FieldChange{}
+	for _, field := range eventFields {
+		var old any
+		if before != nil {
+			old = field.get(before)
+		}
+		value := field.get(after)
+		// masked, a replaced access code would look unchanged: record it anyway
+		if field.name == "access_code" && before != nil && before.AccessCode != after.AccessCode && value != nil && old != nil {
+			changes = append(changes, FieldChange{Field: field.name, Old: old, New: value})
+			continue
+		}
+		if before == nil && isZeroValue(value) {
+			continue
+		}
+		if !reflect.DeepEqual(old, value) {
+			changes = append(changes, FieldChange{Field: field.name, Old: old, New: value})
+		}
+	}
+	return changes
+}
+
+// RestoreFields sets the details of an event to values taken from its history (see EventFieldValues).
+// The status, publishing schedule, ownership and access code are left as they are.
+func (e *Event) RestoreFields(values map[string]any) error {
+	for _, field := range eventFields {
+		value, ok := values[field.name]
+		if !ok || field.set == nil {
+			continue
+		}
+		if err := field.set(e, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isZeroValue reports whether a field value is unset, empty or false
+func isZeroValue(value any) bool {
+	if list, ok := value.([]any); ok {
+		return len(list) == 0
+	}
+	return value == nil || value == "" || value == false
+}
+
+// revisionTime formats a time as recorded in revisions, to the microsecond databases keep
+func revisionTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+func parseRevisionTime(value any) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("not a time")
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func setString(field func(e *Event) *string) func(e *Event, value any) error {
+	return func(e *Event, value any) error {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid text in history")
+		}
+		*field(e) = s
+		return nil
+	}
+}
+
+func setBool(field func(e *Event) *bool) func(e *Event, value any) error {
+	return func(e *Event, value any) error {
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("invalid flag in history")
+		}
+		*field(e) = b
+		return nil
+	}
+}
+
+func setOptionalTime(field func(e *Event) **time.Time) func(e *Event, value any) error {
+	return func(e *Event, value any) error {
+		t, err := parseRevisionTime(value)
+		if err != nil {
+			return fmt.Errorf("invalid time in history")
+		}
+		*field(e) = t
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RegistrationStats sums up the registrations of one or more events. Every seat counts
+// on its own, so a booking with guests adds one registration per seat.
+type RegistrationStats struct {
+	Capacity         int64   `json:"capacity"`          // Seats offered
+	Registrations    int64   `json:"registrations"`     // Seats ever booked, including the ones cancelled since
+	SeatsTaken       int64   `json:"seats_taken"`       // Seats held right now (pending payment, confirmed or checked in)
+	Cancellations    int64   `json:"cancellations"`     // Seats cancelled
+	Confirmed        int64   `json:"confirmed"`         // Seats confirmed and not checked in (yet)
+	CheckedIn        int64   `json:"checked_in"`        // Seats checked in
+	CheckInRate      float64 `json:"check_in_rate"`     // Checked in seats out of confirmed and checked in ones, in percent
+	NoShows          int64   `json:"no_shows"`          // Confirmed seats of events that have ended without being checked in
+	FillPercentage   float64 `json:"fill_percentage"`   // Seats taken out of capacity, in percent
+	PendingApprovals int64   `json:"pending_approvals"` // Applications awaiting the organizer's approval (there is no separate waitlist)
+}
+
+// SetRates computes the check-in rate and fill percentage from the counts
+func (s *RegistrationStats) SetRates() {
+	s.CheckInRate, s.FillPercentage = 0, 0
+	if attendees := s.Confirmed + s.CheckedIn; attendees > 0 {
+		s.CheckInRate = percent(s.CheckedIn, attendees)
+	}
+	if s.Capacity > 0 {
+		s.FillPercentage = percent(s.SeatsTaken, s.Capacity)
+	}
+}
+
+// percent returns part out of total in percent, rounded to one decimal
+func percent(part, total int64) float64 {
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+// DailyRegistrations is the activity of a single day (UTC)
+type DailyRegistrations struct {
+	Date          string `json:"date"`          // YYYY-MM-DD
+	Registrations int64  `json:"registrations"` // Seats booked that day
+	Cancellations int64  `json:"cancellations"` // Seats cancelled that day
+}
+
+// EventStats is the analytics dashboard of a single event
+type EventStats struct {
+	EventID string `json:"event_id"`
+	RegistrationStats
+	Daily []DailyRegistrations `json:"daily"` // Days with activity, oldest first
+}
+
+// OrganizerStatsQuery filters the events of an organizer's dashboard by start date
+type OrganizerStatsQuery struct {
+	From *time.Time `form:"from" time_format:"2006-01-02"` // Events starting on or after this day
+	To   *time.Time `form:"to" time_format:"2006-01-02"`   // Events starting on or before this day
+}
+
+// OrganizerStats is the analytics dashboard of all events a user runs: the ones they organize,
+// those of their event teams and those of their organizations
+type OrganizerStats struct {
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+	Events int64      `json:"events"` // Events included
+	RegistrationStats
+	Daily []DailyRegistrations `json:"daily"` // Days with activity, oldest first
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Event lifecycle:
+//
+//	draft ──► published ◄──► postponed
+//	  │           │              │
+//	  │           ▼              │
+//	  │       completed          │
+//	  ▼                          ▼
+//	cancelled ◄──────────────────┘ (also from published)
+//
+// completed and cancelled are final.
+const (
+	EventStatusDraft     = "draft"     // being prepared, not visible to the public
+	EventStatusPublished = "published" // live and accepting registrations
+	EventStatusPostponed = "postponed" // on hold until new dates are published; registrations are kept
+	EventStatusCompleted = "completed" // took place (set automatically once the event has ended)
+	EventStatusCancelled = "cancelled" // called off
+)
+
+// EventStatuses lists the valid event statuses
+var EventStatuses = []string{EventStatusDraft, EventStatusPublished, EventStatusPostponed, EventStatusCompleted, EventStatusCancelled}
+
+// eventStatusTransitions lists the statuses each status can move to
+var eventStatusTransitions = map[string][]string{
+	EventStatusDraft:     {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished: {EventStatusPostponed, EventStatusCompleted, EventStatusCancelled},
+	EventStatusPostponed: {EventStatusPublished, EventStatusCancelled},
+}
+
+// CanTransitionTo reports whether the event may move from its current status to the given one
+func (e *Event) CanTransitionTo(status string) bool {
+	return slices.Contains(eventStatusTransitions[e.Status], status)
+}
+
+// CheckTransition validates a status change at the given time, returning ErrInvalidStatusTransition
+// for moves the lifecycle doesn't allow. Events can only be completed once they have started.
+func (e *Event) CheckTransition(status string, now time.Time) error {
+	if !e.CanTransitionTo(status) {
+		return NewCodedError(ErrInvalidStatusTransition.Code,
+			fmt.Sprintf("cannot change event status from %s to %s", e.Status, status))
+	}
+	if status == EventStatusCompleted && now.Before(e.StartDatetime) {
+		return NewCodedError(ErrInvalidStatusTransition.Code, "an event cannot be completed before it starts")
+	}
+	return nil
+}
+
+// CheckPublishAt validates a scheduled publishing time at the given time:
+// only drafts can be scheduled, for a time in the future before the event ends.
+func (e *Event) CheckPublishAt(now time.Time) error {
+	if e.PublishAt == nil {
+		return nil
+	}
+	if e.Status != EventStatusDraft {
+		return fmt.Errorf("only draft events can be scheduled for publishing")
+	}
+	if !e.PublishAt.After(now) {
+		return fmt.Errorf("publish_at must be in the future")
+	}
+	if !e.PublishAt.Before(e.EndDatetime) {
+		return fmt.Errorf("publish_at must be before the event ends")
+	}
+	return nil
+}
+
+// SchedulePublishRequest is the body of PUT /events/:id/publish-schedule
+type SchedulePublishRequest struct {
+	PublishAt time.Time `json:"publish_at" binding:"required"`
+}
+
+// EventStatusChange records one step of an event's lifecycle
+type EventStatusChange struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID    string    `gorm:"type:uuid;not null;index" json:"event_id"`
+	FromStatus string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedBy  *string   `gorm:"type:uuid" json:"changed_by"` // nil for automatic changes
+	Reason     string    `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (EventStatusChange) TableName() string {
+	return "event_status_changes"
+}
+
+// PostponeEventRequest is the optional body of POST /events/:id/postpone
+type PostponeEventRequest struct {
+	Reason string `json:"reason" binding:"max=500"` // Shown in the status history (optional)
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CloneEventRequest is the body of POST /events/:id/clone.
+// Every date of the event and its configuration moves by the same offset as the start.
+type CloneEventRequest struct {
+	StartDatetime time.Time `json:"start_datetime" binding:"required"` // Start of the new event
+	Title         string    `json:"title" binding:"omitempty,min=3"`   // Title of the new event (optional, default the source title)
+}
+
+// Clone builds a new draft (without an ID) of the event for the given organizer, starting at start.
+// An empty title keeps the event's title. The registration window keeps its position relative to the start; the clone gets its own
+// share link and no publishing schedule. Tags are shared, everything else attached to the
+// event (tickets, promo codes, forms, policies, the agenda) is copied by the repository.
+func (e *Event) Clone(organizerID, title string, start time.Time) *Event {
+	if title == "" {
+		title = e.Title
+	}
+	offset := start.Sub(e.StartDatetime)
+	return &Event{
+		OrganizerID:          organizerID,
+		Title:                title,
+		Description:          e.Description,
+		StartDatetime:        start,
+		EndDatetime:          e.EndDatetime.Add(offset),
+		Location:             e.Location,
+		Capacity:             e.Capacity,
+		Status:               EventStatusDraft,
+		CategoryID:           e.CategoryID,
+		Tags:                 e.Tags,
+		RequiresApproval:     e.RequiresApproval,
+		AllowTransfers:       e.AllowTransfers,
+		RegistrationOpensAt:  ShiftTime(e.RegistrationOpensAt, offset),
+		RegistrationClosesAt: ShiftTime(e.RegistrationClosesAt, offset),
+		Visibility:           e.Visibility,
+		Slug:                 NewEventSlug(title),
+		AccessCode:           e.AccessCode,
+		OrganizationID:       e.OrganizationID,
+		Version:              1,
+	}
+}
+
+// ShiftTime moves an optional time by offset; nil stays nil
+func ShiftTime(t *time.Time, offset time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.Add(offset)
+	return &shifted
+}
+
+// EventTemplate is a saved set of event details an organizer reuses to create similar events.
+// Templates are private to the organizer who saved them.
+type EventTemplate struct {
+	ID               string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	OrganizerID      string    `gorm:"type:uuid;not null;index" json:"organizer_id"`
+	Name             string    `gorm:"type:varchar(100);not null" json:"name"`          // Template name shown to the organizer
+	Title            string    `gorm:"type:varchar(255);not null" json:"title"`         // Title of the events created from it
+	Description      string    `gorm:"type:text" json:"description"`                    // Event description
+	Location         string    `gorm:"type:varchar(255);not null" json:"location"`      // Event venue or location
+	Capacity         int       `gorm:"not null" json:"capacity"`                        // Maximum number of attendees
+	DurationMinutes  int       `gorm:"not null" json:"duration_minutes"`                // Length of the event; the end is start + duration
+	CategoryID       *string   `gorm:"type:uuid" json:"category_id"`                    // Optional category
+	Tags             []string  `gorm:"type:jsonb;serializer:json;not null" json:"tags"` // Tag names
+	RequiresApproval bool      `gorm:"not null" json:"requires_approval"`               // Registrations must be approved
+	AllowTransfers   bool      `gorm:"not null" json:"allow_transfers"`                 // Attendees may transfer their seats
+	Visibility       string    `gorm:"type:varchar(20);not null" json:"visibility"`     // public, unlisted or private
+	OrganizationID   *string   `gorm:"type:uuid" json:"organization_id"`                // Organization the events are created for (optional)
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (EventTemplate) TableName() string {
+	return "event_templates"
+}
+
+// Validate performs business rule validation on the EventTemplate entity.
+// Tags are normalized and visibility defaults to public.
+func (t *EventTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(strings.TrimSpace(t.Title)) < 3 {
+		return fmt.Errorf("title must be at least 3 characters")
+	}
+	if strings.TrimSpace(t.Location) == "" {
+		return fmt.Errorf("location is required")
+	}
+	if t.Capacity < 1 {
+		return fmt.Errorf("capacity must be at least 1")
+	}
+	if t.DurationMinutes < 1 {
+		return fmt.Errorf("duration must be at least 1 minute")
+	}
+	if t.Visibility == "" {
+		t.Visibility = EventVisibilityPublic
+	}
+	if !slices.Contains(EventVisibilities, t.Visibility) {
+		return fmt.Errorf("visibility must be one of: %s", strings.Join(EventVisibilities, ", "))
+	}
+	tags, err := NormalizeTags(t.Tags)
+	if err != nil {
+		return err
+	}
+	t.Tags = tags
+	return nil
+}
+
+// EventRequest pre-fills a CreateEventRequest from the template for an event starting at start
+func (t *EventTemplate) EventRequest(start time.Time) *CreateEventRequest {
+	allowTransfers := t.AllowTransfers
+	return &CreateEventRequest{
+		Title:            t.Title,
+		Description:      t.Description,
+		StartDatetime:    start,
+		EndDatetime:      start.Add(time.Duration(t.DurationMinutes) * time.Minute),
+		Location:         t.Location,
+		Capacity:         t.Capacity,
+		CategoryID:       t.CategoryID,
+		Tags:             slices.Clone(t.Tags),
+		RequiresApproval: t.RequiresApproval,
+		AllowTransfers:   &allowTransfers,
+		Visibility:       t.Visibility,
+		OrganizationID:   t.OrganizationID,
+	}
+}
+
+// DTOs
+
+// CreateEventTemplateRequest represents the input for saving an event template
+type CreateEventTemplateRequest struct {
+	Name             string   `json:"name" binding:"required,max=100"`
+	Title            string   `json:"title" binding:"required,min=3"`
+	Description      string   `json:"description"`
+	Location         string   `json:"location" binding:"required"`
+	Capacity         int      `json:"capacity" binding:"required,min=1"`
+	DurationMinutes  int      `json:"duration_minutes" binding:"required,min=1"`
+	CategoryID       *string  `json:"category_id"`
+	Tags             []string `json:"tags"`
+	RequiresApproval bool     `json:"requires_approval"`
+	AllowTransfers   *bool    `json:"allow_transfers"` // Default true
+	Visibility       string   `json:"visibility" binding:"omitempty,oneof=public unlisted private"`
+	OrganizationID   *string  `json:"organization_id"`
+}
+
+// UpdateEventTemplateRequest represents the input for updating an event template.
+// Only non-nil fields are updated; empty strings clear the category and organization.
+type UpdateEventTemplateRequest struct {
+	Name             *string   `json:"name,omitempty"`
+	Title            *string   `json:"title,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	Location         *string   `json:"location,omitempty"`
+	Capacity         *int      `json:"capacity,omitempty"`
+	DurationMinutes  *int      `json:"duration_minutes,omitempty"`
+	CategoryID       *string   `json:"category_id,omitempty"`
+	Tags             *[]string `json:"tags,omitempty"`
+	RequiresApproval *bool     `json:"requires_approval,omitempty"`
+	AllowTransfers   *bool     `json:"allow_transfers,omitempty"`
+	Visibility       *string   `json:"visibility,omitempty"`
+	OrganizationID   *string   `json:"organization_id,omitempty"`
+}
+
+// CreateEventFromTemplateRequest is the body of POST /event-templates/:id/events
+type CreateEventFromTemplateRequest struct {
+	StartDatetime time.Time `json:"start_datetime" binding:"required"` // Start of the new event
+	Title         string    `json:"title" binding:"omitempty,min=3"`   // Overrides the template title (optional)
+}
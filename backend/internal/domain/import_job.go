@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxImportRows limits the rows (besides the header) of one CSV import
+const MaxImportRows = 1000
+
+// Import kinds
+const (
+	ImportKindEvents      = "events"      // events created from the rows, as drafts of the importing user
+	ImportKindRegistrants = "registrants" // existing users registered for an event
+)
+
+// Import job statuses
+const (
+	ImportStatusPending   = "pending"   // accepted, waiting to run
+	ImportStatusRunning   = "running"   // rows being validated and imported
+	ImportStatusCompleted = "completed" // every row was processed; FailedRows counts those that failed on import
+	ImportStatusFailed    = "failed"    // the validation pass found errors (or the job broke off), so nothing was imported
+)
+
+// ImportRowError is a problem with one row of an imported file. Rows are numbered as in a
+// spreadsheet: the header is row 1, the first data row row 2.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportReport is the outcome of the validation pass over an imported file (the dry-run result)
+type ImportReport struct {
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// ImportJob tracks an import running in the background. The rows are validated first: if any row
+// is invalid the job fails with the row errors and nothing is imported. Otherwise every row is
+// imported; rows that still fail then (e.g. the event filled up meanwhile) are reported as errors
+// without stopping the others.
+type ImportJob struct {
+	ID            string           `gorm:"type:uuid;primaryKey" json:"id"`
+	Kind          string           `gorm:"type:varchar(20);not null" json:"kind"`
+	EventID       *string          `gorm:"type:uuid" json:"event_id,omitempty"` // Event the registrants are imported into
+	UserID        string           `gorm:"type:uuid;not null" json:"user_id"`
+	Status        string           `gorm:"type:varchar(20);not null" json:"status"`
+	TotalRows     int              `gorm:"not null;default:0" json:"total_rows"`
+	ProcessedRows int              `gorm:"not null;default:0" json:"processed_rows"`
+	ImportedRows  int              `gorm:"not null;default:0" json:"imported_rows"`
+	FailedRows    int              `gorm:"not null;default:0" json:"failed_rows"`
+	Errors        []ImportRowError `gorm:"type:jsonb;serializer:json" json:"errors"`
+	CreatedIDs    []string         `gorm:"type:jsonb;serializer:json" json:"created_ids"` // IDs of the created events or registrations
+	Error         string           `gorm:"type:text" json:"error,omitempty"`              // Why the job broke off, for failures not tied to a row
+	CreatedAt     time.Time        `json:"created_at"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// EventImportRow is a row of an event import, parsed into the request creating the event.
+// Err is set instead when the row could not be parsed.
+type EventImportRow struct {
+	Row     int
+	Request *CreateEventRequest
+	Err     error
+}
+
+// RegistrantImportRow is a row of a registrant import
+type RegistrantImportRow struct {
+	Row        int
+	Email      string // lowercased
+	TicketType string // name of the ticket type (optional when the event has at most one)
+	Err        error
+}
+
+// EventImportColumns lists the columns of an event import; the first five are required
+var EventImportColumns = []string{
+	"title", "start_datetime", "end_datetime", "location", "capacity",
+	"description", "category_id", "tags", "requires_approval", "allow_transfers",
+	"registration_opens_at", "registration_closes_at", "visibility", "access_code", "publish_at",
+}
+
+// RegistrantImportColumns lists the columns of a registrant import; email is required
+var RegistrantImportColumns = []string{"email", "ticket_type"}
+
+// ParseEventImport reads an event import CSV file. Times are RFC 3339, tags are separated by
+// semicolons and empty cells leave optional fields unset. Problems with the file itself (bad
+// header, too many rows) fail the whole import; problems with a row are reported on the row.
+func ParseEventImport(r io.Reader) ([]EventImportRow, error) {
+	var rows []EventImportRow
+	err := readImport(r, EventImportColumns, 5, func(row int, cell func(string) string) {
+		req, err := parseEventRow(cell)
+		rows = append(rows, EventImportRow{Row: row, Request: req, Err: err})
+	})
+	return rows, err
+}
+
+// ParseRegistrantImport reads a registrant import CSV file: one attendee per row, identified by
+// the email address of their account
+func ParseRegistrantImport(r io.Reader) ([]RegistrantImportRow, error) {
+	var rows []RegistrantImportRow
+	err := readImport(r, RegistrantImportColumns, 1, func(row int, cell func(string) string) {
+		parsed := RegistrantImportRow{Row: row, Email: strings.ToLower(cell("email")), TicketType: cell("ticket_type")}
+		if parsed.Email == "" {
+			parsed.Err = fmt.Errorf("email is required")
+		} else if _, err := mail.ParseAddress(parsed.Email); err != nil {
+			parsed.Err = fmt.Errorf("email %q is not a valid address", parsed.Email)
+		}
+		rows = append(rows, parsed)
+	})
+	return rows, err
+}
+
+// readImport reads the header of a CSV file, checking it against the known columns (the first
+// required of them must be present), and passes each data row to fn with a lookup of its trimmed cells
+func readImport(r io.Reader, columns []string, required int, fn func(row int, cell func(string) string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("the file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid CSV: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(columns, name) {
+			return fmt.Errorf("unknown column %q (expected: %s)", name, strings.Join(columns, ", "))
+		}
+		if _, ok := index[name]; ok {
+			return fmt.Errorf("column %q appears twice", name)
+		}
+		index[name] = i
+	}
+	for _, name := range columns[:required] {
+		if _, ok := index[name]; !ok {
+			return fmt.Errorf("required column %q is missing", name)
+		}
+	}
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV: %w", err)
+		}
+		if row-1 > MaxImportRows {
+			return fmt.Errorf("the file has more than %d rows", MaxImportRows)
+		}
+		fn(row, func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		})
+	}
+	return nil
+}
+
+// parseEventRow builds the create request of an event import row
+func parseEventRow(cell func(string) string) (*CreateEventRequest, error) {
+	req := &CreateEventRequest{
+		Title:       cell("title"),
+		Description: cell("description"),
+		Location:    cell("location"),
+		Visibility:  cell("visibility"),
+		AccessCode:  cell("access_code"),
+	}
+	if req.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if len(req.Title) < 3 {
+		return nil, fmt.Errorf("title must be at least 3 characters")
+	}
+	if req.Location == "" {
+		return nil, fmt.Errorf("location is required")
+	}
+
+	var err error
+	if req.StartDatetime, err = parseImportTime(cell, "start_datetime", true); err != nil {
+		return nil, err
+	}
+	if req.EndDatetime, err = parseImportTime(cell, "end_datetime", true); err != nil {
+		return nil, err
+	}
+	for _, optional := range []struct {
+		name  string
+		field **time.Time
+	}{
+		{"registration_opens_at", &req.RegistrationOpensAt},
+		{"registration_closes_at", &req.RegistrationClosesAt},
+		{"publish_at", &req.PublishAt},
+	} {
+		value, err := parseImportTime(cell, optional.name, false)
+		if err != nil {
+			return nil, err
+		}
+		if !value.IsZero() {
+			*optional.field = &value
+		}
+	}
+
+	if req.Capacity, err = strconv.Atoi(cell("capacity")); err != nil {
+		return nil, fmt.Errorf("capacity must be a whole number")
+	}
+	if value := cell("requires_approval"); value != "" {
+		if req.RequiresApproval, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("requires_approval must be true or false")
+		}
+	}
+	if value := cell("allow_transfers"); value != "" {
+		allow, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("allow_transfers must be true or false")
+		}
+		req.AllowTransfers = &allow
+	}
+	if value := cell("category_id"); value != "" {
+		req.CategoryID = &value
+	}
+	for _, tag := range strings.Split(cell("tags"), ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.Tags = append(req.Tags, tag)
+		}
+	}
+	return req, nil
+}
+
+// parseImportTime parses an RFC 3339 time cell; optional cells may be empty (the zero time)
+func parseImportTime(cell func(string) string, name string, required bool) (time.Time, error) {
+	value := cell(name)
+	if value == "" {
+		if required {
+			return time.Time{}, fmt.Errorf("%s is required", name)
+		}
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time, e.g. 2026-05-01T18:00:00Z", name)
+	}
+	return t, nil
+}
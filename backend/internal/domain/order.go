@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusFailed    = "failed"
+	OrderStatusExpired   = "expired"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+// Order is a purchase of seats of a paid ticket type.
+// Its registrations hold their seats as "pending_payment" until the payment
+// provider confirms the payment through a signed webhook, or the hold expires.
+type Order struct {
+	ID                string     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	UserID            string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	EventID           string     `gorm:"type:uuid;not null;index" json:"event_id"`
+	TicketTypeID      string     `gorm:"type:uuid;not null" json:"ticket_type_id"`
+	Quantity          int        `gorm:"not null;default:1" json:"quantity"`                        // Seats bought
+	AmountCents       int64      `gorm:"not null" json:"amount_cents"`                              // Total to pay in minor units (after discount)
+	DiscountCents     int64      `gorm:"not null;default:0" json:"discount_cents"`                  // Promo code discount in minor units
+	PromoCodeID       *string    `gorm:"type:uuid" json:"promo_code_id"`                            // Promo code applied to the order
+	RefundedCents     int64      `gorm:"not null;default:0" json:"refunded_cents"`                  // Total refunded so far
+	RefundIssuedCents int64      `gorm:"not null;default:0" json:"-"`                               // Part of the refunded total already sent to the provider
+	Currency          string     `gorm:"type:char(3);not null;default:'USD'" json:"currency"`       // ISO 4217 currency code
+	Status            string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // "pending", "paid", "failed", "expired", "cancelled", "refunded"
+	Provider          string     `gorm:"type:varchar(50);not null" json:"provider"`                 // Payment provider name
+	ProviderIntentID  *string    `gorm:"type:varchar(255);uniqueIndex" json:"provider_intent_id"`   // Provider-side payment intent
+	ClientSecret      string     `gorm:"-" json:"client_secret,omitempty"`                          // Returned once at checkout, never stored
+	ExpiresAt         time.Time  `gorm:"not null" json:"expires_at"`                                // Seats are released after this time if unpaid
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Order) TableName() string {
+	return "orders"
+}
+
+// IsExpired reports whether an unpaid order's seat hold has lapsed at the given time.
+func (o *Order) IsExpired(now time.Time) bool {
+	return o.Status == OrderStatusPending && !now.Before(o.ExpiresAt)
+}
+
+// OutstandingRefundCents returns the refunds recorded on the order but not yet sent to the provider.
+func (o *Order) OutstandingRefundCents() int64 {
+	return o.RefundedCents - o.RefundIssuedCents
+}
+
+// SeatPriceCents returns the amount paid for a single seat of the order.
+func (o *Order) SeatPriceCents() int64 {
+	if o.Quantity <= 1 {
+		return o.AmountCents
+	}
+	return o.AmountCents / int64(o.Quantity)
+}
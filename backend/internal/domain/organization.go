@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Organization is a team that owns events, so they outlive the people who created them.
+// Its admins manage all of its events; its members can view them.
+type Organization struct {
+	ID          string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"` // Unique identifier
+	Name        string    `gorm:"type:varchar(100);not null" json:"name"`                    // Display name
+	Slug        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"slug"`        // Public profile: GET /organizations/:slug
+	Description string    `gorm:"type:text" json:"description"`                              // Optional description
+	CreatedBy   *string   `gorm:"type:uuid" json:"created_by"`                               // User who created the organization, nil once their account is purged
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// Organization roles
+const (
+	OrganizationRoleAdmin  = "admin"  // manages the organization, its members and all of its events
+	OrganizationRoleMember = "member" // creates events for the organization and views all of them
+)
+
+// OrganizationMember gives a user a role in an organization
+type OrganizationMember struct {
+	ID             string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	OrganizationID string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user" json:"organization_id"`
+	UserID         string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user" json:"user_id"`
+	Role           string    `gorm:"type:varchar(20);not null" json:"role"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// OrganizationEventRole returns the role an organization role grants on each of the organization's events,
+// or "" for users outside the organization
+func OrganizationEventRole(orgRole string) string {
+	switch orgRole {
+	case OrganizationRoleAdmin:
+		return EventRoleOwner
+	case OrganizationRoleMember:
+		return EventRoleViewer
+	}
+	return ""
+}
+
+// Validate performs business rule validation on the Organization entity.
+// An empty slug is derived from the name.
+func (o *Organization) Validate() error {
+	o.Name = strings.TrimSpace(o.Name)
+	if o.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if o.Slug == "" {
+		o.Slug = Slugify(o.Name)
+	}
+	if !slugRegex.MatchString(o.Slug) {
+		return fmt.Errorf("slug may only contain lowercase letters, digits and dashes")
+	}
+	return nil
+}
+
+// DTOs
+
+// CreateOrganizationRequest represents the input for creating an organization.
+// The creator becomes its first admin.
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Slug        string `json:"slug" binding:"omitempty,max=100"` // Derived from name when empty
+	Description string `json:"description"`
+}
+
+// UpdateOrganizationRequest represents the input for updating an organization (admins only).
+// Only non-nil fields are updated.
+type UpdateOrganizationRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// AddOrganizationMemberRequest is the body of POST /organizations/:slug/members.
+// The user is identified by the email address of their account.
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=admin member"`
+}
+
+// OrganizationProfile is the public profile page of an organization
+type OrganizationProfile struct {
+	Organization Organization `json:"organization"`
+	Events       []Event      `json:"events"` // Upcoming public events, soonest first
+}
+
+// TransferEventOwnershipRequest is the body of POST /events/:id/owner.
+// Exactly one of the fields is set: the event goes to a user or to an organization.
+type TransferEventOwnershipRequest struct {
+	Email          string `json:"email" binding:"omitempty,email"` // New organizer; the event leaves its organization
+	OrganizationID string `json:"organization_id"`                 // New owning organization; the organizer stays
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Discount types
+const (
+	DiscountTypePercent = "percent"
+	DiscountTypeFixed   = "fixed"
+)
+
+// PromoCode is an organizer-defined discount code for an event.
+// A code is used up by active registrations only (including applications awaiting approval):
+// when a registration is cancelled, rejected or its payment hold expires, the redemption
+// no longer counts against the limits.
+type PromoCode struct {
+	ID             string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID        string         `gorm:"type:uuid;not null;index" json:"event_id"`
+	Code           string         `gorm:"type:varchar(50);not null" json:"code"`                 // Upper-cased, matched case-insensitively
+	DiscountType   string         `gorm:"type:varchar(10);not null" json:"discount_type"`        // "percent" or "fixed"
+	DiscountValue  int64          `gorm:"not null" json:"discount_value"`                        // Percent (1-100) or amount in minor units
+	MaxRedemptions *int           `json:"max_redemptions"`                                       // Total uses (nil = unlimited)
+	PerUserLimit   int            `gorm:"not null;default:1" json:"per_user_limit"`              // Uses per user
+	ValidFrom      *time.Time     `json:"valid_from"`                                            // Usable from (nil = immediately)
+	ValidUntil     *time.Time     `json:"valid_until"`                                           // Usable until (nil = no end)
+	Active         bool           `gorm:"not null;default:true" json:"active"`                   // Disabled codes cannot be redeemed
+	TicketTypes    []TicketType   `gorm:"many2many:promo_code_ticket_types" json:"ticket_types"` // Restriction (empty = all ticket types)
+	Redemptions    int64          `gorm:"-" json:"redemptions"`                                  // Active redemptions (computed)
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (PromoCode) TableName() string {
+	return "promo_codes"
+}
+
+// PromoRedemption records the use of a promo code by a registration
+type PromoRedemption struct {
+	ID             string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	PromoCodeID    string    `gorm:"type:uuid;not null;index" json:"promo_code_id"`
+	UserID         string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	RegistrationID string    `gorm:"type:uuid;not null" json:"registration_id"`
+	OrderID        *string   `gorm:"type:uuid" json:"order_id"`
+	DiscountCents  int64     `gorm:"not null" json:"discount_cents"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PromoRedemption) TableName() string {
+	return "promo_redemptions"
+}
+
+// DTOs
+
+// CreatePromoCodeRequest represents the input for creating a promo code.
+type CreatePromoCodeRequest struct {
+	Code           string     `json:"code" binding:"required,min=3,max=50"`
+	DiscountType   string     `json:"discount_type" binding:"required,oneof=percent fixed"`
+	DiscountValue  int64      `json:"discount_value" binding:"required,min=1"`
+	MaxRedemptions *int       `json:"max_redemptions" binding:"omitempty,min=1"`
+	PerUserLimit   int        `json:"per_user_limit" binding:"omitempty,min=1"` // Defaults to 1
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	TicketTypeIDs  []string   `json:"ticket_type_ids"` // Restrict to these ticket types (empty = all)
+}
+
+// UpdatePromoCodeRequest represents the input for updating a promo code.
+// Only non-nil fields are updated.
+type UpdatePromoCodeRequest struct {
+	DiscountType   *string    `json:"discount_type,omitempty"`
+	DiscountValue  *int64     `json:"discount_value,omitempty"`
+	MaxRedemptions *int       `json:"max_redemptions,omitempty"`
+	PerUserLimit   *int       `json:"per_user_limit,omitempty"`
+	ValidFrom      *time.Time `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time `json:"valid_until,omitempty"`
+	Active         *bool      `json:"active,omitempty"`
+	TicketTypeIDs  *[]string  `json:"ticket_type_ids,omitempty"` // Replaces the restriction; empty list = all ticket types
+}
+
+// PromoCodeStats summarizes the usage of a promo code.
+type PromoCodeStats struct {
+	PromoCodeID          string `json:"promo_code_id"`
+	Code                 string `json:"code"`
+	Redemptions          int64  `json:"redemptions"`           // Active redemptions (pending or confirmed)
+	ConfirmedRedemptions int64  `json:"confirmed_redemptions"` // Redemptions with a confirmed or checked-in seat
+	PendingRedemptions   int64  `json:"pending_redemptions"`   // Redemptions awaiting approval or payment
+	ReleasedRedemptions  int64  `json:"released_redemptions"`  // Redemptions of cancelled, rejected or expired registrations
+	UniqueUsers          int64  `json:"unique_users"`
+	TotalDiscountCents   int64  `json:"total_discount_cents"` // Discount granted to active redemptions
+	Remaining            *int64 `json:"remaining"`            // Uses left (nil = unlimited)
+}
+
+// Business Logic
+
+var promoCodeRegex = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// NormalizePromoCode upper-cases and trims a code so lookups are case-insensitive.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate performs business rule validation on the PromoCode entity.
+func (p *PromoCode) Validate() error {
+	p.Code = NormalizePromoCode(p.Code)
+	if !promoCodeRegex.MatchString(p.Code) {
+		return fmt.Errorf("code must be 3-50 characters of letters, digits, '-' or '_'")
+	}
+	switch p.DiscountType {
+	case DiscountTypePercent:
+		if p.DiscountValue < 1 || p.DiscountValue > 100 {
+			return fmt.Errorf("percent discount must be between 1 and 100")
+		}
+	case DiscountTypeFixed:
+		if p.DiscountValue < 1 {
+			return fmt.Errorf("fixed discount must be positive")
+		}
+	default:
+		return fmt.Errorf("discount_type must be 'percent' or 'fixed'")
+	}
+	if p.MaxRedemptions != nil && *p.MaxRedemptions < 1 {
+		return fmt.Errorf("max_redemptions must be at least 1")
+	}
+	if p.PerUserLimit == 0 {
+		p.PerUserLimit = 1
+	}
+	if p.PerUserLimit < 1 {
+		return fmt.Errorf("per_user_limit must be at least 1")
+	}
+	if p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidUntil.After(*p.ValidFrom) {
+		return fmt.Errorf("valid_until must be after valid_from")
+	}
+	return nil
+}
+
+// CheckUsable returns an error if the code cannot be redeemed at the given time
+// for the given ticket type. Redemption limits are checked separately.
+func (p *PromoCode) CheckUsable(now time.Time, ticketTypeID string) error {
+	if !p.Active {
+		return fmt.Errorf("promo code is not active")
+	}
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return fmt.Errorf("promo code is not valid yet")
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return fmt.Errorf("promo code has expired")
+	}
+	if len(p.TicketTypes) > 0 {
+		for _, ticketType := range p.TicketTypes {
+			if ticketType.ID == ticketTypeID {
+				return nil
+			}
+		}
+		return fmt.Errorf("promo code does not apply to this ticket type")
+	}
+	return nil
+}
+
+// Discount returns the discount in minor units for the given price, never exceeding it.
+func (p *PromoCode) Discount(priceCents int64) int64 {
+	var discount int64
+	if p.DiscountType == DiscountTypePercent {
+		discount = priceCents * p.DiscountValue / 100
+	} else {
+		discount = p.DiscountValue
+	}
+	return min(discount, priceCents)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RegistrantRecord is a seat of an event as exported for its organizers: who holds it, its status,
+// when it was booked and checked in, and the answers to the registration form. Guest seats carry
+// the guest's name and email, with the account that booked them in BookedBy.
+type RegistrantRecord struct {
+	RegistrationID string      `json:"registration_id"`
+	Name           string      `json:"name"`
+	Email          string      `json:"email"`
+	IsGuest        bool        `json:"is_guest"`
+	BookedBy       string      `json:"booked_by"` // Email of the account the registration belongs to
+	Status         string      `json:"status"`
+	TicketType     string      `json:"ticket_type,omitempty"`
+	RegisteredAt   time.Time   `json:"registered_at"`
+	CheckedInAt    *time.Time  `json:"checked_in_at"`
+	Answers        FormAnswers `json:"answers"`
+}
+
+// registrantExportColumns are the columns of a registrant export before the form questions
+var registrantExportColumns = []string{
+	"registration_id", "name", "email", "is_guest", "booked_by", "status", "ticket_type", "registered_at", "checked_in_at",
+}
+
+// RegistrantExportColumns returns the header of a tabular registrant export: the fixed columns,
+// then one column per question of the registration form, named by its label
+func RegistrantExportColumns(questions []FormQuestion) []string {
+	columns := append([]string{}, registrantExportColumns...)
+	for _, question := range questions {
+		columns = append(columns, question.Label)
+	}
+	return columns
+}
+
+// Cells returns the record as a row of a tabular export with the given form questions.
+// Times are RFC 3339 in UTC; multi choice answers are joined with "; ".
+func (r *RegistrantRecord) Cells(questions []FormQuestion) []string {
+	cells := []string{
+		r.RegistrationID, r.Name, r.Email, strconv.FormatBool(r.IsGuest), r.BookedBy, r.Status, r.TicketType,
+		r.RegisteredAt.UTC().Format(time.RFC3339), "",
+	}
+	if r.CheckedInAt != nil {
+		cells[8] = r.CheckedInAt.UTC().Format(time.RFC3339)
+	}
+	for _, question := range questions {
+		cells = append(cells, formatAnswer(r.Answers[question.ID]))
+	}
+	return cells
+}
+
+// formatAnswer renders an answer as text (empty when the question was not answered)
+func formatAnswer(answer interface{}) string {
+	switch value := answer.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, len(value))
+		for i, part := range value {
+			parts[i] = formatAnswer(part)
+		}
+		return strings.Join(parts, "; ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
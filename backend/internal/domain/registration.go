@@ -1,25 +1,111 @@
 package domain
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Registration entity
+// Registration statuses
+const (
+	RegistrationStatusPending        = "pending" // Awaiting organizer approval
+	RegistrationStatusRejected       = "rejected"
+	RegistrationStatusPendingPayment = "pending_payment"
+	RegistrationStatusConfirmed      = "confirmed"
+	RegistrationStatusCancelled      = "cancelled"
+	RegistrationStatusCheckedIn      = "checked_in"
+	RegistrationStatusExpired        = "expired"
+)
+
+// SeatHoldingStatuses lists the registration statuses that occupy a seat
+// and therefore count against event capacity and ticket type stock.
+// Seats awaiting payment are held until the order is paid or expires.
+var SeatHoldingStatuses = []string{
+	RegistrationStatusPendingPayment,
+	RegistrationStatusConfirmed,
+	RegistrationStatusCheckedIn,
+}
+
+// ActiveRegistrationStatuses lists the statuses of registrations that are still in play:
+// seat-holding ones plus applications awaiting approval (which hold no seat).
+// A user can have at most one active registration of their own per event (guest seats aside).
+var ActiveRegistrationStatuses = append([]string{RegistrationStatusPending}, SeatHoldingStatuses...)
+
+// MaxSeatsPerBooking limits how many seats (the booking user's own plus guests) one registration request can book
+const MaxSeatsPerBooking = 10
+
+// Registration entity.
+// Every seat is a registration of its own: a booking of several seats stores the booking user's
+// seat plus one guest seat per additional attendee, all sharing a GroupID and the same order.
 type Registration struct {
-	ID        string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
-	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
-	User      *User          `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
-	EventID   string         `gorm:"type:uuid;not null;index" json:"event_id"`
-	Event     *Event         `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"event,omitempty"`
-	Status    string         `gorm:"type:varchar(20);not null;default:'confirmed'" json:"status"` // "confirmed", "cancelled", "checked_in"
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           string             `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	UserID       string             `gorm:"type:uuid;not null;index" json:"user_id"`
+	User         *User              `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	EventID      string             `gorm:"type:uuid;not null;index" json:"event_id"`
+	Event        *Event             `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"event,omitempty"`
+	TicketTypeID *string            `gorm:"type:uuid;index" json:"ticket_type_id"`                       // Ticket tier bought (nil for events without tiers)
+	TicketType   *TicketType        `gorm:"foreignKey:TicketTypeID" json:"ticket_type,omitempty"`        // Ticket tier details (eager loaded when needed)
+	OrderID      *string            `gorm:"type:uuid;index" json:"order_id"`                             // Order paying for this seat (nil for free tickets)
+	Order        *Order             `gorm:"foreignKey:OrderID" json:"order,omitempty"`                   // Payment details (set while checkout is pending)
+	Status       string             `gorm:"type:varchar(20);not null;default:'confirmed'" json:"status"` // "pending", "rejected", "pending_payment", "confirmed", "cancelled", "checked_in", "expired"
+	ReviewedBy   *string            `gorm:"type:uuid" json:"reviewed_by,omitempty"`                      // Organizer who approved or rejected the application
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`                                       // When the application was reviewed
+	ReviewReason string             `gorm:"type:text" json:"review_reason,omitempty"`                    // Optional reason given on review
+	Answers      FormAnswers        `gorm:"type:jsonb;serializer:json" json:"answers,omitempty"`         // Answers to the event's registration form
+	RefundCents  int64              `gorm:"not null;default:0" json:"refund_cents"`                      // Amount refunded on cancellation (paid tickets)
+	CancelledAt  *time.Time         `json:"cancelled_at"`                                                // When the registration was cancelled
+	CheckedInAt  *time.Time         `json:"checked_in_at,omitempty"`                                     // When the seat was checked in
+	GroupID      *string            `gorm:"type:uuid;index" json:"group_id,omitempty"`                   // Booking the seat belongs to (multi-seat bookings only)
+	IsGuest      bool               `gorm:"not null" json:"is_guest"`                                    // Seat booked by UserID for someone else
+	GuestName    string             `gorm:"type:varchar(255)" json:"guest_name,omitempty"`               // Guest's name (optional)
+	GuestEmail   string             `gorm:"type:varchar(255)" json:"guest_email,omitempty"`              // Guest's email (optional)
+	TicketToken  string             `gorm:"type:varchar(64);uniqueIndex" json:"ticket_token,omitempty"`  // Secret shown on the ticket; replaced when the seat is transferred
+	Guests       []Registration     `gorm:"-" json:"guests,omitempty"`                                   // Guest seats of the booking (in booking responses)
+	Conflicts    []ScheduleConflict `gorm:"-" json:"conflicts,omitempty"`                                // Events on the user's schedule at the same time (in booking responses)
+	CreatedAt    time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt     `gorm:"index" json:"-"`
 }
 
 // TableName specifies the table name for GORM
 func (Registration) TableName() string {
 	return "registrations"
 }
+
+// NewTicketToken generates a random ticket token
+func NewTicketToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// RegisterRequest is the optional body of POST /events/:id/register.
+type RegisterRequest struct {
+	TicketTypeID *string        `json:"ticket_type_id"`                            // Required when the event has more than one ticket type
+	PromoCode    string         `json:"promo_code"`                                // Optional discount code
+	Answers      FormAnswers    `json:"answers"`                                   // Answers to the event's registration form, keyed by question ID
+	Quantity     int            `json:"quantity" binding:"omitempty,min=1,max=10"` // Seats to book including the user's own (default: 1 + guests)
+	Guests       []GuestRequest `json:"guests" binding:"omitempty,max=9,dive"`     // Optional details of the guests (up to quantity - 1)
+	AccessCode   string         `json:"access_code"`                               // Access code of a private event (not needed with an invitation)
+}
+
+// GuestRequest names the attendee of a guest seat. Both fields are optional.
+type GuestRequest struct {
+	Name  string `json:"name" binding:"max=255"`
+	Email string `json:"email" binding:"omitempty,email,max=255"`
+}
+
+// UpdateAnswersRequest is the body of PUT /events/:id/register/answers.
+// It replaces all answers of the registration.
+type UpdateAnswersRequest struct {
+	Answers FormAnswers `json:"answers" binding:"required"`
+}
+
+// ReviewRegistrationRequest is the optional body of the approve/reject endpoints.
+type ReviewRegistrationRequest struct {
+	Reason string `json:"reason" binding:"max=1000"`
+}
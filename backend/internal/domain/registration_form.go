@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Question types
+const (
+	QuestionTypeText         = "text"
+	QuestionTypeSingleChoice = "single_choice"
+	QuestionTypeMultiChoice  = "multi_choice"
+	QuestionTypeNumber       = "number"
+	QuestionTypeBoolean      = "boolean"
+)
+
+// MaxTextAnswerLength limits free-text answers
+const MaxTextAnswerLength = 1000
+
+// FormQuestion is a single typed question of a registration form.
+type FormQuestion struct {
+	ID       string   `json:"id"`    // Stable key the answers refer to, e.g. "tshirt_size"
+	Label    string   `json:"label"` // Question shown to attendees
+	Type     string   `json:"type"`  // text, single_choice, multi_choice, number, boolean
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"` // Choices (choice questions only)
+}
+
+// FormAnswers maps question IDs to answers: a string for text and single choice questions,
+// a list of strings for multi choice, a number or a boolean.
+type FormAnswers map[string]interface{}
+
+// RegistrationForm is the per-event set of questions attendees answer when registering.
+// Events without a stored form ask nothing.
+type RegistrationForm struct {
+	EventID              string         `gorm:"type:uuid;primaryKey" json:"event_id"`
+	Questions            []FormQuestion `gorm:"type:jsonb;serializer:json;not null" json:"questions"`
+	AnswersEditableUntil *time.Time     `json:"answers_editable_until"` // nil = until the event starts
+	CreatedAt            time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RegistrationForm) TableName() string {
+	return "registration_forms"
+}
+
+// UpdateRegistrationFormRequest represents the input for setting an event's registration form.
+type UpdateRegistrationFormRequest struct {
+	Questions            []FormQuestion `json:"questions"`
+	AnswersEditableUntil *time.Time     `json:"answers_editable_until"`
+}
+
+// EmptyRegistrationForm is the form of events that collect no data.
+func EmptyRegistrationForm(eventID string) *RegistrationForm {
+	return &RegistrationForm{EventID: eventID, Questions: []FormQuestion{}}
+}
+
+// Business Logic
+
+var questionIDRegex = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// Validate performs business rule validation on the RegistrationForm entity.
+func (f *RegistrationForm) Validate() error {
+	if f.Questions == nil {
+		f.Questions = []FormQuestion{}
+	}
+
+	seen := make(map[string]bool, len(f.Questions))
+	for i := range f.Questions {
+		question := &f.Questions[i]
+		question.Label = strings.TrimSpace(question.Label)
+		if !questionIDRegex.MatchString(question.ID) {
+			return fmt.Errorf("question id must be 1-50 characters of lowercase letters, digits or '_'")
+		}
+		if seen[question.ID] {
+			return fmt.Errorf("duplicate question id %q", question.ID)
+		}
+		seen[question.ID] = true
+		if question.Label == "" {
+			return fmt.Errorf("question %q needs a label", question.ID)
+		}
+
+		switch question.Type {
+		case QuestionTypeSingleChoice, QuestionTypeMultiChoice:
+			if len(question.Options) == 0 {
+				return fmt.Errorf("question %q needs at least one option", question.ID)
+			}
+			options := make(map[string]bool, len(question.Options))
+			for _, option := range question.Options {
+				if strings.TrimSpace(option) == "" {
+					return fmt.Errorf("question %q has an empty option", question.ID)
+				}
+				if options[option] {
+					return fmt.Errorf("question %q has duplicate option %q", question.ID, option)
+				}
+				options[option] = true
+			}
+		case QuestionTypeText, QuestionTypeNumber, QuestionTypeBoolean:
+			if len(question.Options) > 0 {
+				return fmt.Errorf("question %q of type %s cannot have options", question.ID, question.Type)
+			}
+		default:
+			return fmt.Errorf("question %q has invalid type %q", question.ID, question.Type)
+		}
+	}
+	return nil
+}
+
+// AnswersEditable reports whether answers can still be changed at the given time.
+func (f *RegistrationForm) AnswersEditable(eventStart, now time.Time) bool {
+	deadline := eventStart
+	if f.AnswersEditableUntil != nil && f.AnswersEditableUntil.Before(eventStart) {
+		deadline = *f.AnswersEditableUntil
+	}
+	return now.Before(deadline)
+}
+
+// ValidateAnswers checks submitted answers against the form and returns them normalized.
+// Unknown questions are rejected, required questions must be answered and every answer must match its question's type.
+func (f *RegistrationForm) ValidateAnswers(answers FormAnswers) (FormAnswers, error) {
+	questions := make(map[string]*FormQuestion, len(f.Questions))
+	for i := range f.Questions {
+		questions[f.Questions[i].ID] = &f.Questions[i]
+	}
+	for id := range answers {
+		if questions[id] == nil {
+			return nil, fmt.Errorf("unknown question %q", id)
+		}
+	}
+
+	normalized := make(FormAnswers, len(answers))
+	for _, question := range f.Questions {
+		value, ok := answers[question.ID]
+		if ok && value != nil {
+			answer, err := question.normalizeAnswer(value)
+			if err != nil {
+				return nil, err
+			}
+			if answer != nil {
+				normalized[question.ID] = answer
+				continue
+			}
+		}
+		if question.Required {
+			return nil, fmt.Errorf("question %q is required", question.ID)
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeAnswer checks a single answer. It returns nil for blank answers.
+func (q *FormQuestion) normalizeAnswer(value interface{}) (interface{}, error) {
+	switch q.Type {
+	case QuestionTypeText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("answer to %q must be text", q.ID)
+		}
+		text = strings.TrimSpace(text)
+		if len(text) > MaxTextAnswerLength {
+			return nil, fmt.Errorf("answer to %q must be at most %d characters", q.ID, MaxTextAnswerLength)
+		}
+		if text == "" {
+			return nil, nil
+		}
+		return text, nil
+	case QuestionTypeSingleChoice:
+		choice, ok := value.(string)
+		if !ok || !slices.Contains(q.Options, choice) {
+			return nil, fmt.Errorf("answer to %q must be one of: %s", q.ID, strings.Join(q.Options, ", "))
+		}
+		return choice, nil
+	case QuestionTypeMultiChoice:
+		var values []interface{}
+		switch list := value.(type) {
+		case []interface{}:
+			values = list
+		case []string:
+			for _, v := range list {
+				values = append(values, v)
+			}
+		default:
+			return nil, fmt.Errorf("answer to %q must be a list of options", q.ID)
+		}
+		choices := make([]string, 0, len(values))
+		for _, v := range values {
+			choice, ok := v.(string)
+			if !ok || !slices.Contains(q.Options, choice) {
+				return nil, fmt.Errorf("answers to %q must be among: %s", q.ID, strings.Join(q.Options, ", "))
+			}
+			if !slices.Contains(choices, choice) {
+				choices = append(choices, choice)
+			}
+		}
+		if len(choices) == 0 {
+			return nil, nil
+		}
+		return choices, nil
+	case QuestionTypeNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("answer to %q must be a number", q.ID)
+		}
+		return number, nil
+	case QuestionTypeBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("answer to %q must be true or false", q.ID)
+		}
+		return flag, nil
+	}
+	return nil, fmt.Errorf("question %q has invalid type %q", q.ID, q.Type)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+)
+
+// Transfer statuses
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+// TransferInvitationTTL is how long a transfer invitation stays open (at most until the event starts)
+const TransferInvitationTTL = 7 * 24 * time.Hour
+
+// RegistrationTransfer is an invitation to take over a registration (seat) from its owner.
+// Transfers are never deleted: together they form the audit trail of every handover of a seat.
+type RegistrationTransfer struct {
+	ID             string        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	RegistrationID string        `gorm:"type:uuid;not null" json:"registration_id"`
+	Registration   *Registration `gorm:"foreignKey:RegistrationID" json:"registration,omitempty"`
+	EventID        string        `gorm:"type:uuid;not null;index" json:"event_id"`
+	Event          *Event        `gorm:"foreignKey:EventID" json:"event,omitempty"`
+	FromUserID     string        `gorm:"type:uuid;not null" json:"from_user_id"`
+	ToEmail        string        `gorm:"type:varchar(255);not null;index" json:"to_email"`
+	ToUserID       *string       `gorm:"type:uuid" json:"to_user_id"` // Set once accepted
+	Status         string        `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ExpiresAt      time.Time     `gorm:"not null" json:"expires_at"`
+	RespondedAt    *time.Time    `json:"responded_at"` // When it was accepted, declined or cancelled
+	CreatedAt      time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RegistrationTransfer) TableName() string {
+	return "registration_transfers"
+}
+
+// CreateTransferRequest is the body of POST /users/me/registrations/:id/transfer.
+type CreateTransferRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// IsOpen reports whether the transfer can still be accepted at the given time.
+func (t *RegistrationTransfer) IsOpen(now time.Time) bool {
+	return t.Status == TransferStatusPending && now.Before(t.ExpiresAt)
+}
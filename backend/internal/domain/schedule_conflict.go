@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ScheduleConflictStatuses lists the statuses of the registrations that put an event on the user's schedule
+var ScheduleConflictStatuses = []string{RegistrationStatusConfirmed, RegistrationStatusCheckedIn}
+
+// ScheduleConflict is an event on the user's schedule that overlaps another one
+type ScheduleConflict struct {
+	EventID       string    `json:"event_id"`
+	Title         string    `json:"title"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+// ScheduleOverlap is a pair of overlapping events on the user's schedule, the earlier one first
+type ScheduleOverlap struct {
+	First  ScheduleConflict `json:"first"`
+	Second ScheduleConflict `json:"second"`
+}
+
+func newScheduleConflict(e *Event) ScheduleConflict {
+	return ScheduleConflict{EventID: e.ID, Title: e.Title, StartDatetime: e.StartDatetime, EndDatetime: e.EndDatetime}
+}
+
+// Overlaps reports whether two events share some time. Back-to-back events don't overlap.
+func (e *Event) Overlaps(other *Event) bool {
+	return e.StartDatetime.Before(other.EndDatetime) && other.StartDatetime.Before(e.EndDatetime)
+}
+
+// ScheduleConflicts returns the events of the schedule that overlap event, leaving out event itself
+func ScheduleConflicts(event *Event, schedule []Event) []ScheduleConflict {
+	conflicts := []ScheduleConflict{}
+	for i := range schedule {
+		other := &schedule[i]
+		if other.ID != event.ID && event.Overlaps(other) {
+			conflicts = append(conflicts, newScheduleConflict(other))
+		}
+	}
+	return conflicts
+}
+
+// FindScheduleOverlaps returns every pair of overlapping events of the schedule, by start time
+func FindScheduleOverlaps(schedule []Event) []ScheduleOverlap {
+	events := make([]Event, len(schedule))
+	copy(events, schedule)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartDatetime.Before(events[j].StartDatetime)
+	})
+
+	overlaps := []ScheduleOverlap{}
+	for i := range events {
+		for j := i + 1; j < len(events); j++ {
+			// sorted by start: nothing after an event starting once events[i] has ended overlaps it
+			if !events[j].StartDatetime.Before(events[i].EndDatetime) {
+				break
+			}
+			overlaps = append(overlaps, ScheduleOverlap{
+				First:  newScheduleConflict(&events[i]),
+				Second: newScheduleConflict(&events[j]),
+			})
+		}
+	}
+	return overlaps
+}
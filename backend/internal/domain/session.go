@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Speaker presents sessions of an event
+type Speaker struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID   string    `gorm:"type:uuid;not null;index" json:"event_id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	Headline  string    `gorm:"type:varchar(255)" json:"headline"`  // Job title and company, e.g. "Staff Engineer, Acme"
+	Bio       string    `gorm:"type:text" json:"bio"`               // Shown on the agenda
+	PhotoURL  string    `gorm:"type:varchar(500)" json:"photo_url"` // Optional portrait
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Speaker) TableName() string {
+	return "speakers"
+}
+
+// Session is a talk, workshop or other slot on an event's agenda.
+// Sessions lie within the event's time range, and two sessions can't use the same room at the same time.
+// Sessions with a capacity take registrations from the event's attendees; the others are open to all of them.
+type Session struct {
+	ID            string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID       string    `gorm:"type:uuid;not null;index" json:"event_id"`
+	Title         string    `gorm:"type:varchar(255);not null" json:"title"`
+	Description   string    `gorm:"type:text" json:"description"`
+	Track         string    `gorm:"type:varchar(100)" json:"track"` // Optional grouping, e.g. "Backend"
+	Room          string    `gorm:"type:varchar(100)" json:"room"`  // Optional; sessions in the same room must not overlap
+	StartDatetime time.Time `gorm:"not null" json:"start_datetime"`
+	EndDatetime   time.Time `gorm:"not null" json:"end_datetime"`
+	Capacity      *int      `json:"capacity"`                                   // Seats for registered attendees (nil = no registration)
+	Speakers      []Speaker `gorm:"many2many:session_speakers" json:"speakers"` // Speakers of the event presenting the session
+	Registered    int64     `gorm:"-" json:"registered"`                        // Seats taken (computed)
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// SessionRegistration books an event attendee a seat in a session with limited capacity
+type SessionRegistration struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	SessionID string    `gorm:"type:uuid;not null;uniqueIndex:idx_session_registrations_session_user" json:"session_id"`
+	UserID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_session_registrations_session_user" json:"user_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SessionRegistration) TableName() string {
+	return "session_registrations"
+}
+
+// Validate performs business rule validation on the Session entity:
+// it needs a title, must end after it starts, lie within the event and have a positive capacity if any.
+func (s *Session) Validate(event *Event) error {
+	s.Title = strings.TrimSpace(s.Title)
+	s.Room = strings.TrimSpace(s.Room)
+	if s.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if !s.EndDatetime.After(s.StartDatetime) {
+		return fmt.Errorf("session must end after it starts")
+	}
+	if s.StartDatetime.Before(event.StartDatetime) || s.EndDatetime.After(event.EndDatetime) {
+		return fmt.Errorf("session must take place within the event (%s - %s)",
+			event.StartDatetime.Format(time.RFC3339), event.EndDatetime.Format(time.RFC3339))
+	}
+	if s.Capacity != nil && *s.Capacity < 1 {
+		return fmt.Errorf("capacity must be at least 1")
+	}
+	return nil
+}
+
+// Overlaps reports whether two sessions share some time. Back-to-back sessions don't overlap.
+func (s *Session) Overlaps(other *Session) bool {
+	return s.StartDatetime.Before(other.EndDatetime) && other.StartDatetime.Before(s.EndDatetime)
+}
+
+// RoomConflict returns the first of the given sessions that uses the same room as s at the same time,
+// or nil. Sessions without a room never conflict.
+func (s *Session) RoomConflict(others []Session) *Session {
+	if s.Room == "" {
+		return nil
+	}
+	for i := range others {
+		other := &others[i]
+		if other.ID != s.ID && strings.EqualFold(other.Room, s.Room) && s.Overlaps(other) {
+			return other
+		}
+	}
+	return nil
+}
+
+// DTOs
+
+// CreateSpeakerRequest represents the input for adding a speaker to an event
+type CreateSpeakerRequest struct {
+	Name     string `json:"name" binding:"required,min=2,max=100"`
+	Headline string `json:"headline" binding:"max=255"`
+	Bio      string `json:"bio"`
+	PhotoURL string `json:"photo_url" binding:"omitempty,url,max=500"`
+}
+
+// UpdateSpeakerRequest represents the input for updating a speaker.
+// Only non-nil fields are updated.
+type UpdateSpeakerRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	Headline *string `json:"headline,omitempty" binding:"omitempty,max=255"`
+	Bio      *string `json:"bio,omitempty"`
+	PhotoURL *string `json:"photo_url,omitempty" binding:"omitempty,max=500"`
+}
+
+// CreateSessionRequest represents the input for adding a session to an event's agenda
+type CreateSessionRequest struct {
+	Title         string    `json:"title" binding:"required,min=3"`
+	Description   string    `json:"description"`
+	Track         string    `json:"track" binding:"max=100"`
+	Room          string    `json:"room" binding:"max=100"`
+	StartDatetime time.Time `json:"start_datetime" binding:"required"`
+	EndDatetime   time.Time `json:"end_datetime" binding:"required"`
+	Capacity      *int      `json:"capacity" binding:"omitempty,min=1"` // Attendees register for a seat (optional)
+	SpeakerIDs    []string  `json:"speaker_ids"`                        // Speakers of the same event
+}
+
+// UpdateSessionRequest represents the input for updating a session.
+// Only non-nil fields are updated; a capacity of 0 removes the limit and the registration.
+type UpdateSessionRequest struct {
+	Title         *string    `json:"title,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	Track         *string    `json:"track,omitempty"`
+	Room          *string    `json:"room,omitempty"`
+	StartDatetime *time.Time `json:"start_datetime,omitempty"`
+	EndDatetime   *time.Time `json:"end_datetime,omitempty"`
+	Capacity      *int       `json:"capacity,omitempty"`
+	SpeakerIDs    *[]string  `json:"speaker_ids,omitempty"` // Replaces the speakers
+}
+
+// Agenda is the program of an event: its sessions in order and everyone speaking
+type Agenda struct {
+	EventID  string    `json:"event_id"`
+	Sessions []Session `json:"sessions"` // By start time, then room
+	Speakers []Speaker `json:"speakers"` // By name
+}
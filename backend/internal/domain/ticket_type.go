@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TicketType is a priced tier of seats within an event (e.g. General, VIP, Student).
+// Each tier has its own stock, while Event.Capacity still caps the total across all tiers.
+// Prices are stored in minor currency units (cents) to avoid floating point rounding.
+type TicketType struct {
+	ID          string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	EventID     string         `gorm:"type:uuid;not null;index" json:"event_id"`
+	Name        string         `gorm:"type:varchar(100);not null" json:"name"`              // Tier name shown to attendees
+	Description string         `gorm:"type:text" json:"description"`                        // Optional tier description
+	PriceCents  int64          `gorm:"not null;default:0" json:"price_cents"`               // Price in minor units (0 = free)
+	Currency    string         `gorm:"type:char(3);not null;default:'USD'" json:"currency"` // ISO 4217 currency code
+	Quantity    int            `gorm:"not null" json:"quantity"`                            // Seats available in this tier
+	SalesStart  *time.Time     `json:"sales_start"`                                         // Sales open at (nil = immediately)
+	SalesEnd    *time.Time     `json:"sales_end"`                                           // Sales close at (nil = until the event starts)
+	MaxPerOrder int            `gorm:"not null;default:10" json:"max_per_order"`            // Maximum seats of this tier per order
+	Sold        int64          `gorm:"-" json:"sold"`                                       // Seats held by active registrations (computed)
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (TicketType) TableName() string {
+	return "ticket_types"
+}
+
+// DTOs
+
+// CreateTicketTypeRequest represents the input for adding a ticket tier to an event.
+type CreateTicketTypeRequest struct {
+	Name        string     `json:"name" binding:"required,min=2,max=100"`
+	Description string     `json:"description"`
+	PriceCents  int64      `json:"price_cents" binding:"min=0"`
+	Currency    string     `json:"currency"` // Defaults to USD
+	Quantity    int        `json:"quantity" binding:"required,min=1"`
+	SalesStart  *time.Time `json:"sales_start"`
+	SalesEnd    *time.Time `json:"sales_end"`
+	MaxPerOrder int        `json:"max_per_order" binding:"omitempty,min=1"` // Defaults to 10
+}
+
+// UpdateTicketTypeRequest represents the input for updating a ticket tier.
+// Only non-nil fields are updated.
+type UpdateTicketTypeRequest struct {
+	Name        *string    `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	PriceCents  *int64     `json:"price_cents,omitempty"`
+	Currency    *string    `json:"currency,omitempty"`
+	Quantity    *int       `json:"quantity,omitempty"`
+	SalesStart  *time.Time `json:"sales_start,omitempty"`
+	SalesEnd    *time.Time `json:"sales_end,omitempty"`
+	MaxPerOrder *int       `json:"max_per_order,omitempty"`
+}
+
+// Business Logic
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate performs business rule validation on the TicketType entity.
+// The currency is upper-cased and defaults to USD.
+func (t *TicketType) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if t.PriceCents < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	t.Currency = strings.ToUpper(strings.TrimSpace(t.Currency))
+	if t.Currency == "" {
+		t.Currency = "USD"
+	}
+	if !currencyRegex.MatchString(t.Currency) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+	if t.Quantity < 1 {
+		return fmt.Errorf("quantity must be at least 1")
+	}
+	if t.MaxPerOrder == 0 {
+		t.MaxPerOrder = 10
+	}
+	if t.MaxPerOrder < 1 {
+		return fmt.Errorf("max_per_order must be at least 1")
+	}
+	if t.SalesStart != nil && t.SalesEnd != nil && !t.SalesEnd.After(*t.SalesStart) {
+		return fmt.Errorf("sales_end must be after sales_start")
+	}
+	return nil
+}
+
+// CheckOnSale returns an error if the tier cannot be bought at the given time.
+func (t *TicketType) CheckOnSale(now time.Time) error {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return fmt.Errorf("ticket sales for %q have not started yet", t.Name)
+	}
+	if t.SalesEnd != nil && !now.Before(*t.SalesEnd) {
+		return fmt.Errorf("ticket sales for %q have ended", t.Name)
+	}
+	return nil
+}
+
+// CheckOrderSize returns an error if more seats of the tier are booked at once than it allows.
+func (t *TicketType) CheckOrderSize(quantity int) error {
+	if t.MaxPerOrder > 0 && quantity > t.MaxPerOrder {
+		return fmt.Errorf("at most %d %q ticket(s) can be booked at once", t.MaxPerOrder, t.Name)
+	}
+	return nil
+}
+
+// IsFree reports whether the tier costs nothing.
+func (t *TicketType) IsFree() bool {
+	return t.PriceCents == 0
+}
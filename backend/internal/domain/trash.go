@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// TrashedEvent is a soft-deleted event, as listed in the trash until it is restored or purged
+type TrashedEvent struct {
+	Event
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashedUser is a soft-deleted user account, as listed in the trash until it is restored or purged
+type TrashedUser struct {
+	User
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// PurgeResult counts the rows a purge of the trash deleted for good
+type PurgeResult struct {
+	Events        int64
+	Users         int64
+	Registrations int64
+	FileKeys      []string // Blob store objects of the files of purged events, left for the caller to delete
+}
+
+// Add adds up the counts of another purge
+func (r *PurgeResult) Add(other *PurgeResult) {
+	r.Events += other.Events
+	r.Users += other.Users
+	r.Registrations += other.Registrations
+	r.FileKeys = append(r.FileKeys, other.FileKeys...)
+}
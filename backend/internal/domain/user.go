@@ -10,14 +10,15 @@ import (
 
 // User entity with GORM tags
 type User struct {
-	ID           string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
-	Email        string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	PasswordHash string         `gorm:"type:varchar(255);not null" json:"-"` // Never expose in JSON
-	Name         string         `gorm:"type:varchar(255);not null" json:"name"`
-	Role         string         `gorm:"type:varchar(20);not null;default:'user'" json:"role"` // "user", "organizer", "admin"
-	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete support
+	ID             string         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	Email          string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
+	PasswordHash   string         `gorm:"type:varchar(255);not null" json:"-"` // Never expose in JSON
+	Name           string         `gorm:"type:varchar(255);not null" json:"name"`
+	Role           string         `gorm:"type:varchar(20);not null;default:'user'" json:"role"` // "user", "organizer", "admin"
+	StrictSchedule bool           `gorm:"not null" json:"strict_schedule"`                      // Block registrations that overlap events on the user's schedule (otherwise only warn)
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete support
 }
 
 // TableName specifies the table name for GORM
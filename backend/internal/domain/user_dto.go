@@ -1,7 +1,8 @@
 package domain
 
 type UpdateUserRequest struct {
-	Name     *string `json:"name"`
-	Email    *string `json:"email"`
-	Password *string `json:"password"`
+	Name           *string `json:"name"`
+	Email          *string `json:"email"`
+	Password       *string `json:"password"`
+	StrictSchedule *bool   `json:"strict_schedule"` // Block overlapping registrations instead of warning
 }
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// CSVContentType is the media type of CSV files
+const CSVContentType = "text/csv"
+
+// CSVWriter writes rows of text cells as CSV. Cells that spreadsheets would read as formulas are
+// prefixed with a quote, so opening an export can't run anything a user typed into a field.
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// WriteRow appends a row
+func (c *CSVWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		escaped[i] = cell
+	}
+	return c.w.Write(escaped)
+}
+
+// Flush sends the rows written so far on to the underlying writer
+func (c *CSVWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Close writes out the remaining rows
+func (c *CSVWriter) Close() error {
+	return c.Flush()
+}
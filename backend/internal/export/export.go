@@ -0,0 +1,12 @@
+// Package export writes tabular data as downloadable files, streaming rows as they are produced.
+package export
+
+// RowWriter writes rows of text cells to a file format
+type RowWriter interface {
+	// WriteRow appends a row
+	WriteRow(cells []string) error
+	// Flush sends the rows written so far on to the underlying writer
+	Flush() error
+	// Close finishes the file; it does not close the underlying writer
+	Close() error
+}
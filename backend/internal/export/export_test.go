@@ -0,0 +1,64 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSXWriter_WritesReadableWorkbook(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "Registrants")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow([]string{"name", "answer"}))
+	require.NoError(t, w.WriteRow([]string{"Ada <Lovelace> & co", ""}))
+	require.NoError(t, w.WriteRow([]string{"line\nbreak", "bell\x07"}))
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		parts[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		require.Contains(t, parts, name)
+		assert.NoError(t, xml.Unmarshal([]byte(parts[name]), new(struct{})), name)
+	}
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Registrants"`)
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Text string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(parts["xl/worksheets/sheet1.xml"]), &sheet))
+	require.Len(t, sheet.Rows, 3)
+	assert.Equal(t, "Ada <Lovelace> & co", sheet.Rows[1].Cells[0].Text)
+	assert.Equal(t, "", sheet.Rows[1].Cells[1].Text)
+	assert.Equal(t, "line\nbreak", sheet.Rows[2].Cells[0].Text)
+	assert.Equal(t, "bell", sheet.Rows[2].Cells[1].Text)
+}
+
+func TestCSVWriter_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	require.NoError(t, w.WriteRow([]string{"name", "note"}))
+	require.NoError(t, w.WriteRow([]string{"=HYPERLINK(\"x\")", "@SUM(A1), ok"}))
+	require.NoError(t, w.WriteRow([]string{"Ada", ""}))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "name,note\n\"'=HYPERLINK(\"\"x\"\")\",\"'@SUM(A1), ok\"\nAda,\n", buf.String())
+}
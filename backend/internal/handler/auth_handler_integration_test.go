@@ -34,6 +34,7 @@ func setupAuthDB(t *testing.T) *gorm.DB {
 		password_hash TEXT NOT NULL,
 		name TEXT NOT NULL,
 		role TEXT NOT NULL DEFAULT 'user',
+		strict_schedule BOOLEAN NOT NULL DEFAULT FALSE,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
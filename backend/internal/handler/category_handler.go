@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler handles HTTP requests for the event category taxonomy.
+type CategoryHandler struct {
+	categoryService *service.CategoryService
+}
+
+// NewCategoryHandler creates a new CategoryHandler and registers category routes.
+//
+// Public routes:
+//   - GET /categories - Category tree (categories nested under their parents)
+//
+// Admin routes (JWT authentication + admin role required):
+//   - POST /categories - Create a category
+//   - PUT /categories/:id - Update a category
+//   - DELETE /categories/:id - Delete a category without sub-categories
+func NewCategoryHandler(r *gin.Engine, categoryService *service.CategoryService, authMiddleware gin.HandlerFunc) {
+	h := &CategoryHandler{categoryService: categoryService}
+
+	public := r.Group("/categories")
+	public.GET("", h.GetCategories)
+
+	admin := r.Group("/categories")
+	admin.Use(authMiddleware, middleware.RequireRole("admin"))
+	admin.POST("", h.CreateCategory)
+	admin.PUT("/:id", h.UpdateCategory)
+	admin.DELETE("/:id", h.DeleteCategory)
+}
+
+// GetCategories handles GET /categories (public)
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.categoryService.GetCategoryTree()
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+	response.Success(c, 200, categories)
+}
+
+// CreateCategory handles POST /categories (admin)
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req domain.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(&req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, category)
+}
+
+// UpdateCategory handles PUT /categories/:id (admin)
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	var req domain.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, category)
+}
+
+// DeleteCategory handles DELETE /categories/:id (admin)
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	if err := h.categoryService.DeleteCategory(c.Param("id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "category deleted")
+}
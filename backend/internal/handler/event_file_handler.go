@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the multipart envelope around an uploaded file
+const multipartOverhead = 1 << 20
+
+// EventFileHandler handles HTTP requests for the files of events: cover images and attachments.
+type EventFileHandler struct {
+	fileService *service.EventFileService
+}
+
+// NewEventFileHandler creates a new EventFileHandler and registers file routes.
+//
+// Public routes (visible to whoever may see the event):
+//   - GET /events/:id/files - The cover image and attachments, with signed, expiring URLs
+//
+// Protected routes (organizers only; uploads are multipart/form-data with a "file" field):
+//   - PUT /events/:id/cover - Upload the cover image (JPEG, PNG or GIF), replacing the previous one
+//   - DELETE /events/:id/cover - Remove the cover image
+//   - POST /events/:id/attachments - Upload an attachment (PDF or image)
+//   - DELETE /events/:id/attachments/:file_id - Remove an attachment
+func NewEventFileHandler(r *gin.Engine, fileService *service.EventFileService, authMiddleware, optionalAuthMiddleware gin.HandlerFunc) {
+	h := &EventFileHandler{fileService: fileService}
+
+	r.GET("/events/:id/files", optionalAuthMiddleware, h.GetFiles)
+
+	protected := r.Group("/events/:id")
+	protected.Use(authMiddleware)
+	protected.PUT("/cover", h.SetCover)
+	protected.DELETE("/cover", h.RemoveCover)
+	protected.POST("/attachments", h.AddAttachment)
+	protected.DELETE("/attachments/:file_id", h.RemoveAttachment)
+}
+
+// GetFiles handles GET /events/:id/files (public)
+// Query Parameters: access_code - Access code of a private event (optional)
+func (h *EventFileHandler) GetFiles(c *gin.Context) {
+	viewerID, _ := getUserIDFromContext(c)
+	files, err := h.fileService.GetFiles(c.Param("id"), viewerID, c.Query("access_code"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	response.Success(c, 200, files)
+}
+
+// SetCover handles PUT /events/:id/cover (protected)
+// Files over the size limit fail with 422 FILE_TOO_LARGE, other types with 422 UNSUPPORTED_FILE_TYPE.
+func (h *EventFileHandler) SetCover(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	upload, closeUpload, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+	defer closeUpload()
+	file, err := h.fileService.SetCover(userID, c.Param("id"), upload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 200, file)
+}
+
+// RemoveCover handles DELETE /events/:id/cover (protected)
+func (h *EventFileHandler) RemoveCover(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.fileService.RemoveCover(userID, c.Param("id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "cover image removed")
+}
+
+// AddAttachment handles POST /events/:id/attachments (protected)
+// Files over the size limit fail with 422 FILE_TOO_LARGE, other types with 422 UNSUPPORTED_FILE_TYPE.
+func (h *EventFileHandler) AddAttachment(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	upload, closeUpload, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+	defer closeUpload()
+	file, err := h.fileService.AddAttachment(userID, c.Param("id"), upload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 201, file)
+}
+
+// RemoveAttachment handles DELETE /events/:id/attachments/:file_id (protected)
+func (h *EventFileHandler) RemoveAttachment(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.fileService.RemoveAttachment(userID, c.Param("id"), c.Param("file_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "attachment removed")
+}
+
+// readUpload opens the "file" field of a multipart request, refusing bodies well over the size limit
+// without reading them. It writes the error response itself when it fails.
+func (h *EventFileHandler) readUpload(c *gin.Context) (*domain.FileUpload, func() error, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.fileService.MaxUploadSize()+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(c, domain.ErrFileTooLarge)
+			return nil, nil, false
+		}
+		response.BadRequest(c, "a multipart form with a \"file\" field is required")
+		return nil, nil, false
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read the uploaded file")
+		return nil, nil, false
+	}
+	return &domain.FileUpload{Name: header.Filename, Size: header.Size, Body: f}, f.Close, true
+}
+
+// BlobHandler serves the objects of a local blob store through signed URLs.
+type BlobHandler struct {
+	store *storage.LocalStore
+}
+
+// NewBlobHandler registers the route serving objects of the local blob store.
+//
+// Public routes (guarded by the URL signature instead of authentication):
+//   - GET /files/*key?expires=...&signature=... - Download an object until the URL expires
+func NewBlobHandler(r *gin.Engine, store *storage.LocalStore) {
+	h := &BlobHandler{store: store}
+	r.GET(storage.LocalFilesPath+"*key", h.Serve)
+}
+
+// Serve handles GET /files/*key (public, signed)
+func (h *BlobHandler) Serve(c *gin.Context) {
+	key := c.Param("key")[1:] // the wildcard keeps the leading slash
+	if err := h.store.VerifySignature(key, c.Query("expires"), c.Query("signature"), time.Now()); err != nil {
+		response.Forbidden(c, err.Error())
+		return
+	}
+
+	f, err := h.store.Open(key)
+	if err != nil {
+		response.NotFound(c, "file not found")
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		response.InternalServerError(c, "failed to read file")
+		return
+	}
+
+	http.ServeContent(c.Writer, c.Request, key, info.ModTime(), f)
+}
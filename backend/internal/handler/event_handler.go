@@ -1,6 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
 	"github.com/Fixsbreaker/event-hub/backend/internal/service"
 	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
@@ -20,37 +25,64 @@ type EventHandler struct {
 //   - r: The root Gin router to register routes on
 //   - eventService: Service layer containing business logic for event operations
 //   - authMiddleware: JWT authentication middleware for protected routes
+//   - optionalAuthMiddleware: identifies the user on public routes when a token is sent
 //
-// Public routes (no authentication required):
-//   - GET /events - List all events with optional filtering and pagination
+// Public routes (no authentication required; the response depends on the viewer when authenticated):
+//   - GET /events - List public events (and the viewer's own) with optional filtering and pagination
+//   - GET /events/facets - Event counts per category, tag, status and month for the current filters
+//   - GET /events/share/:slug - Get an event through its share link
 //   - GET /events/:id - Get a specific event by ID
 //
 // Protected routes (JWT authentication required):
 //   - POST /events - Create a new event
 //   - PUT /events/:id - Update an existing event (organizer only)
-//   - DELETE /events/:id - Delete an event (organizer only)
-//   - POST /events/:id/publish - Publish a draft event (organizer only)
-//   - POST /events/:id/cancel - Cancel a published event (organizer only)
+//   - DELETE /events/:id - Delete an event (event owners only)
+//   - POST /events/:id/clone - Copy an event and its configuration into a new draft (organizer only)
+//   - POST /events/:id/publish - Publish a draft or postponed event (organizer only)
+//   - PUT /events/:id/publish-schedule - Publish a draft automatically at a set time (organizer only)
+//   - DELETE /events/:id/publish-schedule - Cancel the publishing schedule (organizer only)
+//   - POST /events/:id/postpone - Postpone a published event (organizer only)
+//   - POST /events/:id/complete - Mark a started event as completed (organizer only)
+//   - POST /events/:id/cancel - Cancel an event that is not completed (organizer only)
+//   - GET /events/:id/status-history - Status changes of the event (event team only)
+//   - GET /events/:id/history - Field-level history of every change to the event (event team only)
+//   - POST /events/:id/revert - Bring the event's details back to an earlier version (organizer only)
+//   - GET /events/trash - The user's deleted events, until they are purged
+//   - POST /events/:id/restore - Take a deleted event out of the trash (event owners only)
 func NewEventHandler(
 	r *gin.Engine,
 	eventService *service.EventService,
 	authMiddleware gin.HandlerFunc,
+	optionalAuthMiddleware gin.HandlerFunc,
 ) {
 	h := &EventHandler{eventService: eventService}
 
 	// Public routes - accessible without authentication
 	public := r.Group("/events")
+	public.Use(optionalAuthMiddleware)
 	public.GET("", h.GetAllEvents)
+	public.GET("/facets", h.GetEventFacets)
+	public.GET("/share/:slug", h.GetEventBySlug)
 	public.GET("/:id", h.GetEventByID)
 
 	// Protected routes - require JWT authentication
 	protected := r.Group("/events")
 	protected.Use(authMiddleware)
 	protected.POST("", h.CreateEvent)
+	protected.GET("/trash", h.GetTrash)
 	protected.PUT("/:id", h.UpdateEvent)
 	protected.DELETE("/:id", h.DeleteEvent)
+	protected.POST("/:id/clone", h.CloneEvent)
 	protected.POST("/:id/publish", h.PublishEvent)
+	protected.PUT("/:id/publish-schedule", h.SchedulePublish)
+	protected.DELETE("/:id/publish-schedule", h.CancelScheduledPublish)
+	protected.POST("/:id/postpone", h.PostponeEvent)
+	protected.POST("/:id/complete", h.CompleteEvent)
 	protected.POST("/:id/cancel", h.CancelEvent)
+	protected.GET("/:id/status-history", h.GetStatusHistory)
+	protected.GET("/:id/history", h.GetHistory)
+	protected.POST("/:id/revert", h.RevertEvent)
+	protected.POST("/:id/restore", h.RestoreEvent)
 }
 
 // Helper Functions
@@ -73,6 +105,33 @@ func getUserIDFromContext(c *gin.Context) (string, bool) {
 	return userID, true
 }
 
+// respondError writes a service error. Business rule violations carrying a
+// domain.CodedError are returned as 422 with their code; other errors as 400.
+func respondError(c *gin.Context, err error) {
+	var coded *domain.CodedError
+	if errors.As(err, &coded) {
+		response.Error(c, 422, coded.Code, coded.Message)
+		return
+	}
+	response.BadRequest(c, err.Error())
+}
+
+// setETag sends the version of an event as its ETag, for clients to send back in If-Match
+func setETag(c *gin.Context, event *domain.Event) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, event.Version))
+}
+
+// parseIfMatch reads the event version from an If-Match header: an ETag ("3", or weak W/"3")
+// or "*" for any version, which parses as 0
+func parseIfMatch(header string) (int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	return version, err == nil && version > 0
+}
+
 // HTTP Handlers
 
 // CreateEvent handles POST /events (protected)
@@ -107,19 +166,61 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		return
 	}
 
+	setETag(c, event)
+	response.Success(c, 201, event)
+}
+
+// CloneEvent handles POST /events/:id/clone (protected)
+// Copies an event into a new draft starting at the given time. Its ticket types, promo codes,
+// cancellation policy, registration form, registration window, speakers and sessions are copied
+// with their dates shifted by the same offset; registrations are not.
+//
+// Path Parameters: id - Event UUID
+// Request Body: domain.CloneEventRequest
+// Success Response: 201 Created with the new event
+// Error Responses:
+//   - 400 Bad Request: Invalid input, event not found or not managed by the user
+//   - 401 Unauthorized: Missing authentication
+func (h *EventHandler) CloneEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	var req domain.CloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: start_datetime is required")
+		return
+	}
+
+	event, err := h.eventService.CloneEvent(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	setETag(c, event)
 	response.Success(c, 201, event)
 }
 
 // UpdateEvent handles PUT /events/:id (protected)
 // Updates an existing event. Only the event organizer can update their events.
+// The If-Match header must carry the ETag of the version the changes were made on (or "*"),
+// so concurrent edits never overwrite each other.
 //
 // Path Parameters: id - Event UUID
+// Headers: If-Match - ETag from GET /events/:id
 // Request Body: domain.UpdateEventRequest (all fields optional)
-// Success Response: 200 OK with updated event
+// Success Response: 200 OK with updated event and its new ETag
 // Error Responses:
 //   - 400 Bad Request: Invalid input or event not found
 //   - 401 Unauthorized: Missing authentication
 //   - 403 Forbidden: User is not the event organizer
+//   - 412 Precondition Failed: The event was changed since that version (VERSION_MISMATCH)
+//   - 422 Unprocessable Entity: Capacity below the seats already taken (CAPACITY_BELOW_SEATS_TAKEN)
+//   - 422 Unprocessable Entity: New dates leave sessions outside the event (SESSIONS_OUTSIDE_EVENT)
+//   - 428 Precondition Required: Missing If-Match header
 func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	eventID := c.Param("id")
 	if eventID == "" {
@@ -139,13 +240,29 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		response.Error(c, 428, "PRECONDITION_REQUIRED", "the If-Match header is required: send the ETag of the event you edited")
+		return
+	}
+	version, ok := parseIfMatch(ifMatch)
+	if !ok {
+		response.Error(c, 412, domain.ErrEventVersionMismatch.Code, "the If-Match header does not hold an event ETag")
+		return
+	}
+
 	// Service layer handles authorization check
-	event, err := h.eventService.UpdateEvent(userID, eventID, &req)
+	event, err := h.eventService.UpdateEventIfMatch(userID, eventID, version, &req)
+	if errors.Is(err, domain.ErrEventVersionMismatch) {
+		response.Error(c, 412, domain.ErrEventVersionMismatch.Code, domain.ErrEventVersionMismatch.Message)
+		return
+	}
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
+	setETag(c, event)
 	response.Success(c, 200, event)
 }
 
@@ -180,13 +297,14 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 }
 
 // PublishEvent handles POST /events/:id/publish (protected)
-// Changes event status from 'draft' to 'published', making it visible to public.
+// Changes event status from 'draft' (or 'postponed') to 'published', making it visible to public.
 // Only the event organizer can publish their events.
 //
 // Path Parameters: id - Event UUID
 // Success Response: 200 OK with success message
 // Error Responses:
-//   - 400 Bad Request: Event not found or already published
+//   - 400 Bad Request: Event not found
+//   - 422 Unprocessable Entity: Event cannot be published from its status (INVALID_STATUS_TRANSITION)
 //   - 401 Unauthorized: Missing authentication
 //   - 403 Forbidden: User is not the event organizer
 func (h *EventHandler) PublishEvent(c *gin.Context) {
@@ -203,20 +321,248 @@ func (h *EventHandler) PublishEvent(c *gin.Context) {
 	}
 
 	if err := h.eventService.PublishEvent(userID, eventID); err != nil {
-		response.BadRequest(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	response.SuccessWithMessage(c, 200, "event published")
 }
 
+// SchedulePublish handles PUT /events/:id/publish-schedule (protected)
+// Sets the time a draft event is published automatically; scheduling again moves the time.
+//
+// Path Parameters: id - Event UUID
+// Request Body: domain.SchedulePublishRequest
+// Success Response: 200 OK with the updated event
+// Error Responses:
+//   - 400 Bad Request: Event not found, not a draft, or publish_at not in the future before the event ends
+//   - 401 Unauthorized: Missing authentication
+func (h *EventHandler) SchedulePublish(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	var req domain.SchedulePublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	event, err := h.eventService.SchedulePublish(userID, c.Param("id"), req.PublishAt)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, event)
+}
+
+// CancelScheduledPublish handles DELETE /events/:id/publish-schedule (protected)
+// Removes the publishing schedule of a draft event; the event stays a draft.
+func (h *EventHandler) CancelScheduledPublish(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	if err := h.eventService.CancelScheduledPublish(userID, c.Param("id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "publishing schedule cancelled")
+}
+
+// PostponeEvent handles POST /events/:id/postpone (protected)
+// Changes event status from 'published' to 'postponed'. Registrations are kept; the organizer
+// publishes the event again once the new dates are set.
+//
+// Path Parameters: id - Event UUID
+// Request Body: domain.PostponeEventRequest (optional)
+// Success Response: 200 OK with success message
+// Error Responses:
+//   - 400 Bad Request: Event not found
+//   - 401 Unauthorized: Missing authentication
+//   - 422 Unprocessable Entity: Event is not published (INVALID_STATUS_TRANSITION)
+func (h *EventHandler) PostponeEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	var req domain.PostponeEventRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid request body")
+			return
+		}
+	}
+
+	if err := h.eventService.PostponeEvent(userID, c.Param("id"), req.Reason); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "event postponed")
+}
+
+// CompleteEvent handles POST /events/:id/complete (protected)
+// Changes event status from 'published' to 'completed'. Events are also completed
+// automatically once they have ended.
+//
+// Path Parameters: id - Event UUID
+// Success Response: 200 OK with success message
+// Error Responses:
+//   - 400 Bad Request: Event not found
+//   - 401 Unauthorized: Missing authentication
+//   - 422 Unprocessable Entity: Event is not published or has not started (INVALID_STATUS_TRANSITION)
+func (h *EventHandler) CompleteEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	if err := h.eventService.CompleteEvent(userID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "event completed")
+}
+
+// GetStatusHistory handles GET /events/:id/status-history (protected)
+// Returns the status changes of an event, oldest first. Automatic changes have no changed_by.
+func (h *EventHandler) GetStatusHistory(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	history, err := h.eventService.GetStatusHistory(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, history)
+}
+
+// GetHistory handles GET /events/:id/history (protected)
+// Returns every recorded change of an event, oldest first: who made it, when, and the old and new
+// value of each field. Automatic changes have no actor_id.
+func (h *EventHandler) GetHistory(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	history, err := h.eventService.GetHistory(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, history)
+}
+
+// RevertEvent handles POST /events/:id/revert (protected)
+// Brings the details of an event back to an earlier version from its history. The revert is a new
+// update: it gets a new version and shows in the history. Status, publishing schedule and access code are kept.
+//
+// Path Parameters: id - Event UUID
+// Request Body: domain.RevertEventRequest
+// Success Response: 200 OK with the reverted event and its new ETag
+// Error Responses:
+//   - 400 Bad Request: Event not found, version not in the history, or the result is invalid
+//   - 401 Unauthorized: Missing authentication
+//   - 412 Precondition Failed: The event was changed while reverting (VERSION_MISMATCH)
+//   - 422 Unprocessable Entity: Capacity below the seats already taken (CAPACITY_BELOW_SEATS_TAKEN)
+//   - 422 Unprocessable Entity: New dates leave sessions outside the event (SESSIONS_OUTSIDE_EVENT)
+func (h *EventHandler) RevertEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	var req domain.RevertEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "version is required")
+		return
+	}
+
+	event, err := h.eventService.RevertEvent(userID, c.Param("id"), req.Version)
+	if errors.Is(err, domain.ErrEventVersionMismatch) {
+		response.Error(c, 412, domain.ErrEventVersionMismatch.Code, domain.ErrEventVersionMismatch.Message)
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	setETag(c, event)
+	response.Success(c, 200, event)
+}
+
+// GetTrash handles GET /events/trash (protected)
+// Returns the deleted events the user organizes, most recently deleted first, until they are purged.
+func (h *EventHandler) GetTrash(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	trash, err := h.eventService.GetTrash(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, trash)
+}
+
+// RestoreEvent handles POST /events/:id/restore (protected)
+// Takes a deleted event back out of the trash, with its registrations, as it was when deleted.
+//
+// Path Parameters: id - Event UUID
+// Success Response: 200 OK with the restored event and its new ETag
+// Error Responses:
+//   - 401 Unauthorized: Missing authentication
+//   - 404 Not Found: Not in the trash, or the user is not one of the event's owners
+func (h *EventHandler) RestoreEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unable to read user from context")
+		return
+	}
+
+	event, err := h.eventService.RestoreEvent(userID, c.Param("id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	setETag(c, event)
+	response.Success(c, 200, event)
+}
+
 // CancelEvent handles POST /events/:id/cancel (protected)
 // Changes event status to 'cancelled'. Only the event organizer can cancel their events.
 //
 // Path Parameters: id - Event UUID
 // Success Response: 200 OK with success message
 // Error Responses:
-//   - 400 Bad Request: Event not found or already cancelled
+//   - 400 Bad Request: Event not found
+//   - 422 Unprocessable Entity: Event is already completed or cancelled (INVALID_STATUS_TRANSITION)
 //   - 401 Unauthorized: Missing authentication
 //   - 403 Forbidden: User is not the event organizer
 func (h *EventHandler) CancelEvent(c *gin.Context) {
@@ -233,7 +579,7 @@ func (h *EventHandler) CancelEvent(c *gin.Context) {
 	}
 
 	if err := h.eventService.Cancel(userID, eventID); err != nil {
-		response.BadRequest(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -246,7 +592,7 @@ func (h *EventHandler) CancelEvent(c *gin.Context) {
 // Query Parameters:
 //   - page: Page number (default: 1)
 //   - page_size: Items per page (default: 10, max: 100)
-//   - status: Filter by status (draft, published, cancelled)
+//   - status: Filter by status (draft, published, postponed, completed, cancelled)
 //   - start_date_from: Filter events starting after this date (ISO 8601)
 //   - start_date_to: Filter events starting before this date (ISO 8601)
 //   - min_capacity: Minimum event capacity
@@ -254,6 +600,9 @@ func (h *EventHandler) CancelEvent(c *gin.Context) {
 //   - location: Filter by location (partial match)
 //   - keyword: Search in title and description
 //   - organizer_id: Filter by organizer UUID
+//   - category: Filter by category slug or UUID (includes sub-categories)
+//   - tags: Comma-separated tag names (e.g. go,backend)
+//   - tag_mode: any (default) matches one of the tags, all requires every tag
 //   - upcoming_only: Show only future events (true/false)
 //   - past_only: Show only past events (true/false)
 //   - sort_by: Sort field (start_date, capacity, created_at)
@@ -273,17 +622,19 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 		response.BadRequest(c, "invalid query parameters")
 		return
 	}
+	queryReq.ViewerID, _ = getUserIDFromContext(c)
 
 	// Check if any filtering/pagination parameters are provided
 	hasParams := queryReq.Page > 0 || queryReq.PageSize > 0 ||
 		queryReq.StartDateFrom != nil || queryReq.StartDateTo != nil ||
 		queryReq.MinCapacity != nil || queryReq.MaxCapacity != nil ||
 		queryReq.Status != "" || queryReq.Location != "" || queryReq.Keyword != "" ||
-		queryReq.OrganizerID != "" || queryReq.UpcomingOnly || queryReq.PastOnly || queryReq.SortBy != ""
+		queryReq.OrganizerID != "" || queryReq.UpcomingOnly || queryReq.PastOnly || queryReq.SortBy != "" ||
+		queryReq.Category != "" || queryReq.Tags != "" || queryReq.RegistrationState != ""
 
 	// For backward compatibility, return all events if no parameters provided
 	if !hasParams {
-		events, err := h.eventService.GetAllEvents()
+		events, err := h.eventService.GetAllEvents(queryReq.ViewerID)
 		if err != nil {
 			response.InternalServerError(c, err.Error())
 			return
@@ -302,14 +653,42 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 	response.Success(c, 200, eventsResponse)
 }
 
+// GetEventFacets handles GET /events/facets (public)
+// Returns the number of events per category, tag, status and start month,
+// computed over the same filters accepted by GET /events (pagination and sorting are ignored).
+// Intended for building sidebar filters in the front end.
+//
+// Success Response: 200 OK with domain.EventFacets
+// Error Responses:
+//   - 400 Bad Request: Invalid query parameters
+func (h *EventHandler) GetEventFacets(c *gin.Context) {
+	var queryReq domain.EventQueryRequest
+	if err := c.ShouldBindQuery(&queryReq); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	queryReq.ViewerID, _ = getUserIDFromContext(c)
+
+	facets, err := h.eventService.GetEventFacets(&queryReq)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, facets)
+}
+
 // GetEventByID handles GET /events/:id (public)
 // Retrieves a single event by its UUID. Includes organizer details.
+// Unlisted events are only returned to their organizer; private events also to invitees,
+// registrants and requests carrying the access code.
 //
 // Path Parameters: id - Event UUID
+// Query Parameters: access_code - Access code of a private event (optional)
 // Success Response: 200 OK with event details
 // Error Responses:
 //   - 400 Bad Request: Missing event ID
-//   - 404 Not Found: Event does not exist
+//   - 404 Not Found: Event does not exist or is not visible to the viewer
 func (h *EventHandler) GetEventByID(c *gin.Context) {
 	eventID := c.Param("id")
 	if eventID == "" {
@@ -317,12 +696,34 @@ func (h *EventHandler) GetEventByID(c *gin.Context) {
 		return
 	}
 
-	event, err := h.eventService.GetEventByID(eventID)
+	viewerID, _ := getUserIDFromContext(c)
+	event, err := h.eventService.GetEventByID(eventID, viewerID, c.Query("access_code"))
 	if err != nil {
 		response.NotFound(c, err.Error())
 		return
 	}
 
+	setETag(c, event)
 	response.Success(c, 200, event)
 }
 
+// GetEventBySlug handles GET /events/share/:slug (public)
+// Retrieves an event through its share link, the way unlisted events are reached.
+// Private events still require an invitation or the access code.
+//
+// Path Parameters: slug - Event share slug
+// Query Parameters: access_code - Access code of a private event (optional)
+// Success Response: 200 OK with event details
+// Error Responses:
+//   - 404 Not Found: Event does not exist or is not visible to the viewer
+func (h *EventHandler) GetEventBySlug(c *gin.Context) {
+	viewerID, _ := getUserIDFromContext(c)
+	event, err := h.eventService.GetEventBySlug(c.Param("slug"), viewerID, c.Query("access_code"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	setETag(c, event)
+	response.Success(c, 200, event)
+}
@@ -35,9 +35,86 @@ func setupInMemoryDB(t *testing.T) *gorm.DB {
 		end_datetime DATETIME,
 		capacity INTEGER,
 		status TEXT,
+		category_id TEXT,
+		requires_approval BOOLEAN NOT NULL DEFAULT 0,
+		allow_transfers BOOLEAN NOT NULL DEFAULT 1,
+		registration_opens_at DATETIME,
+		registration_closes_at DATETIME,
+		visibility TEXT NOT NULL DEFAULT 'public',
+		slug TEXT,
+		access_code TEXT,
+		organization_id TEXT,
+		publish_at DATETIME,
+		publish_claimed_until DATETIME,
+		version INTEGER NOT NULL DEFAULT 1,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS categories (
+		id TEXT PRIMARY KEY,
+		parent_id TEXT,
+		name TEXT,
+		slug TEXT UNIQUE,
+		description TEXT,
+		created_at DATETIME,
+		updated_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS tags (
+		id TEXT PRIMARY KEY,
+		name TEXT UNIQUE,
+		created_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS event_tags (
+		event_id TEXT,
+		tag_id TEXT,
+		PRIMARY KEY (event_id, tag_id)
+	);
+	CREATE TABLE IF NOT EXISTS registrations (
+		id TEXT PRIMARY KEY,
+		user_id TEXT,
+		event_id TEXT,
+		status TEXT,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS event_members (
+		id TEXT PRIMARY KEY,
+		event_id TEXT,
+		user_id TEXT,
+		role TEXT,
+		added_by TEXT,
+		created_at DATETIME,
+		updated_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS organization_members (
+		id TEXT PRIMARY KEY,
+		organization_id TEXT,
+		publish_at DATETIME,
+		user_id TEXT,
+		role TEXT,
+		created_at DATETIME,
+		updated_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS event_status_changes (
+		id TEXT PRIMARY KEY,
+		event_id TEXT,
+		from_status TEXT,
+		to_status TEXT,
+		changed_by TEXT,
+		reason TEXT,
+		created_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS event_revisions (
+		id TEXT PRIMARY KEY,
+		event_id TEXT,
+		version INTEGER,
+		action TEXT,
+		actor_id TEXT,
+		changes TEXT,
+		reverted_to INTEGER,
+		created_at DATETIME
 	);`
 	err = db.Exec(createSQL).Error
 	require.NoError(t, err)
@@ -75,7 +152,7 @@ func TestCreateAndGetEvents_Integration(t *testing.T) {
 	h.CreateEvent(c)
 	require.Equal(t, http.StatusCreated, rec.Code)
 
-	events, err := svc.GetAllEvents()
+	events, err := svc.GetAllEvents("")
 	require.NoError(t, err)
 	require.Len(t, events, 1)
 }
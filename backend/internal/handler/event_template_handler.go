@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type EventTemplateHandler struct {
+	templateService *service.EventTemplateService
+}
+
+func NewEventTemplateHandler(r *gin.Engine, templateService *service.EventTemplateService, authMiddleware gin.HandlerFunc) {
+	h := &EventTemplateHandler{templateService: templateService}
+
+	// Saved event templates are private to the organizer who saved them
+	protected := r.Group("/event-templates")
+	protected.Use(authMiddleware)
+	protected.POST("", h.Create)
+	protected.GET("", h.List)
+	protected.GET("/:id", h.Get)
+	protected.PUT("/:id", h.Update)
+	protected.DELETE("/:id", h.Delete)
+
+	// Create a draft event pre-filled from a template
+	protected.POST("/:id/events", h.CreateEvent)
+}
+
+// POST /event-templates
+func (h *EventTemplateHandler) Create(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateEventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: name, title, location, capacity and duration_minutes are required")
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(userID, &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 201, template)
+}
+
+// GET /event-templates
+func (h *EventTemplateHandler) List(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	templates, err := h.templateService.GetTemplates(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, templates)
+}
+
+// GET /event-templates/:id
+func (h *EventTemplateHandler) Get(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(userID, c.Param("id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, template)
+}
+
+// PUT /event-templates/:id
+func (h *EventTemplateHandler) Update(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateEventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, template)
+}
+
+// DELETE /event-templates/:id
+func (h *EventTemplateHandler) Delete(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(userID, c.Param("id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "event template deleted")
+}
+
+// POST /event-templates/:id/events
+func (h *EventTemplateHandler) CreateEvent(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateEventFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: start_datetime is required")
+		return
+	}
+
+	event, err := h.templateService.CreateEvent(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 201, event)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize limits the size of an imported CSV file
+const maxImportSize = 2 << 20
+
+// ImportHandler handles HTTP requests for CSV imports of events and registrants.
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler and registers import routes.
+//
+// Protected routes (the CSV file is sent as the body with Content-Type text/csv, or as the
+// "file" field of a multipart form; dry_run=true only validates it):
+//   - POST /events/import - Create draft events from the rows of a CSV file
+//   - POST /events/:id/registrants/import - Register existing users for an event (organizer only)
+//   - GET /events/imports/:job_id - Progress and row errors of an import started by the user
+func NewImportHandler(r *gin.Engine, importService *service.ImportService, authMiddleware gin.HandlerFunc) {
+	h := &ImportHandler{importService: importService}
+
+	protected := r.Group("/events")
+	protected.Use(authMiddleware)
+	protected.POST("/import", h.ImportEvents)
+	protected.POST("/:id/registrants/import", h.ImportRegistrants)
+	protected.GET("/imports/:job_id", h.GetJob)
+}
+
+// ImportEvents handles POST /events/import (protected)
+// Query Parameters: dry_run - true to only validate the file and report row errors
+// Returns 200 with the validation report on a dry run, 202 with the import job otherwise.
+func (h *ImportHandler) ImportEvents(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "user not authenticated")
+		return
+	}
+	file, ok := readImportFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	if c.Query("dry_run") == "true" {
+		report, err := h.importService.ValidateEvents(userID, file)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.Success(c, 200, report)
+		return
+	}
+
+	job, err := h.importService.StartEventImport(userID, file)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 202, job)
+}
+
+// ImportRegistrants handles POST /events/:id/registrants/import (protected, organizer only)
+// Query Parameters: dry_run - true to only validate the file and report row errors
+// Returns 200 with the validation report on a dry run, 202 with the import job otherwise.
+func (h *ImportHandler) ImportRegistrants(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "user not authenticated")
+		return
+	}
+	file, ok := readImportFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	if c.Query("dry_run") == "true" {
+		report, err := h.importService.ValidateRegistrants(userID, c.Param("id"), file)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.Success(c, 200, report)
+		return
+	}
+
+	job, err := h.importService.StartRegistrantImport(userID, c.Param("id"), file)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 202, job)
+}
+
+// GetJob handles GET /events/imports/:job_id (protected)
+func (h *ImportHandler) GetJob(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "user not authenticated")
+		return
+	}
+
+	job, err := h.importService.GetJob(userID, c.Param("job_id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	response.Success(c, 200, job)
+}
+
+// readImportFile returns the CSV file of an import request: the "file" field of a multipart form,
+// or the request body itself. It writes the error response itself when it fails.
+func readImportFile(c *gin.Context) (io.ReadCloser, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+multipartOverhead)
+
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		body, err := io.ReadAll(c.Request.Body)
+		if !checkImportRead(c, err) {
+			return nil, false
+		}
+		if len(body) > maxImportSize {
+			respondError(c, domain.ErrFileTooLarge)
+			return nil, false
+		}
+		return io.NopCloser(bytes.NewReader(body)), true
+	}
+
+	header, err := c.FormFile("file")
+	if !checkImportRead(c, err) {
+		return nil, false
+	}
+	if header.Size > maxImportSize {
+		respondError(c, domain.ErrFileTooLarge)
+		return nil, false
+	}
+	f, err := header.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read the uploaded file")
+		return nil, false
+	}
+	return f, true
+}
+
+// checkImportRead reports whether reading the imported file worked, responding to the failure otherwise
+func checkImportRead(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondError(c, domain.ErrFileTooLarge)
+		return false
+	}
+	response.BadRequest(c, "a CSV file is required, as the request body or the \"file\" field of a multipart form")
+	return false
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+func NewInvitationHandler(r *gin.Engine, invitationService *service.InvitationService, authMiddleware gin.HandlerFunc) {
+	h := &InvitationHandler{invitationService: invitationService}
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+
+	// Invitations to private events (organizer only)
+	protected.POST("/events/:id/invitations", h.Invite)
+	protected.GET("/events/:id/invitations", h.List)
+	protected.DELETE("/events/:id/invitations/:invitation_id", h.Revoke)
+}
+
+// POST /events/:id/invitations
+func (h *InvitationHandler) Invite(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateInvitationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: emails must be a list of 1-500 valid email addresses")
+		return
+	}
+
+	result, err := h.invitationService.InviteByEmail(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 201, result)
+}
+
+// GET /events/:id/invitations
+func (h *InvitationHandler) List(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	invitations, err := h.invitationService.GetInvitations(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, invitations)
+}
+
+// DELETE /events/:id/invitations/:invitation_id
+func (h *InvitationHandler) Revoke(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.invitationService.RevokeInvitation(userID, c.Param("id"), c.Param("invitation_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "invitation revoked")
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type MemberHandler struct {
+	memberService *service.MemberService
+}
+
+func NewMemberHandler(r *gin.Engine, memberService *service.MemberService, authMiddleware gin.HandlerFunc) {
+	h := &MemberHandler{memberService: memberService}
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+
+	// Event team: owners add and remove members, the whole team can see it
+	protected.POST("/events/:id/members", h.Add)
+	protected.GET("/events/:id/members", h.List)
+	protected.DELETE("/events/:id/members/:user_id", h.Remove)
+
+	// Hand the event over to another user or an organization (owners only)
+	protected.POST("/events/:id/owner", h.TransferOwnership)
+}
+
+// POST /events/:id/members
+func (h *MemberHandler) Add(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.AddEventMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: email and role (owner, co_organizer, checkin_staff or viewer) are required")
+		return
+	}
+
+	member, err := h.memberService.AddMember(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 201, member)
+}
+
+// GET /events/:id/members
+func (h *MemberHandler) List(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	team, err := h.memberService.GetTeam(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, team)
+}
+
+// DELETE /events/:id/members/:user_id
+func (h *MemberHandler) Remove(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.memberService.RemoveMember(userID, c.Param("id"), c.Param("user_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "member removed")
+}
+
+// POST /events/:id/owner
+func (h *MemberHandler) TransferOwnership(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.TransferEventOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	event, err := h.memberService.TransferOwnership(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, event)
+}
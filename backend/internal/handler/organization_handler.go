@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles HTTP requests for organizations and their members.
+type OrganizationHandler struct {
+	orgService *service.OrganizationService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler and registers organization routes.
+//
+// Public routes:
+//   - GET /organizations/:slug/events - Public profile: the organization and its upcoming public events
+//
+// Protected routes (JWT authentication required):
+//   - POST /organizations - Create an organization (the creator becomes its admin)
+//   - PUT /organizations/:slug - Update an organization (admins only)
+//   - GET /organizations/:slug/members - List members (members only)
+//   - POST /organizations/:slug/members - Add a member or change their role (admins only)
+//   - DELETE /organizations/:slug/members/:user_id - Remove a member (admins, or the member themselves)
+//   - GET /users/me/organizations - Organizations I belong to
+func NewOrganizationHandler(r *gin.Engine, orgService *service.OrganizationService, authMiddleware gin.HandlerFunc) {
+	h := &OrganizationHandler{orgService: orgService}
+
+	public := r.Group("/organizations")
+	public.GET("/:slug/events", h.GetProfile)
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+	protected.POST("/organizations", h.Create)
+	protected.PUT("/organizations/:slug", h.Update)
+	protected.GET("/organizations/:slug/members", h.GetMembers)
+	protected.POST("/organizations/:slug/members", h.AddMember)
+	protected.DELETE("/organizations/:slug/members/:user_id", h.RemoveMember)
+	protected.GET("/users/me/organizations", h.GetMyOrganizations)
+}
+
+// GetProfile handles GET /organizations/:slug/events (public)
+func (h *OrganizationHandler) GetProfile(c *gin.Context) {
+	profile, err := h.orgService.GetProfile(c.Param("slug"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	response.Success(c, 200, profile)
+}
+
+// Create handles POST /organizations
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	org, err := h.orgService.CreateOrganization(userID, &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, org)
+}
+
+// Update handles PUT /organizations/:slug
+func (h *OrganizationHandler) Update(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	org, err := h.orgService.UpdateOrganization(userID, c.Param("slug"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, org)
+}
+
+// GetMembers handles GET /organizations/:slug/members
+func (h *OrganizationHandler) GetMembers(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	members, err := h.orgService.GetMembers(userID, c.Param("slug"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, members)
+}
+
+// AddMember handles POST /organizations/:slug/members
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body: email and role (admin or member) are required")
+		return
+	}
+
+	member, err := h.orgService.AddMember(userID, c.Param("slug"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, member)
+}
+
+// RemoveMember handles DELETE /organizations/:slug/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.orgService.RemoveMember(userID, c.Param("slug"), c.Param("user_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "member removed")
+}
+
+// GetMyOrganizations handles GET /users/me/organizations
+func (h *OrganizationHandler) GetMyOrganizations(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	orgs, err := h.orgService.GetUserOrganizations(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+	response.Success(c, 200, orgs)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBodySize limits the size of provider webhook payloads
+const maxWebhookBodySize = 64 << 10
+
+// PaymentHandler handles HTTP requests for orders and payment provider callbacks.
+type PaymentHandler struct {
+	paymentService *service.PaymentService
+}
+
+// NewPaymentHandler creates a new PaymentHandler and registers payment routes.
+//
+// Public routes:
+//   - POST /payments/webhook - Signed provider webhook (X-Payment-Signature header)
+//
+// Protected routes:
+//   - GET /users/me/orders - List my orders
+//   - GET /orders/:id - Get one of my orders
+//   - POST /orders/:id/simulate-payment - Pay (or decline) an order on the fake provider
+func NewPaymentHandler(r *gin.Engine, paymentService *service.PaymentService, authMiddleware gin.HandlerFunc) {
+	h := &PaymentHandler{paymentService: paymentService}
+
+	r.POST("/payments/webhook", h.Webhook)
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+	protected.GET("/users/me/orders", h.GetMyOrders)
+	protected.GET("/orders/:id", h.GetOrder)
+	protected.POST("/orders/:id/simulate-payment", h.SimulatePayment)
+}
+
+// Webhook handles POST /payments/webhook (public, authenticated by signature)
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	if err := h.paymentService.HandleWebhook(payload, c.GetHeader(payment.SignatureHeader)); err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			response.Unauthorized(c, err.Error())
+			return
+		}
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "webhook processed")
+}
+
+// GetMyOrders handles GET /users/me/orders
+func (h *PaymentHandler) GetMyOrders(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	orders, err := h.paymentService.GetUserOrders(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, orders)
+}
+
+// GetOrder handles GET /orders/:id
+func (h *PaymentHandler) GetOrder(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	order, err := h.paymentService.GetUserOrder(userID, c.Param("id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, order)
+}
+
+// SimulatePayment handles POST /orders/:id/simulate-payment?outcome=succeed|decline
+func (h *PaymentHandler) SimulatePayment(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	succeed := c.DefaultQuery("outcome", "succeed") != "decline"
+	order, err := h.paymentService.SimulatePayment(userID, c.Param("id"), succeed)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, order)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// PromoHandler handles HTTP requests for event promo codes.
+type PromoHandler struct {
+	promoService *service.PromoService
+}
+
+// NewPromoHandler creates a new PromoHandler and registers promo code routes.
+//
+// Protected routes (organizer only):
+//   - GET /events/:id/promo-codes - List promo codes with redemption counts
+//   - POST /events/:id/promo-codes - Create a promo code
+//   - PUT /events/:id/promo-codes/:promo_code_id - Update a promo code
+//   - DELETE /events/:id/promo-codes/:promo_code_id - Remove a promo code
+//   - GET /events/:id/promo-codes/:promo_code_id/stats - Redemption statistics
+func NewPromoHandler(r *gin.Engine, promoService *service.PromoService, authMiddleware gin.HandlerFunc) {
+	h := &PromoHandler{promoService: promoService}
+
+	protected := r.Group("/events/:id/promo-codes")
+	protected.Use(authMiddleware)
+	protected.GET("", h.GetPromoCodes)
+	protected.POST("", h.CreatePromoCode)
+	protected.PUT("/:promo_code_id", h.UpdatePromoCode)
+	protected.DELETE("/:promo_code_id", h.DeletePromoCode)
+	protected.GET("/:promo_code_id/stats", h.GetPromoCodeStats)
+}
+
+// GetPromoCodes handles GET /events/:id/promo-codes (protected)
+func (h *PromoHandler) GetPromoCodes(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	promos, err := h.promoService.GetPromoCodes(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, promos)
+}
+
+// CreatePromoCode handles POST /events/:id/promo-codes (protected)
+func (h *PromoHandler) CreatePromoCode(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	promo, err := h.promoService.CreatePromoCode(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, promo)
+}
+
+// UpdatePromoCode handles PUT /events/:id/promo-codes/:promo_code_id (protected)
+func (h *PromoHandler) UpdatePromoCode(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	promo, err := h.promoService.UpdatePromoCode(userID, c.Param("id"), c.Param("promo_code_id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, promo)
+}
+
+// DeletePromoCode handles DELETE /events/:id/promo-codes/:promo_code_id (protected)
+func (h *PromoHandler) DeletePromoCode(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.promoService.DeletePromoCode(userID, c.Param("id"), c.Param("promo_code_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "promo code deleted")
+}
+
+// GetPromoCodeStats handles GET /events/:id/promo-codes/:promo_code_id/stats (protected)
+func (h *PromoHandler) GetPromoCodeStats(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	stats, err := h.promoService.GetPromoCodeStats(userID, c.Param("id"), c.Param("promo_code_id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, stats)
+}
@@ -1,6 +1,12 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/export"
 	"github.com/Fixsbreaker/event-hub/backend/internal/service"
 	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -19,16 +25,40 @@ func NewRegistrationHandler(r *gin.Engine, regService *service.RegistrationServi
 	// Register for event
 	protected.POST("/events/:id/register", h.Register)
 
-	// Cancel registration
+	// Cancel registration (subject to the event's cancellation policy)
 	protected.DELETE("/events/:id/register", h.Cancel)
 
+	// Cancel a single guest seat of my booking
+	protected.DELETE("/events/:id/register/guests/:reg_id", h.CancelGuestSeat)
+
+	// Cancellation policy: anyone can read it, only the organizer can change it
+	r.GET("/events/:id/cancellation-policy", h.GetCancellationPolicy)
+	protected.PUT("/events/:id/cancellation-policy", h.SetCancellationPolicy)
+
+	// Registration form: anyone can read it, only the organizer can change it
+	r.GET("/events/:id/registration-form", h.GetRegistrationForm)
+	protected.PUT("/events/:id/registration-form", h.SetRegistrationForm)
+
+	// Change my answers to the registration form (until the form's deadline)
+	protected.PUT("/events/:id/register/answers", h.UpdateAnswers)
+
 	// Get my registrations
 	protected.GET("/users/me/registrations", h.GetMyRegistrations)
 
+	// Get the overlapping upcoming events on my schedule
+	protected.GET("/users/me/conflicts", h.GetMyConflicts)
+
 	// Check in attendee
 	protected.PATCH("/events/:id/check/:attendee_id", h.CheckIn)
 
-	// Get event registrants (organizer only), you can also filter with "?status=all/checked_in/confirmed/cancelled"
+	// Check in a single seat (e.g. a guest's) by its registration ID
+	protected.PATCH("/events/:id/registrations/:reg_id/check-in", h.CheckInSeat)
+
+	// Review applications for events that require approval (organizer only)
+	protected.POST("/events/:id/registrations/:reg_id/approve", h.Approve)
+	protected.POST("/events/:id/registrations/:reg_id/reject", h.Reject)
+
+	// Get event registrants (event team only), you can also filter with "?status=all/pending/rejected/pending_payment/confirmed/checked_in/cancelled/expired"
 	protected.GET("/events/:id/registrants", h.GetEventRegistrants)
 }
 
@@ -46,9 +76,18 @@ func (h *RegistrationHandler) Register(c *gin.Context) {
 		return
 	}
 
-	reg, err := h.regService.RegisterUser(userID, eventID)
+	// body is optional: events without ticket types accept an empty request
+	var req domain.RegisterRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid request body")
+			return
+		}
+	}
+
+	reg, err := h.regService.RegisterUser(userID, eventID, &req)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -69,12 +108,173 @@ func (h *RegistrationHandler) Cancel(c *gin.Context) {
 		return
 	}
 
-	if err := h.regService.CancelRegistration(userID, eventID); err != nil {
+	reg, err := h.regService.CancelRegistration(userID, eventID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, 200, reg)
+}
+
+// DELETE /events/:id/register/guests/:reg_id
+func (h *RegistrationHandler) CancelGuestSeat(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	reg, err := h.regService.CancelGuestSeat(userID, c.Param("id"), c.Param("reg_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, 200, reg)
+}
+
+// GET /events/:id/cancellation-policy
+func (h *RegistrationHandler) GetCancellationPolicy(c *gin.Context) {
+	policy, err := h.regService.GetCancellationPolicy(c.Param("id"))
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, policy)
+}
+
+// PUT /events/:id/cancellation-policy
+func (h *RegistrationHandler) SetCancellationPolicy(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateCancellationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	policy, err := h.regService.SetCancellationPolicy(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, policy)
+}
+
+// GET /events/:id/registration-form
+func (h *RegistrationHandler) GetRegistrationForm(c *gin.Context) {
+	form, err := h.regService.GetRegistrationForm(c.Param("id"))
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, form)
+}
+
+// PUT /events/:id/registration-form
+func (h *RegistrationHandler) SetRegistrationForm(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateRegistrationFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	form, err := h.regService.SetRegistrationForm(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, form)
+}
+
+// PUT /events/:id/register/answers
+func (h *RegistrationHandler) UpdateAnswers(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateAnswersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	reg, err := h.regService.UpdateAnswers(userID, c.Param("id"), req.Answers)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, 200, reg)
+}
+
+// POST /events/:id/registrations/:reg_id/approve
+func (h *RegistrationHandler) Approve(c *gin.Context) {
+	organizerID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	// body is optional: the reason may be omitted
+	var req domain.ReviewRegistrationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid request body")
+			return
+		}
+	}
+
+	reg, err := h.regService.ApproveRegistration(organizerID, c.Param("id"), c.Param("reg_id"), req.Reason)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, reg)
+}
+
+// POST /events/:id/registrations/:reg_id/reject
+func (h *RegistrationHandler) Reject(c *gin.Context) {
+	organizerID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	// body is optional: the reason may be omitted
+	var req domain.ReviewRegistrationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid request body")
+			return
+		}
+	}
+
+	reg, err := h.regService.RejectRegistration(organizerID, c.Param("id"), c.Param("reg_id"), req.Reason)
+	if err != nil {
 		response.BadRequest(c, err.Error())
 		return
 	}
 
-	response.SuccessWithMessage(c, 200, "registration cancelled")
+	response.Success(c, 200, reg)
 }
 
 // GET /users/me/registrations
@@ -94,6 +294,23 @@ func (h *RegistrationHandler) GetMyRegistrations(c *gin.Context) {
 	response.Success(c, 200, regs)
 }
 
+// GET /users/me/conflicts
+func (h *RegistrationHandler) GetMyConflicts(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	conflicts, err := h.regService.GetScheduleConflicts(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, conflicts)
+}
+
 // Mark attendee that he/she actually physically attends the event
 func (h *RegistrationHandler) CheckIn(c *gin.Context) {
 	eventID := c.Param("id")
@@ -122,7 +339,25 @@ func (h *RegistrationHandler) CheckIn(c *gin.Context) {
 	response.SuccessWithMessage(c, 200, "attendee checked in successfully")
 }
 
+// PATCH /events/:id/registrations/:reg_id/check-in
+func (h *RegistrationHandler) CheckInSeat(c *gin.Context) {
+	organizerID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.regService.CheckInSeat(organizerID, c.Param("id"), c.Param("reg_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(c, 200, "attendee checked in successfully")
+}
+
 // GET /events/:id/registrants
+// Returns JSON unless an export format is selected with format=csv|xlsx|ndjson|json or the Accept
+// header (text/csv, the XLSX media type or application/x-ndjson); exports are streamed as downloads.
 func (h *RegistrationHandler) GetEventRegistrants(c *gin.Context) {
 	eventID := c.Param("id")
 	if eventID == "" {
@@ -144,13 +379,27 @@ func (h *RegistrationHandler) GetEventRegistrants(c *gin.Context) {
 
 	// Validate status parameter
 	validStatuses := map[string]bool{
-		"all":        true,
-		"confirmed":  true,
-		"cancelled":  true,
-		"checked_in": true,
+		"all":                                   true,
+		domain.RegistrationStatusPending:        true,
+		domain.RegistrationStatusRejected:       true,
+		domain.RegistrationStatusPendingPayment: true,
+		domain.RegistrationStatusConfirmed:      true,
+		domain.RegistrationStatusCancelled:      true,
+		domain.RegistrationStatusCheckedIn:      true,
+		domain.RegistrationStatusExpired:        true,
 	}
 	if !validStatuses[status] {
-		response.BadRequest(c, "invalid status filter. Valid values: all, confirmed, cancelled, checked_in")
+		response.BadRequest(c, "invalid status filter. Valid values: all, pending, rejected, pending_payment, confirmed, cancelled, checked_in, expired")
+		return
+	}
+
+	format, ok := registrantExportFormat(c)
+	if !ok {
+		response.BadRequest(c, "invalid format. Valid values: json, csv, xlsx, ndjson")
+		return
+	}
+	if format != exportFormatJSON {
+		h.exportRegistrants(c, organizerID, eventID, status, format)
 		return
 	}
 
@@ -162,3 +411,108 @@ func (h *RegistrationHandler) GetEventRegistrants(c *gin.Context) {
 
 	response.Success(c, 200, registrants)
 }
+
+// Registrant export formats
+const (
+	exportFormatJSON   = "json"
+	exportFormatCSV    = "csv"
+	exportFormatXLSX   = "xlsx"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportContentTypes maps export formats to their media types
+var exportContentTypes = map[string]string{
+	exportFormatJSON:   "application/json",
+	exportFormatCSV:    export.CSVContentType,
+	exportFormatXLSX:   export.XLSXContentType,
+	exportFormatNDJSON: "application/x-ndjson",
+}
+
+// exportFlushInterval is how many rows an export writes between flushes to the client
+const exportFlushInterval = 100
+
+// registrantExportFormat picks the format of a registrant listing: the format query parameter,
+// else the Accept header, else JSON
+func registrantExportFormat(c *gin.Context) (string, bool) {
+	if format := c.Query("format"); format != "" {
+		_, ok := exportContentTypes[format]
+		return format, ok
+	}
+	accepted := c.NegotiateFormat(exportContentTypes[exportFormatJSON], exportContentTypes[exportFormatCSV],
+		exportContentTypes[exportFormatXLSX], exportContentTypes[exportFormatNDJSON])
+	for format, contentType := range exportContentTypes {
+		if contentType == accepted {
+			return format, true
+		}
+	}
+	return exportFormatJSON, true
+}
+
+// exportRegistrants streams the registrants of an event as a CSV, XLSX or NDJSON download.
+// Once the first bytes are out the status can no longer change, so failures midway are only logged.
+func (h *RegistrationHandler) exportRegistrants(c *gin.Context, organizerID, eventID, status, format string) {
+	registrants, err := h.regService.ExportEventRegistrants(organizerID, eventID, status)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", exportContentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="registrants-%s.%s"`, registrants.Event.ID, format))
+	c.Status(200)
+
+	written := 0
+	flush := func(writer export.RowWriter) error {
+		written++
+		if written%exportFlushInterval != 0 {
+			return nil
+		}
+		if writer != nil {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	if format == exportFormatNDJSON {
+		encoder := json.NewEncoder(c.Writer)
+		err = registrants.Each(func(record *domain.RegistrantRecord) error {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+			return flush(nil)
+		})
+	} else {
+		err = writeRegistrantTable(c, registrants, format, flush)
+	}
+	if err != nil {
+		log.Printf("failed to export registrants of event %s: %v", eventID, err)
+	}
+}
+
+// writeRegistrantTable writes the registrants as a CSV or XLSX table, one column per form question after the fixed ones
+func writeRegistrantTable(c *gin.Context, registrants *service.RegistrantExport, format string, flush func(export.RowWriter) error) error {
+	var writer export.RowWriter = export.NewCSVWriter(c.Writer)
+	if format == exportFormatXLSX {
+		xlsx, err := export.NewXLSXWriter(c.Writer, "Registrants")
+		if err != nil {
+			return err
+		}
+		writer = xlsx
+	}
+
+	if err := writer.WriteRow(domain.RegistrantExportColumns(registrants.Questions)); err != nil {
+		return err
+	}
+	if err := registrants.Each(func(record *domain.RegistrantRecord) error {
+		if err := writer.WriteRow(record.Cells(registrants.Questions)); err != nil {
+			return err
+		}
+		return flush(writer)
+	}); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler handles HTTP requests for event agendas: speakers, sessions and session registrations.
+type SessionHandler struct {
+	sessionService *service.SessionService
+}
+
+// NewSessionHandler creates a new SessionHandler and registers agenda routes.
+//
+// Public routes (the agenda is visible to whoever may see the event):
+//   - GET /events/:id/agenda - Sessions by start time with their speakers, and all speakers
+//
+// Protected routes (organizers only):
+//   - POST /events/:id/speakers - Add a speaker
+//   - PUT /events/:id/speakers/:speaker_id - Update a speaker
+//   - DELETE /events/:id/speakers/:speaker_id - Remove a speaker
+//   - POST /events/:id/sessions - Add a session
+//   - PUT /events/:id/sessions/:session_id - Update a session
+//   - DELETE /events/:id/sessions/:session_id - Remove a session
+//
+// Protected routes (attendees):
+//   - POST /events/:id/sessions/:session_id/registration - Book a seat in a session with limited capacity
+//   - DELETE /events/:id/sessions/:session_id/registration - Give the seat up
+func NewSessionHandler(r *gin.Engine, sessionService *service.SessionService, authMiddleware, optionalAuthMiddleware gin.HandlerFunc) {
+	h := &SessionHandler{sessionService: sessionService}
+
+	r.GET("/events/:id/agenda", optionalAuthMiddleware, h.GetAgenda)
+
+	protected := r.Group("/events/:id")
+	protected.Use(authMiddleware)
+	protected.POST("/speakers", h.AddSpeaker)
+	protected.PUT("/speakers/:speaker_id", h.UpdateSpeaker)
+	protected.DELETE("/speakers/:speaker_id", h.RemoveSpeaker)
+	protected.POST("/sessions", h.AddSession)
+	protected.PUT("/sessions/:session_id", h.UpdateSession)
+	protected.DELETE("/sessions/:session_id", h.RemoveSession)
+	protected.POST("/sessions/:session_id/registration", h.Register)
+	protected.DELETE("/sessions/:session_id/registration", h.CancelRegistration)
+}
+
+// GetAgenda handles GET /events/:id/agenda (public)
+// Query Parameters: access_code - Access code of a private event (optional)
+func (h *SessionHandler) GetAgenda(c *gin.Context) {
+	viewerID, _ := getUserIDFromContext(c)
+	agenda, err := h.sessionService.GetAgenda(c.Param("id"), viewerID, c.Query("access_code"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	response.Success(c, 200, agenda)
+}
+
+// AddSpeaker handles POST /events/:id/speakers (protected)
+func (h *SessionHandler) AddSpeaker(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	speaker, err := h.sessionService.AddSpeaker(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, speaker)
+}
+
+// UpdateSpeaker handles PUT /events/:id/speakers/:speaker_id (protected)
+func (h *SessionHandler) UpdateSpeaker(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	speaker, err := h.sessionService.UpdateSpeaker(userID, c.Param("id"), c.Param("speaker_id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, speaker)
+}
+
+// RemoveSpeaker handles DELETE /events/:id/speakers/:speaker_id (protected)
+func (h *SessionHandler) RemoveSpeaker(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.sessionService.RemoveSpeaker(userID, c.Param("id"), c.Param("speaker_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "speaker removed")
+}
+
+// AddSession handles POST /events/:id/sessions (protected)
+// Overlapping sessions in the same room are rejected with 422 SESSION_ROOM_CONFLICT.
+func (h *SessionHandler) AddSession(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	session, err := h.sessionService.AddSession(userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 201, session)
+}
+
+// UpdateSession handles PUT /events/:id/sessions/:session_id (protected)
+func (h *SessionHandler) UpdateSession(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	session, err := h.sessionService.UpdateSession(userID, c.Param("id"), c.Param("session_id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 200, session)
+}
+
+// RemoveSession handles DELETE /events/:id/sessions/:session_id (protected)
+func (h *SessionHandler) RemoveSession(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.sessionService.RemoveSession(userID, c.Param("id"), c.Param("session_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "session removed")
+}
+
+// Register handles POST /events/:id/sessions/:session_id/registration (protected)
+// A full session is rejected with 422 SESSION_FULL.
+func (h *SessionHandler) Register(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	registration, err := h.sessionService.RegisterForSession(userID, c.Param("id"), c.Param("session_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 201, registration)
+}
+
+// CancelRegistration handles DELETE /events/:id/sessions/:session_id/registration (protected)
+func (h *SessionHandler) CancelRegistration(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.sessionService.CancelSessionRegistration(userID, c.Param("id"), c.Param("session_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "session registration cancelled")
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler handles HTTP requests for the analytics dashboards of organizers.
+type StatsHandler struct {
+	statsService *service.StatsService
+}
+
+// NewStatsHandler creates a new StatsHandler and registers stats routes.
+//
+// Protected routes (stats are cached for a minute, so they can lag behind slightly):
+//   - GET /events/:id/stats - Registrations per day, cancellations, check-ins, no-shows, fill and pending approvals of an event (event team)
+//   - GET /organizers/me/stats - The same, summed up over the events I organize or run with my event teams and organizations
+func NewStatsHandler(r *gin.Engine, statsService *service.StatsService, authMiddleware gin.HandlerFunc) {
+	h := &StatsHandler{statsService: statsService}
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+	protected.GET("/events/:id/stats", h.GetEventStats)
+	protected.GET("/organizers/me/stats", h.GetOrganizerStats)
+}
+
+// GetEventStats handles GET /events/:id/stats (protected, event team)
+func (h *StatsHandler) GetEventStats(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "user not authenticated")
+		return
+	}
+
+	stats, err := h.statsService.GetEventStats(userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 200, stats)
+}
+
+// GetOrganizerStats handles GET /organizers/me/stats (protected)
+// Query Parameters: from, to - only events starting between these days (YYYY-MM-DD, both included)
+func (h *StatsHandler) GetOrganizerStats(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "user not authenticated")
+		return
+	}
+	var query domain.OrganizerStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	stats, err := h.statsService.GetOrganizerStats(userID, &query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, 200, stats)
+}
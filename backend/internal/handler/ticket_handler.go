@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TicketHandler handles HTTP requests for event ticket types (pricing tiers).
+type TicketHandler struct {
+	ticketService *service.TicketService
+}
+
+// NewTicketHandler creates a new TicketHandler and registers ticket type routes.
+//
+// Public routes:
+//   - GET /events/:id/ticket-types - List ticket types with seats sold
+//
+// Protected routes (organizer only):
+//   - POST /events/:id/ticket-types - Add a ticket type
+//   - PUT /events/:id/ticket-types/:ticket_type_id - Update a ticket type
+//   - DELETE /events/:id/ticket-types/:ticket_type_id - Remove a ticket type
+func NewTicketHandler(r *gin.Engine, ticketService *service.TicketService, authMiddleware gin.HandlerFunc) {
+	h := &TicketHandler{ticketService: ticketService}
+
+	r.GET("/events/:id/ticket-types", h.GetTicketTypes)
+
+	protected := r.Group("/events/:id/ticket-types")
+	protected.Use(authMiddleware)
+	protected.POST("", h.CreateTicketType)
+	protected.PUT("/:ticket_type_id", h.UpdateTicketType)
+	protected.DELETE("/:ticket_type_id", h.DeleteTicketType)
+}
+
+// GetTicketTypes handles GET /events/:id/ticket-types (public)
+func (h *TicketHandler) GetTicketTypes(c *gin.Context) {
+	ticketTypes, err := h.ticketService.GetTicketTypes(c.Param("id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	response.Success(c, 200, ticketTypes)
+}
+
+// CreateTicketType handles POST /events/:id/ticket-types (protected)
+func (h *TicketHandler) CreateTicketType(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateTicketTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	ticketType, err := h.ticketService.CreateTicketType(userID, c.Param("id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 201, ticketType)
+}
+
+// UpdateTicketType handles PUT /events/:id/ticket-types/:ticket_type_id (protected)
+func (h *TicketHandler) UpdateTicketType(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.UpdateTicketTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	ticketType, err := h.ticketService.UpdateTicketType(userID, c.Param("id"), c.Param("ticket_type_id"), &req)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, 200, ticketType)
+}
+
+// DeleteTicketType handles DELETE /events/:id/ticket-types/:ticket_type_id (protected)
+func (h *TicketHandler) DeleteTicketType(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	if err := h.ticketService.DeleteTicketType(userID, c.Param("id"), c.Param("ticket_type_id")); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.SuccessWithMessage(c, 200, "ticket type deleted")
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler struct {
+	transferService *service.TransferService
+}
+
+func NewTransferHandler(r *gin.Engine, transferService *service.TransferService, authMiddleware gin.HandlerFunc) {
+	h := &TransferHandler{transferService: transferService}
+
+	protected := r.Group("/")
+	protected.Use(authMiddleware)
+
+	// Offer one of my seats to someone else by email
+	protected.POST("/users/me/registrations/:id/transfer", h.Create)
+
+	// Transfers I sent or was invited to
+	protected.GET("/users/me/transfers", h.GetMyTransfers)
+
+	// Respond to an invitation (recipient) or withdraw it (sender)
+	protected.POST("/transfers/:id/accept", h.Accept)
+	protected.POST("/transfers/:id/decline", h.Decline)
+	protected.POST("/transfers/:id/cancel", h.Cancel)
+
+	// Audit trail of all transfers of an event's seats (event team only)
+	protected.GET("/events/:id/transfers", h.GetEventTransfers)
+}
+
+// POST /users/me/registrations/:id/transfer
+func (h *TransferHandler) Create(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	var req domain.CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	transfer, err := h.transferService.CreateTransfer(userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, 201, transfer)
+}
+
+// GET /users/me/transfers
+func (h *TransferHandler) GetMyTransfers(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	transfers, err := h.transferService.GetUserTransfers(userID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, transfers)
+}
+
+// POST /transfers/:id/accept
+func (h *TransferHandler) Accept(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	reg, err := h.transferService.AcceptTransfer(userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, 200, reg)
+}
+
+// POST /transfers/:id/decline
+func (h *TransferHandler) Decline(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	transfer, err := h.transferService.DeclineTransfer(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, transfer)
+}
+
+// POST /transfers/:id/cancel
+func (h *TransferHandler) Cancel(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	transfer, err := h.transferService.CancelTransfer(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, transfer)
+}
+
+// GET /events/:id/transfers
+func (h *TransferHandler) GetEventTransfers(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "unauthorized")
+		return
+	}
+
+	transfers, err := h.transferService.GetEventTransfers(userID, c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.Success(c, 200, transfers)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
 	"github.com/Fixsbreaker/event-hub/backend/internal/service"
 	"github.com/Fixsbreaker/event-hub/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -21,6 +22,35 @@ func NewUserHandler(r *gin.Engine, userService *service.UserService, authMiddlew
 
 	protected.GET("/me", h.GetMe)
 	protected.PATCH("/me", h.UpdateMe)
+
+	// Admin routes: the trash of deleted accounts
+	admin := r.Group("/users")
+	admin.Use(authMiddleware, middleware.RequireRole("admin"))
+	admin.GET("/trash", h.GetTrash)
+	admin.POST("/:id/restore", h.RestoreUser)
+}
+
+// GetTrash handles GET /users/trash (admin)
+// Returns the deleted accounts, most recently deleted first, until they are purged.
+func (h *UserHandler) GetTrash(c *gin.Context) {
+	trash, err := h.userService.GetTrash()
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, trash)
+}
+
+// RestoreUser handles POST /users/:id/restore (admin)
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	user, err := h.userService.RestoreUser(c.Param("id"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, user)
 }
 
 func (h *UserHandler) UpdateMe(c *gin.Context) {
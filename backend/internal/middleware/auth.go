@@ -40,3 +40,36 @@ func Auth(secret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireRole only lets requests through when the authenticated user has one of the given roles.
+// It must be chained after Auth, which stores the role from the JWT claims in the context.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("user_role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		response.Forbidden(c, "insufficient permissions")
+		c.Abort()
+	}
+}
+
+// OptionalAuth identifies the user when a valid Bearer token is sent, like Auth, but lets
+// anonymous requests (and requests with an invalid token) through without a user_id.
+// It is used by public routes whose response depends on who is asking.
+func OptionalAuth(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if tokenStr, ok := strings.CutPrefix(authHeader, "Bearer "); ok {
+			if claims, err := jwt.ValidateToken(tokenStr, secret); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("user_email", claims.Email)
+				c.Set("user_role", claims.Role)
+			}
+		}
+		c.Next()
+	}
+}
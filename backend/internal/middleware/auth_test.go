@@ -87,3 +87,46 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
 	}
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	secret := "test-secret"
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.OptionalAuth(secret))
+	r.GET("/public", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("user_id"))
+	})
+
+	token, err := jwt.GenerateToken("123", "test@example.com", "user", secret, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"anonymous", "", ""},
+		{"invalid token", "Bearer invalid.token.here", ""},
+		{"valid token", "Bearer " + token, "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/public", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+			if w.Body.String() != tt.want {
+				t.Fatalf("expected user_id %q, got %q", tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// signatureTolerance is how old a signed webhook may be before it is rejected (replay protection)
+const signatureTolerance = 5 * time.Minute
+
+// FakeProvider is an in-memory payment gateway for local development and tests.
+// Intents never leave the process; confirming one produces a signed webhook which is
+// POSTed to WebhookURL (when set), exactly like a real provider would call back.
+type FakeProvider struct {
+	secret     string
+	WebhookURL string
+	client     *http.Client
+
+	mu      sync.Mutex
+	intents map[string]*Intent
+}
+
+// NewFakeProvider creates a fake provider signing webhooks with the given secret
+func NewFakeProvider(secret, webhookURL string) *FakeProvider {
+	return &FakeProvider{
+		secret:     secret,
+		WebhookURL: webhookURL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		intents:    make(map[string]*Intent),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// CreateIntent registers a new intent awaiting confirmation
+func (p *FakeProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.AmountCents <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	id := "pi_fake_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	intent := &Intent{
+		ID:           id,
+		ClientSecret: id + "_secret_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:16],
+		AmountCents:  req.AmountCents,
+		Currency:     req.Currency,
+		Status:       IntentStatusRequiresConfirmation,
+		OrderID:      req.OrderID,
+	}
+
+	p.mu.Lock()
+	p.intents[id] = intent
+	p.mu.Unlock()
+
+	copied := *intent
+	return &copied, nil
+}
+
+// Confirm marks the intent as paid and delivers a payment.succeeded webhook
+func (p *FakeProvider) Confirm(ctx context.Context, intentID string) (*Intent, error) {
+	return p.complete(ctx, intentID, IntentStatusSucceeded, EventPaymentSucceeded)
+}
+
+// Decline marks the intent as failed and delivers a payment.failed webhook.
+// It simulates a rejected card and is only available on the fake provider.
+func (p *FakeProvider) Decline(ctx context.Context, intentID string) (*Intent, error) {
+	return p.complete(ctx, intentID, IntentStatusFailed, EventPaymentFailed)
+}
+
+// Refund returns part of a succeeded payment
+func (p *FakeProvider) Refund(ctx context.Context, intentID string, amountCents int64) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+	if intent.Status != IntentStatusSucceeded {
+		return nil, fmt.Errorf("cannot refund a payment with status %s", intent.Status)
+	}
+	if amountCents <= 0 || intent.RefundedCents+amountCents > intent.AmountCents {
+		return nil, ErrRefundExceeds
+	}
+	intent.RefundedCents += amountCents
+
+	return &Refund{
+		ID:          "re_fake_" + strings.ReplaceAll(uuid.NewString(), "-", ""),
+		IntentID:    intentID,
+		AmountCents: amountCents,
+	}, nil
+}
+
+// VerifyWebhook checks a "t=<unix>,v1=<hex hmac>" signature over "<t>.<payload>"
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	var timestamp, mac string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			mac = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || mac == "" {
+		return nil, ErrInvalidSignature
+	}
+	if time.Since(time.Unix(unix, 0)).Abs() > signatureTolerance {
+		return nil, ErrInvalidSignature
+	}
+	expected := p.sign(timestamp, payload)
+	if !hmac.Equal([]byte(expected), []byte(mac)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	return &event, nil
+}
+
+// SignPayload produces the signature header value for a webhook payload
+func (p *FakeProvider) SignPayload(payload []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, p.sign(timestamp, payload))
+}
+
+// BuildWebhook returns a signed webhook payload for an intent without delivering it
+func (p *FakeProvider) BuildWebhook(intentID, eventType string) ([]byte, string, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, "", ErrIntentNotFound
+	}
+	event := WebhookEvent{
+		ID:          "evt_fake_" + strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Type:        eventType,
+		IntentID:    intent.ID,
+		OrderID:     intent.OrderID,
+		AmountCents: intent.AmountCents,
+		CreatedAt:   time.Now().UTC(),
+	}
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode webhook: %w", err)
+	}
+	return payload, p.SignPayload(payload, time.Now()), nil
+}
+
+func (p *FakeProvider) complete(ctx context.Context, intentID, status, eventType string) (*Intent, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, ErrIntentNotFound
+	}
+	if intent.Status != IntentStatusRequiresConfirmation {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("payment intent already %s", intent.Status)
+	}
+	intent.Status = status
+	copied := *intent
+	p.mu.Unlock()
+
+	if p.WebhookURL != "" {
+		if err := p.deliver(ctx, intentID, eventType); err != nil {
+			return nil, err
+		}
+	}
+	return &copied, nil
+}
+
+// deliver POSTs a signed webhook to WebhookURL
+func (p *FakeProvider) deliver(ctx context.Context, intentID, eventType string) error {
+	payload, signature, err := p.BuildWebhook(intentID, eventType)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *FakeProvider) sign(timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
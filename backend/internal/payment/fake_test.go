@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeProvider_WebhookSignature(t *testing.T) {
+	provider := NewFakeProvider("secret", "")
+
+	intent, err := provider.CreateIntent(context.Background(), IntentRequest{OrderID: "order-1", AmountCents: 2500, Currency: "USD"})
+	require.NoError(t, err)
+
+	payload, signature, err := provider.BuildWebhook(intent.ID, EventPaymentSucceeded)
+	require.NoError(t, err)
+
+	event, err := provider.VerifyWebhook(payload, signature)
+	require.NoError(t, err)
+	assert.Equal(t, intent.ID, event.IntentID)
+	assert.Equal(t, "order-1", event.OrderID)
+	assert.Equal(t, EventPaymentSucceeded, event.Type)
+
+	// tampered payload
+	_, err = provider.VerifyWebhook(append(payload, ' '), signature)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// signed with another secret
+	other := NewFakeProvider("other-secret", "")
+	_, err = provider.VerifyWebhook(payload, other.SignPayload(payload, time.Now()))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// replayed long after it was signed
+	_, err = provider.VerifyWebhook(payload, provider.SignPayload(payload, time.Now().Add(-time.Hour)))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = provider.VerifyWebhook(payload, "garbage")
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestFakeProvider_ConfirmAndRefund(t *testing.T) {
+	provider := NewFakeProvider("secret", "")
+	ctx := context.Background()
+
+	intent, err := provider.CreateIntent(ctx, IntentRequest{OrderID: "order-1", AmountCents: 1000, Currency: "USD"})
+	require.NoError(t, err)
+
+	_, err = provider.Refund(ctx, intent.ID, 500)
+	assert.Error(t, err, "cannot refund before the payment succeeded")
+
+	confirmed, err := provider.Confirm(ctx, intent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, IntentStatusSucceeded, confirmed.Status)
+
+	_, err = provider.Confirm(ctx, intent.ID)
+	assert.Error(t, err, "an intent can only be confirmed once")
+
+	refund, err := provider.Refund(ctx, intent.ID, 600)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), refund.AmountCents)
+
+	_, err = provider.Refund(ctx, intent.ID, 500)
+	assert.ErrorIs(t, err, ErrRefundExceeds)
+
+	_, err = provider.Confirm(ctx, "pi_missing")
+	assert.ErrorIs(t, err, ErrIntentNotFound)
+}
@@ -0,0 +1,82 @@
+// Package payment defines the abstraction over external payment gateways
+// used for checkout of paid tickets, plus a fully local fake implementation.
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Webhook event types delivered by providers
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+// Intent statuses
+const (
+	IntentStatusRequiresConfirmation = "requires_confirmation"
+	IntentStatusSucceeded            = "succeeded"
+	IntentStatusFailed               = "failed"
+)
+
+// SignatureHeader is the HTTP header carrying the webhook signature
+const SignatureHeader = "X-Payment-Signature"
+
+var (
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrRefundExceeds    = errors.New("refund amount exceeds captured amount")
+)
+
+// IntentRequest describes the amount to collect for an order
+type IntentRequest struct {
+	OrderID     string
+	AmountCents int64
+	Currency    string
+	Description string
+}
+
+// Intent is a provider-side payment attempt
+type Intent struct {
+	ID            string
+	ClientSecret  string // Handed to the front end to complete the payment
+	AmountCents   int64
+	RefundedCents int64
+	Currency      string
+	Status        string
+	OrderID       string
+}
+
+// Refund is the result of refunding (part of) a captured payment
+type Refund struct {
+	ID          string
+	IntentID    string
+	AmountCents int64
+}
+
+// WebhookEvent is a verified notification from the provider
+type WebhookEvent struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	IntentID    string    `json:"intent_id"`
+	OrderID     string    `json:"order_id"`
+	AmountCents int64     `json:"amount_cents"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PaymentProvider is implemented by every payment gateway integration.
+// Implementations must be safe for concurrent use.
+type PaymentProvider interface {
+	// Name identifies the provider (stored on orders)
+	Name() string
+	// CreateIntent starts collecting a payment for an order
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	// Confirm completes a payment intent; the outcome is also reported through a webhook
+	Confirm(ctx context.Context, intentID string) (*Intent, error)
+	// Refund returns (part of) a captured payment to the customer
+	Refund(ctx context.Context, intentID string, amountCents int64) (*Refund, error)
+	// VerifyWebhook checks the signature of a webhook payload and decodes it
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CancellationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewCancellationPolicyRepository(db *gorm.DB) *CancellationPolicyRepository {
+	return &CancellationPolicyRepository{db: db}
+}
+
+// GetByEvent retrieves the cancellation policy of an event, or nil if the event has none
+func (r *CancellationPolicyRepository) GetByEvent(eventID string) (*domain.CancellationPolicy, error) {
+	var policy domain.CancellationPolicy
+	result := r.db.Where("event_id = ?", eventID).First(&policy)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", result.Error)
+	}
+	return &policy, nil
+}
+
+// Upsert creates or replaces the cancellation policy of an event
+func (r *CancellationPolicyRepository) Upsert(policy *domain.CancellationPolicy) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"allow_cancellation", "cutoff_hours", "refund_tiers", "updated_at"}),
+	}).Create(policy).Error; err != nil {
+		return fmt.Errorf("failed to save cancellation policy: %w", err)
+	}
+	return nil
+}
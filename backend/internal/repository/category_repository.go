@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// categoryTreeCTE selects a category and all of its descendants.
+// The root is matched on the column substituted for %s (id or slug).
+const categoryTreeCTE = `
+	WITH RECURSIVE category_tree AS (
+		SELECT id FROM categories WHERE %s = ?
+		UNION ALL
+		SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+	)
+	SELECT id FROM category_tree`
+
+// categoryTreeQuery builds a sub-query returning the IDs of the category
+// identified by idOrSlug and all of its descendants.
+func categoryTreeQuery(db *gorm.DB, idOrSlug string) *gorm.DB {
+	column := "slug"
+	if _, err := uuid.Parse(idOrSlug); err == nil {
+		column = "id"
+	}
+	return db.Raw(fmt.Sprintf(categoryTreeCTE, column), idOrSlug)
+}
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// Create inserts a new category
+func (r *CategoryRepository) Create(category *domain.Category) error {
+	if err := r.db.Create(category).Error; err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing category
+func (r *CategoryRepository) Update(category *domain.Category) error {
+	if err := r.db.Save(category).Error; err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a category. Sub-categories must be removed or moved first;
+// events in the category keep existing with their category cleared (and a new version).
+// Returns the IDs of these events.
+func (r *CategoryRepository) Delete(id string) ([]string, error) {
+	var children int64
+	if err := r.db.Model(&domain.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return nil, fmt.Errorf("failed to check sub-categories: %w", err)
+	}
+	if children > 0 {
+		return nil, fmt.Errorf("category has sub-categories")
+	}
+
+	var eventIDs []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Event{}).Where("category_id = ?", id).Pluck("id", &eventIDs).Error; err != nil {
+			return fmt.Errorf("failed to find events of category: %w", err)
+		}
+		if len(eventIDs) > 0 {
+			if err := tx.Model(&domain.Event{}).Where("id IN ?", eventIDs).Updates(map[string]interface{}{"category_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return fmt.Errorf("failed to detach events from category: %w", err)
+			}
+		}
+		result := tx.Delete(&domain.Category{}, "id = ?", id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete category: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("category not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eventIDs, nil
+}
+
+// GetByID retrieves a category by ID
+func (r *CategoryRepository) GetByID(id string) (*domain.Category, error) {
+	var category domain.Category
+	result := r.db.Where("id = ?", id).First(&category)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to get category: %w", result.Error)
+	}
+	return &category, nil
+}
+
+// GetAll retrieves all categories ordered by name
+func (r *CategoryRepository) GetAll() ([]domain.Category, error) {
+	var categories []domain.Category
+	if err := r.db.Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return categories, nil
+}
+
+// GetDescendantIDs returns the IDs of a category and all of its descendants
+func (r *CategoryRepository) GetDescendantIDs(id string) ([]string, error) {
+	var ids []string
+	if err := categoryTreeQuery(r.db, id).Scan(&ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sub-categories: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type EventFileRepository struct {
+	db *gorm.DB
+}
+
+func NewEventFileRepository(db *gorm.DB) *EventFileRepository {
+	return &EventFileRepository{db: db}
+}
+
+// Create inserts a new attachment
+func (r *EventFileRepository) Create(file *domain.EventFile) error {
+	if err := r.db.Create(file).Error; err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+// ReplaceCover makes file the event's cover image and returns the previous one (nil when there was none),
+// whose objects the caller removes from the blob store
+func (r *EventFileRepository) ReplaceCover(file *domain.EventFile) (*domain.EventFile, error) {
+	var previous *domain.EventFile
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockEvent(tx, file.EventID); err != nil {
+			return err
+		}
+		var existing domain.EventFile
+		err := tx.Where("event_id = ? AND kind = ?", file.EventID, domain.EventFileKindCover).First(&existing).Error
+		switch {
+		case err == nil:
+			if err := tx.Delete(&existing).Error; err != nil {
+				return err
+			}
+			previous = &existing
+		case err != gorm.ErrRecordNotFound:
+			return err
+		}
+		return tx.Create(file).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save cover image: %w", err)
+	}
+	return previous, nil
+}
+
+// Delete removes a file record of an event and returns it
+func (r *EventFileRepository) Delete(eventID, fileID string) (*domain.EventFile, error) {
+	file, err := r.GetByID(eventID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Delete(file).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete file: %w", err)
+	}
+	return file, nil
+}
+
+// GetByID retrieves a file of an event
+func (r *EventFileRepository) GetByID(eventID, fileID string) (*domain.EventFile, error) {
+	var file domain.EventFile
+	if err := r.db.Where("id = ? AND event_id = ?", fileID, eventID).First(&file).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("file not found")
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return &file, nil
+}
+
+// GetCover retrieves the cover image of an event
+func (r *EventFileRepository) GetCover(eventID string) (*domain.EventFile, error) {
+	var file domain.EventFile
+	if err := r.db.Where("event_id = ? AND kind = ?", eventID, domain.EventFileKindCover).First(&file).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("the event has no cover image")
+		}
+		return nil, fmt.Errorf("failed to get cover image: %w", err)
+	}
+	return &file, nil
+}
+
+// GetByEvent returns the files of an event, oldest first
+func (r *EventFileRepository) GetByEvent(eventID string) ([]domain.EventFile, error) {
+	files := []domain.EventFile{}
+	if err := r.db.Where("event_id = ?", eventID).Order("created_at, id").Find(&files).Error; err != nil {
+		return nil, fmt.Errorf("failed to get files: %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EventInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewEventInvitationRepository(db *gorm.DB) *EventInvitationRepository {
+	return &EventInvitationRepository{db: db}
+}
+
+// CreateMany invites the given (normalized) email addresses to an event in one transaction.
+// Addresses that are already invited are skipped and returned separately.
+func (r *EventInvitationRepository) CreateMany(eventID, invitedBy string, emails []string) ([]domain.EventInvitation, []string, error) {
+	invited := []domain.EventInvitation{}
+	alreadyInvited := []string{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []string
+		if err := tx.Model(&domain.EventInvitation{}).
+			Where("event_id = ? AND email IN ?", eventID, emails).
+			Pluck("email", &existing).Error; err != nil {
+			return fmt.Errorf("failed to check existing invitations: %w", err)
+		}
+		known := make(map[string]bool, len(existing))
+		for _, email := range existing {
+			known[email] = true
+		}
+
+		for _, email := range emails {
+			if known[email] {
+				alreadyInvited = append(alreadyInvited, email)
+				continue
+			}
+			invited = append(invited, domain.EventInvitation{
+				ID:        uuid.NewString(),
+				EventID:   eventID,
+				Email:     email,
+				InvitedBy: invitedBy,
+			})
+		}
+		if len(invited) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(&invited, 100).Error; err != nil {
+			return fmt.Errorf("failed to create invitations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return invited, alreadyInvited, nil
+}
+
+// GetByEvent returns the invitations of an event, oldest first
+func (r *EventInvitationRepository) GetByEvent(eventID string) ([]domain.EventInvitation, error) {
+	invitations := []domain.EventInvitation{}
+	if err := r.db.Where("event_id = ?", eventID).Order("created_at ASC, email ASC").Find(&invitations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// Delete revokes an invitation of an event
+func (r *EventInvitationRepository) Delete(eventID, invitationID string) error {
+	result := r.db.Where("id = ? AND event_id = ?", invitationID, eventID).Delete(&domain.EventInvitation{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete invitation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("invitation not found")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewEventMemberRepository(db *gorm.DB) *EventMemberRepository {
+	return &EventMemberRepository{db: db}
+}
+
+// Upsert adds a user to an event's team, or changes their role if they are already on it
+func (r *EventMemberRepository) Upsert(member *domain.EventMember) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "added_by", "updated_at"}),
+	}).Create(member).Error; err != nil {
+		return fmt.Errorf("failed to save event member: %w", err)
+	}
+	return nil
+}
+
+// Get returns a member of an event's team with their user
+func (r *EventMemberRepository) Get(eventID, userID string) (*domain.EventMember, error) {
+	var member domain.EventMember
+	if err := r.db.Preload("User").Where("event_id = ? AND user_id = ?", eventID, userID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("member not found")
+		}
+		return nil, fmt.Errorf("failed to get event member: %w", err)
+	}
+	return &member, nil
+}
+
+// GetByEvent returns the members of an event's team with their users, oldest first
+func (r *EventMemberRepository) GetByEvent(eventID string) ([]domain.EventMember, error) {
+	members := []domain.EventMember{}
+	if err := r.db.Preload("User").Where("event_id = ?", eventID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event members: %w", err)
+	}
+	return members, nil
+}
+
+// Delete removes a user from an event's team
+func (r *EventMemberRepository) Delete(eventID, userID string) error {
+	result := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&domain.EventMember{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete event member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
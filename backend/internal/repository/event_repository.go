@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EventRepository struct {
@@ -25,38 +27,526 @@ func (r *EventRepository) Create(event *domain.Event) error {
 	return nil
 }
 
-// update modifies an existing event
-func (r *EventRepository) Update(userID string, event *domain.Event) error {
-	if event.OrganizerID != userID {
-		return fmt.Errorf("user does not own the event")
+// CreateWithTags inserts a new event and attaches the given tags (created on demand) in one transaction,
+// recording its creation in the event history
+func (r *EventRepository) CreateWithTags(event *domain.Event, tagNames []string, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tags, err := findOrCreateTags(tx, tagNames)
+		if err != nil {
+			return err
+		}
+		event.Tags = tags
+		if err := tx.Create(event).Error; err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// Clone inserts clone as a copy of source, in one transaction: the tags are shared, while the ticket types,
+// promo codes, cancellation policy, registration form, speakers and sessions are copied with their dates
+// moved by the same offset as the start. Registrations (of the event and its sessions), invitations and
+// the team stay with the source. The creation of the clone is recorded in its history.
+func (r *EventRepository) Clone(source, clone *domain.Event, revision *domain.EventRevision) error {
+	offset := clone.StartDatetime.Sub(source.StartDatetime)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		clone.Tags = source.Tags
+		if err := tx.Omit("Tags.*").Create(clone).Error; err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+		if err := recordRevision(tx, revision); err != nil {
+			return err
+		}
+
+		var ticketTypes []domain.TicketType
+		if err := tx.Where("event_id = ?", source.ID).Find(&ticketTypes).Error; err != nil {
+			return fmt.Errorf("failed to get ticket types: %w", err)
+		}
+		copies := make(map[string]domain.TicketType, len(ticketTypes))
+		for _, ticketType := range ticketTypes {
+			copied := ticketType
+			copied.ID = uuid.NewString()
+			copied.EventID = clone.ID
+			copied.SalesStart = domain.ShiftTime(ticketType.SalesStart, offset)
+			copied.SalesEnd = domain.ShiftTime(ticketType.SalesEnd, offset)
+			copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&copied).Error; err != nil {
+				return fmt.Errorf("failed to copy ticket type: %w", err)
+			}
+			copies[ticketType.ID] = copied
+		}
+
+		var promos []domain.PromoCode
+		if err := tx.Preload("TicketTypes").Where("event_id = ?", source.ID).Find(&promos).Error; err != nil {
+			return fmt.Errorf("failed to get promo codes: %w", err)
+		}
+		for _, promo := range promos {
+			copied := promo
+			copied.ID = uuid.NewString()
+			copied.EventID = clone.ID
+			copied.ValidFrom = domain.ShiftTime(promo.ValidFrom, offset)
+			copied.ValidUntil = domain.ShiftTime(promo.ValidUntil, offset)
+			copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+			copied.TicketTypes = nil
+			for _, ticketType := range promo.TicketTypes {
+				if restricted, ok := copies[ticketType.ID]; ok {
+					copied.TicketTypes = append(copied.TicketTypes, restricted)
+				}
+			}
+			if err := tx.Omit("TicketTypes.*").Create(&copied).Error; err != nil {
+				return fmt.Errorf("failed to copy promo code: %w", err)
+			}
+			// active defaults to true on insert
+			if !promo.Active {
+				if err := tx.Model(&copied).Update("active", false).Error; err != nil {
+					return fmt.Errorf("failed to copy promo code: %w", err)
+				}
+			}
+		}
+
+		var policies []domain.CancellationPolicy
+		if err := tx.Where("event_id = ?", source.ID).Limit(1).Find(&policies).Error; err != nil {
+			return fmt.Errorf("failed to get cancellation policy: %w", err)
+		}
+		for _, policy := range policies {
+			policy.EventID = clone.ID
+			policy.CreatedAt, policy.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&policy).Error; err != nil {
+				return fmt.Errorf("failed to copy cancellation policy: %w", err)
+			}
+		}
+
+		var forms []domain.RegistrationForm
+		if err := tx.Where("event_id = ?", source.ID).Limit(1).Find(&forms).Error; err != nil {
+			return fmt.Errorf("failed to get registration form: %w", err)
+		}
+		for _, form := range forms {
+			form.EventID = clone.ID
+			form.AnswersEditableUntil = domain.ShiftTime(form.AnswersEditableUntil, offset)
+			form.CreatedAt, form.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&form).Error; err != nil {
+				return fmt.Errorf("failed to copy registration form: %w", err)
+			}
+		}
+
+		var speakers []domain.Speaker
+		if err := tx.Where("event_id = ?", source.ID).Find(&speakers).Error; err != nil {
+			return fmt.Errorf("failed to get speakers: %w", err)
+		}
+		speakerCopies := make(map[string]domain.Speaker, len(speakers))
+		for _, speaker := range speakers {
+			copied := speaker
+			copied.ID = uuid.NewString()
+			copied.EventID = clone.ID
+			copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&copied).Error; err != nil {
+				return fmt.Errorf("failed to copy speaker: %w", err)
+			}
+			speakerCopies[speaker.ID] = copied
+		}
+
+		var sessions []domain.Session
+		if err := tx.Preload("Speakers").Where("event_id = ?", source.ID).Find(&sessions).Error; err != nil {
+			return fmt.Errorf("failed to get sessions: %w", err)
+		}
+		for _, session := range sessions {
+			copied := session
+			copied.ID = uuid.NewString()
+			copied.EventID = clone.ID
+			copied.StartDatetime = session.StartDatetime.Add(offset)
+			copied.EndDatetime = session.EndDatetime.Add(offset)
+			copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+			copied.Speakers = nil
+			for _, speaker := range session.Speakers {
+				copied.Speakers = append(copied.Speakers, speakerCopies[speaker.ID])
+			}
+			if err := tx.Omit("Speakers.*").Create(&copied).Error; err != nil {
+				return fmt.Errorf("failed to copy session: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// findOrCreateTags resolves normalized tag names to tag rows, inserting missing ones
+func findOrCreateTags(tx *gorm.DB, names []string) ([]domain.Tag, error) {
+	tags := make([]domain.Tag, 0, len(names))
+	for _, name := range names {
+		var tag domain.Tag
+		if err := tx.Where(domain.Tag{Name: name}).Attrs(domain.Tag{ID: uuid.NewString()}).FirstOrCreate(&tag).Error; err != nil {
+			return nil, fmt.Errorf("failed to resolve tag %q: %w", name, err)
+		}
+		tags = append(tags, tag)
 	}
-	result := r.db.Save(&event)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update event: %w", result.Error)
+	return tags, nil
+}
+
+// CategoryExists checks whether a category with the given ID exists
+func (r *EventRepository) CategoryExists(categoryID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.Category{}).Where("id = ?", categoryID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check category: %w", err)
 	}
-	return nil
+	return count > 0, nil
 }
 
-// delete removes an event by ID
-func (r *EventRepository) Delete(userID string, eventID string) error {
-	result := r.db.Delete(&domain.Event{}, "id = ? AND organizer_id = ?", eventID, userID)
+// update saves changes made to a copy of an event read at event.Version, and bumps the version.
+// Callers authorize the change (see service.authorizeEvent).
+// Under the event row lock it fails with domain.ErrEventVersionMismatch when the event was changed
+// since it was read, with domain.ErrCapacityBelowSeatsTaken when the capacity would drop below
+// the seats held, and with domain.ErrSessionsOutsideEvent when new dates would leave sessions of the
+// agenda outside the event. Registrations and sessions take the same lock, so none can slip in between.
+// The tags are replaced by tagNames (unknown ones are created, nil keeps them) and the revision is
+// recorded with the new version in the same transaction.
+func (r *EventRepository) Update(event *domain.Event, tagNames []string, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockEvent(tx, event.ID)
+		if err != nil {
+			return err
+		}
+		if current.Version != event.Version {
+			return domain.ErrEventVersionMismatch
+		}
+		if event.Capacity < current.Capacity {
+			var held int64
+			if err := tx.Model(&domain.Registration{}).
+				Where("event_id = ? AND status IN ?", event.ID, domain.SeatHoldingStatuses).
+				Count(&held).Error; err != nil {
+				return fmt.Errorf("failed to count registrations: %w", err)
+			}
+			if int(held) > event.Capacity {
+				return domain.NewCodedError(domain.ErrCapacityBelowSeatsTaken.Code,
+					fmt.Sprintf("the capacity cannot be lower than the %d seats already taken", held))
+			}
+		}
+
+		if !event.StartDatetime.Equal(current.StartDatetime) || !event.EndDatetime.Equal(current.EndDatetime) {
+			var outside domain.Session
+			err := tx.Where("event_id = ? AND (start_datetime < ? OR end_datetime > ?)", event.ID, event.StartDatetime, event.EndDatetime).
+				Order("start_datetime").First(&outside).Error
+			if err == nil {
+				return domain.NewCodedError(domain.ErrSessionsOutsideEvent.Code,
+					fmt.Sprintf("session %q would no longer take place within the event; move it first", outside.Title))
+			}
+			if err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("failed to check sessions: %w", err)
+			}
+		}
+
+		// associations (tags, category) are managed explicitly, never through Save,
+		// and the scheduler's lease is left to the scheduler
+		event.Version++
+		if err := tx.Omit(clause.Associations, "publish_claimed_until").Save(event).Error; err != nil {
+			event.Version--
+			return fmt.Errorf("failed to update event: %w", err)
+		}
+		if tagNames != nil {
+			tags, err := findOrCreateTags(tx, tagNames)
+			if err != nil {
+				event.Version--
+				return err
+			}
+			if err := tx.Model(event).Association("Tags").Replace(tags); err != nil {
+				event.Version--
+				return fmt.Errorf("failed to update event tags: %w", err)
+			}
+			event.Tags = tags
+		}
+		if err := recordRevision(tx, revision); err != nil {
+			event.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+// delete removes an event by ID, recording the deletion in its history
+func (r *EventRepository) Delete(eventID string, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Event{}).Where("id = ?", eventID).
+			Update("version", gorm.Expr("version + 1")).Error; err != nil {
+			return fmt.Errorf("failed to delete event: %w", err)
+		}
+		result := tx.Delete(&domain.Event{}, "id = ?", eventID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("event not found")
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// GetTrash returns the soft-deleted events a user may restore, most recently deleted first:
+// the ones they organize, own as a team member, or that belong to an organization they administer
+// (the same access authorizeEvent gives for deleting and restoring an event).
+func (r *EventRepository) GetTrash(userID string) ([]domain.Event, error) {
+	teams := r.db.Model(&domain.EventMember{}).Select("event_id").
+		Where("user_id = ? AND role IN ?", userID, domain.EventRolesAllowing(domain.EventActionDelete))
+	orgs := r.db.Model(&domain.OrganizationMember{}).Select("organization_id").
+		Where("user_id = ? AND role = ?", userID, domain.OrganizationRoleAdmin)
+
+	events := []domain.Event{}
+	if err := r.db.Unscoped().Preload("Category").Preload("Tags").
+		Where("deleted_at IS NOT NULL").
+		Where("organizer_id = ? OR id IN (?) OR organization_id IN (?)", userID, teams, orgs).
+		Order("deleted_at DESC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to get deleted events: %w", err)
+	}
+	return events, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted event by ID
+func (r *EventRepository) GetDeletedByID(id string) (*domain.Event, error) {
+	var event domain.Event
+	result := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&event)
 	if result.Error != nil {
-		return fmt.Errorf("failed to delete event: %w", result.Error)
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("event not found in the trash")
+		}
+		return nil, fmt.Errorf("failed to get deleted event: %w", result.Error)
+	}
+	return &event, nil
+}
+
+// Restore takes a soft-deleted event back out of the trash, recording it in the event history
+func (r *EventRepository) Restore(eventID string, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&domain.Event{}).
+			Where("id = ? AND deleted_at IS NOT NULL", eventID).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("event not found in the trash")
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// TransitionStatus moves an event from one status to another and records the change, in one transaction.
+// The update only applies while the event is still in change.FromStatus, so concurrent changes can't both win.
+// Leaving draft drops any publishing schedule. The revision is recorded in the event history alongside.
+func (r *EventRepository) TransitionStatus(change *domain.EventStatusChange, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Event{}).
+			Where("id = ? AND status = ?", change.EventID, change.FromStatus).
+			Updates(map[string]interface{}{"status": change.ToStatus, "publish_at": nil, "publish_claimed_until": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("event status was changed by someone else, please retry")
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// SetPublishAt schedules (or with nil, unschedules) the publishing of a draft event.
+// The revision, if any, is recorded in the event history alongside.
+func (r *EventRepository) SetPublishAt(eventID string, publishAt *time.Time, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Event{}).
+			Where("id = ? AND status = ?", eventID, domain.EventStatusDraft).
+			Updates(map[string]interface{}{"publish_at": publishAt, "publish_claimed_until": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return fmt.Errorf("failed to schedule publishing: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("only draft events can be scheduled for publishing")
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// ClaimDuePublications leases up to limit drafts whose publish_at has passed to the calling
+// scheduler replica until now+lease, and returns them. Rows are locked with SKIP LOCKED while
+// leasing, so replicas running the scheduler at once never claim the same event. publish_at is
+// left alone: it is cleared when the event is published, so if the replica dies before that the
+// event is claimed again once the lease has expired. The returned events carry their lease, which
+// identifies the claim to PublishScheduled and ReleasePublication.
+func (r *EventRepository) ClaimDuePublications(now time.Time, lease time.Duration, limit int) ([]domain.Event, error) {
+	var due []domain.Event
+	// stored timestamps keep microseconds, so the lease compares equal when read back
+	claimedUntil := now.Add(lease).Truncate(time.Microsecond)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", domain.EventStatusDraft, now).
+			Where("publish_claimed_until IS NULL OR publish_claimed_until <= ?", now).
+			Order("publish_at ASC").
+			Limit(limit).
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to find due publications: %w", err)
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		ids := make([]string, len(due))
+		for i, event := range due {
+			ids[i] = event.ID
+		}
+		// a lease is bookkeeping of the scheduler: it doesn't change the event's version
+		if err := tx.Model(&domain.Event{}).Where("id IN ?", ids).
+			Update("publish_claimed_until", claimedUntil).Error; err != nil {
+			return fmt.Errorf("failed to claim due publications: %w", err)
+		}
+		for i := range due {
+			due[i].PublishClaimedUntil = &claimedUntil
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// PublishScheduled publishes a draft claimed with ClaimDuePublications and records the change, in one
+// transaction. It only applies while the draft is still scheduled, due at now and leased until
+// claimedUntil; if its schedule was changed or removed meanwhile, or its lease expired and another
+// replica claimed it, nothing is changed and false is returned.
+func (r *EventRepository) PublishScheduled(change *domain.EventStatusChange, revision *domain.EventRevision, now, claimedUntil time.Time) (bool, error) {
+	published := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Event{}).
+			Where("id = ? AND status = ?", change.EventID, domain.EventStatusDraft).
+			Where("publish_at IS NOT NULL AND publish_at <= ? AND publish_claimed_until = ?", now, claimedUntil).
+			Updates(map[string]interface{}{"status": domain.EventStatusPublished, "publish_at": nil, "publish_claimed_until": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return fmt.Errorf("failed to publish event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+		published = true
+		return recordRevision(tx, revision)
+	})
+	if err != nil {
+		return false, err
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("event not found")
+	return published, nil
+}
+
+// ReleasePublication gives up a lease on a claimed draft, so the next scheduler run retries it.
+// A lease another replica took over since (after this one expired) is left alone.
+func (r *EventRepository) ReleasePublication(eventID string, claimedUntil time.Time) error {
+	if err := r.db.Model(&domain.Event{}).Where("id = ? AND publish_claimed_until = ?", eventID, claimedUntil).
+		Update("publish_claimed_until", nil).Error; err != nil {
+		return fmt.Errorf("failed to release publication of event %s: %w", eventID, err)
 	}
 	return nil
 }
 
-// publish event (set status to "published")
-func (r *EventRepository) UpdateStatus(userID string, eventID string, newStatus string) error {
-	result := r.db.Model(&domain.Event{}).Where("id = ? AND organizer_id = ?", eventID, userID).Update("status", newStatus)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update status: %w", result.Error)
+// CompleteEnded marks all published events that ended before now as completed and records the changes.
+// Returns the IDs of the completed events.
+func (r *EventRepository) CompleteEnded(now time.Time) ([]string, error) {
+	var ids []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Event{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND end_datetime <= ?", domain.EventStatusPublished, now).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find ended events: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&domain.Event{}).
+			Where("id IN ? AND status = ?", ids, domain.EventStatusPublished).
+			Updates(map[string]interface{}{"status": domain.EventStatusCompleted, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return fmt.Errorf("failed to complete events: %w", err)
+		}
+		changes := make([]domain.EventStatusChange, len(ids))
+		for i, id := range ids {
+			changes[i] = domain.EventStatusChange{
+				ID:         uuid.NewString(),
+				EventID:    id,
+				FromStatus: domain.EventStatusPublished,
+				ToStatus:   domain.EventStatusCompleted,
+				Reason:     "event ended",
+			}
+		}
+		if err := tx.CreateInBatches(&changes, 100).Error; err != nil {
+			return fmt.Errorf("failed to record status changes: %w", err)
+		}
+
+		var completed []domain.Event
+		if err := tx.Select("id", "version").Where("id IN ?", ids).Find(&completed).Error; err != nil {
+			return fmt.Errorf("failed to record event history: %w", err)
+		}
+		revisions := make([]domain.EventRevision, len(completed))
+		for i, event := range completed {
+			revisions[i] = domain.EventRevision{
+				ID:      uuid.NewString(),
+				EventID: event.ID,
+				Version: event.Version,
+				Action:  domain.EventStatusCompleted,
+				Changes: []domain.FieldChange{{Field: "status", Old: domain.EventStatusPublished, New: domain.EventStatusCompleted}},
+			}
+		}
+		if err := tx.CreateInBatches(&revisions, 100).Error; err != nil {
+			return fmt.Errorf("failed to record event history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("event not found or user does not own the event")
+	return ids, nil
+}
+
+// GetStatusHistory returns the status changes of an event, oldest first
+func (r *EventRepository) GetStatusHistory(eventID string) ([]domain.EventStatusChange, error) {
+	changes := []domain.EventStatusChange{}
+	if err := r.db.Where("event_id = ?", eventID).Order("created_at ASC").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	return changes, nil
+}
+
+// GetRevisions returns the history of an event, oldest first
+func (r *EventRepository) GetRevisions(eventID string) ([]domain.EventRevision, error) {
+	revisions := []domain.EventRevision{}
+	if err := r.db.Where("event_id = ?", eventID).Order("version ASC, created_at ASC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event history: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevisionsSince returns the revisions of an event from the given version on, newest first
+func (r *EventRepository) GetRevisionsSince(eventID string, version int64) ([]domain.EventRevision, error) {
+	revisions := []domain.EventRevision{}
+	if err := r.db.Where("event_id = ? AND version >= ?", eventID, version).
+		Order("version DESC, created_at DESC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event history: %w", err)
+	}
+	return revisions, nil
+}
+
+// recordRevision stores a revision in the transaction of the change it records, stamped with the version
+// of the event the change produced. A nil revision records nothing.
+func recordRevision(tx *gorm.DB, revision *domain.EventRevision) error {
+	if revision == nil {
+		return nil
+	}
+	if revision.ID == "" {
+		revision.ID = uuid.NewString()
+	}
+	var event domain.Event
+	if err := tx.Unscoped().Select("version").Where("id = ?", revision.EventID).First(&event).Error; err != nil {
+		return fmt.Errorf("failed to record event history: %w", err)
+	}
+	revision.Version = event.Version
+	if err := tx.Create(revision).Error; err != nil {
+		return fmt.Errorf("failed to record event history: %w", err)
 	}
 	return nil
 }
@@ -64,7 +554,7 @@ func (r *EventRepository) UpdateStatus(userID string, eventID string, newStatus
 // getByID retrieves an event by ID
 func (r *EventRepository) GetByID(id string) (*domain.Event, error) {
 	var event domain.Event
-	result := r.db.Where("id = ?", id).First(&event)
+	result := r.db.Preload("Category").Preload("Tags").Where("id = ?", id).First(&event)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("event not found")
@@ -74,10 +564,109 @@ func (r *EventRepository) GetByID(id string) (*domain.Event, error) {
 	return &event, nil
 }
 
-// getAll retrieves all events
-func (r *EventRepository) GetAll() ([]domain.Event, error) {
+// GetBySlug retrieves an event by its share link slug
+func (r *EventRepository) GetBySlug(slug string) (*domain.Event, error) {
+	var event domain.Event
+	result := r.db.Preload("Category").Preload("Tags").Where("slug = ?", slug).First(&event)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event by slug: %w", result.Error)
+	}
+	return &event, nil
+}
+
+// GetMemberRole returns the role of a user on an event's team, or "" if they are not a member.
+// The organizer has no member row: they are always an owner (see service.eventRole).
+func (r *EventRepository) GetMemberRole(eventID, userID string) (string, error) {
+	var roles []string
+	if err := r.db.Model(&domain.EventMember{}).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error; err != nil {
+		return "", fmt.Errorf("failed to get event role: %w", err)
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return roles[0], nil
+}
+
+// GetOrganizationRole returns a user's role in an organization, or "" if they don't belong to it
+func (r *EventRepository) GetOrganizationRole(orgID, userID string) (string, error) {
+	var roles []string
+	if err := r.db.Model(&domain.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error; err != nil {
+		return "", fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return roles[0], nil
+}
+
+// TransferOwnership hands an event over to a new organizer and/or organization in one transaction.
+// The new organizer leaves the event's team, since the organizer is always an owner.
+// The revision is recorded in the event history alongside.
+func (r *EventRepository) TransferOwnership(eventID, organizerID string, organizationID *string, revision *domain.EventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+			"organizer_id":    organizerID,
+			"organization_id": organizationID,
+			"version":         gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("failed to transfer event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("event not found")
+		}
+		if err := tx.Where("event_id = ? AND user_id = ?", eventID, organizerID).Delete(&domain.EventMember{}).Error; err != nil {
+			return fmt.Errorf("failed to update event team: %w", err)
+		}
+		return recordRevision(tx, revision)
+	})
+}
+
+// HasPrivateAccess reports whether a user may see a private event without its access code:
+// they are on the event's team, their email address is invited, or they already hold a registration for it
+func (r *EventRepository) HasPrivateAccess(eventID, userID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+	var count int64
+	if err := r.db.Model(&domain.EventMember{}).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check event team: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&domain.EventInvitation{}).
+		Joins("JOIN users ON LOWER(users.email) = event_invitations.email").
+		Where("event_invitations.event_id = ? AND users.id = ?", eventID, userID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check invitation: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&domain.Registration{}).
+		Where("event_id = ? AND user_id = ? AND status IN ?", eventID, userID, domain.ActiveRegistrationStatuses).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check registration: %w", err)
+	}
+	return count > 0, nil
+}
+
+// getAll retrieves all events listed to the viewer: public events, the viewer's own and those of their teams and organizations
+func (r *EventRepository) GetAll(viewerID string) ([]domain.Event, error) {
 	var events []domain.Event
-	result := r.db.Find(&events)
+	result := r.listedTo(r.db.Preload("Category").Preload("Tags"), viewerID).Find(&events)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get events: %w", result.Error)
 	}
@@ -90,9 +679,105 @@ func (r *EventRepository) GetEvents(req *domain.EventQueryRequest) ([]domain.Eve
 	var total int64
 
 	// Build base query
-	query := r.db.Model(&domain.Event{})
+	query := r.applyFilters(r.db.Model(&domain.Event{}), req)
+
+	// --- NEW SORTING ---
+	sortColumn := req.SortColumn()
+
+	sortDirection := "DESC"
+	if req.SortOrder == "asc" || req.SortOrder == "ASC" {
+		sortDirection = "ASC"
+	}
+
+	query = query.Order(fmt.Sprintf("%s %s", sortColumn, sortDirection))
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	// Apply pagination if requested
+	if req.Page > 0 || req.PageSize > 0 {
+		page := req.Page
+		if page < 1 {
+			page = 1
+		}
+		pageSize := req.PageSize
+		if pageSize < 1 {
+			pageSize = 10
+		}
+		if pageSize > 20 {
+			pageSize = 20
+		}
+		offset := (page - 1) * pageSize
+		query = query.Offset(offset).Limit(pageSize)
+	}
+
+	// --- EXECUTE QUERY ---
+	if err := query.Preload("Category").Preload("Tags").Find(&events).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch events: %w", err)
+	}
+	return events, total, nil
+}
+
+// GetFacets counts events per category, tag, status and start month for the given filter set.
+// Pagination and sorting parameters are ignored.
+func (r *EventRepository) GetFacets(req *domain.EventQueryRequest) (*domain.EventFacets, error) {
+	facets := &domain.EventFacets{}
+
+	// IDs of all events matching the current filters, reused by every facet query
+	filtered := r.applyFilters(r.db.Model(&domain.Event{}), req).Select("events.id")
+
+	if err := r.applyFilters(r.db.Model(&domain.Event{}), req).Count(&facets.Total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	if err := r.db.Table("events").
+		Select("categories.slug AS value, categories.name AS label, COUNT(*) AS count").
+		Joins("JOIN categories ON categories.id = events.category_id").
+		Where("events.id IN (?)", filtered).
+		Group("categories.slug, categories.name").
+		Order("count DESC, value ASC").
+		Scan(&facets.Categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to count category facets: %w", err)
+	}
+
+	if err := r.db.Table("event_tags").
+		Select("tags.name AS value, COUNT(*) AS count").
+		Joins("JOIN tags ON tags.id = event_tags.tag_id").
+		Where("event_tags.event_id IN (?)", filtered).
+		Group("tags.name").
+		Order("count DESC, value ASC").
+		Scan(&facets.Tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to count tag facets: %w", err)
+	}
+
+	if err := r.db.Table("events").
+		Select("status AS value, COUNT(*) AS count").
+		Where("id IN (?)", filtered).
+		Group("status").
+		Order("value ASC").
+		Scan(&facets.Statuses).Error; err != nil {
+		return nil, fmt.Errorf("failed to count status facets: %w", err)
+	}
+
+	month := r.monthExpr("start_datetime")
+	if err := r.db.Table("events").
+		Select(month+" AS value, COUNT(*) AS count").
+		Where("id IN (?)", filtered).
+		Group(month).
+		Order("value ASC").
+		Scan(&facets.Months).Error; err != nil {
+		return nil, fmt.Errorf("failed to count month facets: %w", err)
+	}
+
+	return facets, nil
+}
+
+// applyFilters adds the WHERE conditions of an event query (everything except sorting and pagination)
+func (r *EventRepository) applyFilters(query *gorm.DB, req *domain.EventQueryRequest) *gorm.DB {
+	query = r.listedTo(query, req.ViewerID)
 
-	// Apply filters
 	if req.StartDateFrom != nil {
 		query = query.Where("start_datetime >= ?", *req.StartDateFrom)
 	}
@@ -129,44 +814,96 @@ func (r *EventRepository) GetEvents(req *domain.EventQueryRequest) ([]domain.Eve
 		query = query.Where("end_datetime <= ?", time.Now())
 	}
 
-	// --- NEW SORTING ---
-	sortColumn := "created_at"
-	if req.SortBy != "" {
-		sortColumn = req.SortBy
+	if req.RegistrationState != "" {
+		query = r.applyRegistrationState(query, req.RegistrationState)
 	}
 
-	sortDirection := "DESC"
-	if req.SortOrder == "asc" || req.SortOrder == "ASC" {
-		sortDirection = "ASC"
+	// category filter matches the category itself and all of its sub-categories
+	if req.Category != "" {
+		query = query.Where("category_id IN (?)", categoryTreeQuery(r.db, req.Category))
 	}
 
-	query = query.Order(fmt.Sprintf("%s %s", sortColumn, sortDirection))
+	// tag filter: "any" matches events with at least one of the tags, "all" requires every tag
+	if tags := req.TagList(); len(tags) > 0 {
+		tagged := r.db.Table("event_tags").
+			Select("event_tags.event_id").
+			Joins("JOIN tags ON tags.id = event_tags.tag_id").
+			Where("tags.name IN ?", tags)
+		if req.TagMode == domain.TagModeAll {
+			tagged = tagged.Group("event_tags.event_id").Having("COUNT(DISTINCT tags.id) = ?", len(tags))
+		}
+		query = query.Where("events.id IN (?)", tagged)
+	}
 
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count events: %w", err)
+	return query
+}
+
+// listedTo restricts a query to the events listed to a viewer: unlisted and private events
+// only appear in the listings of their organizer, team and organization
+func (r *EventRepository) listedTo(query *gorm.DB, viewerID string) *gorm.DB {
+	if viewerID == "" {
+		return query.Where("events.visibility = ?", domain.EventVisibilityPublic)
 	}
+	teams := r.db.Model(&domain.EventMember{}).Select("event_id").Where("user_id = ?", viewerID)
+	orgs := r.db.Model(&domain.OrganizationMember{}).Select("organization_id").Where("user_id = ?", viewerID)
+	return query.Where("events.visibility = ? OR events.organizer_id = ? OR events.id IN (?) OR events.organization_id IN (?)",
+		domain.EventVisibilityPublic, viewerID, teams, orgs)
+}
 
-	// Apply pagination if requested
-	if req.Page > 0 || req.PageSize > 0 {
-		page := req.Page
-		if page < 1 {
-			page = 1
-		}
-		pageSize := req.PageSize
-		if pageSize < 1 {
-			pageSize = 10
-		}
-		if pageSize > 20 {
-			pageSize = 20
-		}
-		offset := (page - 1) * pageSize
-		query = query.Offset(offset).Limit(pageSize)
+// applyRegistrationState filters events by their computed registration state,
+// mirroring domain.Event.ComputeRegistrationState in SQL
+func (r *EventRepository) applyRegistrationState(query *gorm.DB, state string) *gorm.DB {
+	now := time.Now()
+	deadline := "COALESCE(registration_closes_at, start_datetime)"
+	seatsTaken := r.db.Model(&domain.Registration{}).
+		Select("COUNT(*)").
+		Where("registrations.event_id = events.id AND registrations.status IN ?", domain.SeatHoldingStatuses)
+	// published, within the window
+	inWindow := r.db.Where("status = ?", "published").
+		Where(deadline+" > ?", now).
+		Where("registration_opens_at IS NULL OR registration_opens_at <= ?", now)
+
+	switch state {
+	case domain.RegistrationStateNotOpen:
+		return query.Where("status = ?", "published").
+			Where(deadline+" > ?", now).
+			Where("registration_opens_at > ?", now)
+	case domain.RegistrationStateClosed:
+		return query.Where(r.db.Where("status <> ?", "published").Or(deadline+" <= ?", now))
+	case domain.RegistrationStateFull:
+		return query.Where(inWindow).Where("(?) >= capacity", seatsTaken)
+	default:
+		return query.Where(inWindow).Where("(?) < capacity", seatsTaken)
 	}
+}
 
-	// --- EXECUTE QUERY ---
-	if err := query.Find(&events).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to fetch events: %w", err)
+// CountSeatsTaken returns the number of seat-holding registrations of each of the given events
+func (r *EventRepository) CountSeatsTaken(eventIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return counts, nil
 	}
-	return events, total, nil
+	var rows []struct {
+		EventID string
+		Count   int64
+	}
+	if err := r.db.Model(&domain.Registration{}).
+		Select("event_id, COUNT(*) AS count").
+		Where("event_id IN ? AND status IN ?", eventIDs, domain.SeatHoldingStatuses).
+		Group("event_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count seats taken: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.EventID] = row.Count
+	}
+	return counts, nil
+}
+
+// monthExpr returns a SQL expression formatting a timestamp column as YYYY-MM
+func (r *EventRepository) monthExpr(column string) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return fmt.Sprintf("strftime('%%Y-%%m', %s)", column)
+	}
+	return fmt.Sprintf("to_char(%s, 'YYYY-MM')", column)
 }
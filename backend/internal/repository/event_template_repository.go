@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type EventTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewEventTemplateRepository(db *gorm.DB) *EventTemplateRepository {
+	return &EventTemplateRepository{db: db}
+}
+
+// Create inserts a new event template
+func (r *EventTemplateRepository) Create(template *domain.EventTemplate) error {
+	if err := r.db.Create(template).Error; err != nil {
+		return fmt.Errorf("failed to create event template: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an event template
+func (r *EventTemplateRepository) Update(template *domain.EventTemplate) error {
+	if err := r.db.Save(template).Error; err != nil {
+		return fmt.Errorf("failed to update event template: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an event template of the given organizer
+func (r *EventTemplateRepository) Delete(organizerID, templateID string) error {
+	result := r.db.Delete(&domain.EventTemplate{}, "id = ? AND organizer_id = ?", templateID, organizerID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete event template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("event template not found")
+	}
+	return nil
+}
+
+// GetByID retrieves an event template of the given organizer; other organizers' templates are not found
+func (r *EventTemplateRepository) GetByID(organizerID, templateID string) (*domain.EventTemplate, error) {
+	var template domain.EventTemplate
+	if err := r.db.Where("id = ? AND organizer_id = ?", templateID, organizerID).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("event template not found")
+		}
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return &template, nil
+}
+
+// GetByOrganizer returns the event templates of an organizer, by name
+func (r *EventTemplateRepository) GetByOrganizer(organizerID string) ([]domain.EventTemplate, error) {
+	templates := []domain.EventTemplate{}
+	if err := r.db.Where("organizer_id = ?", organizerID).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event templates: %w", err)
+	}
+	return templates, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// GetByID retrieves an order by ID
+func (r *OrderRepository) GetByID(id string) (*domain.Order, error) {
+	var order domain.Order
+	if err := r.db.Where("id = ?", id).First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return &order, nil
+}
+
+// GetByIntentID retrieves the order paid by a provider payment intent
+func (r *OrderRepository) GetByIntentID(intentID string) (*domain.Order, error) {
+	var order domain.Order
+	if err := r.db.Where("provider_intent_id = ?", intentID).First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return &order, nil
+}
+
+// GetUserOrders retrieves all orders of a user, newest first
+func (r *OrderRepository) GetUserOrders(userID string) ([]domain.Order, error) {
+	var orders []domain.Order
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user orders: %w", err)
+	}
+	return orders, nil
+}
+
+// SetIntent stores the provider payment intent created for an order
+func (r *OrderRepository) SetIntent(orderID, intentID string) error {
+	result := r.db.Model(&domain.Order{}).Where("id = ?", orderID).Update("provider_intent_id", intentID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update order: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("order not found")
+	}
+	return nil
+}
+
+// MarkPaid settles a pending order and confirms the registrations holding its seats.
+// The order row is locked, so a concurrent expiry or a duplicate webhook cannot interleave.
+// A pending order whose hold already lapsed is expired instead. When the seats were already
+// released (expired, cancelled or failed order), the full amount is recorded as refunded once,
+// so duplicate deliveries don't refund again. The caller inspects the returned status and
+// sends the outstanding refund to the provider.
+func (r *OrderRepository) MarkPaid(orderID string, paidAt time.Time) (*domain.Order, error) {
+	var order domain.Order
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOrder(tx, orderID, &order); err != nil {
+			return err
+		}
+		if order.Status == domain.OrderStatusPaid || order.Status == domain.OrderStatusRefunded {
+			return nil
+		}
+		if order.Status != domain.OrderStatusPending {
+			return recordLateRefund(tx, &order)
+		}
+		if order.IsExpired(paidAt) {
+			if err := closeOrder(tx, &order, domain.OrderStatusExpired, domain.RegistrationStatusExpired); err != nil {
+				return err
+			}
+			return recordLateRefund(tx, &order)
+		}
+
+		if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+			"status":  domain.OrderStatusPaid,
+			"paid_at": paidAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if err := tx.Model(&domain.Registration{}).
+			Where("order_id = ? AND status = ?", order.ID, domain.RegistrationStatusPendingPayment).
+			Update("status", domain.RegistrationStatusConfirmed).Error; err != nil {
+			return fmt.Errorf("failed to confirm registrations: %w", err)
+		}
+		order.Status = domain.OrderStatusPaid
+		order.PaidAt = &paidAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Close moves a pending order to a final status (failed, cancelled, expired) and releases its seats.
+// Orders that are no longer pending are left untouched.
+func (r *OrderRepository) Close(orderID, status, registrationStatus string) (*domain.Order, error) {
+	var order domain.Order
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOrder(tx, orderID, &order); err != nil {
+			return err
+		}
+		if order.Status != domain.OrderStatusPending {
+			return nil
+		}
+		return closeOrder(tx, &order, status, registrationStatus)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ClaimRefund marks the outstanding refund of an order as sent and returns the order and the
+// amount to send. The order row is locked, so concurrent claims never send the same refund twice.
+// If sending fails, the claim is undone with ReleaseRefund so that it is retried.
+func (r *OrderRepository) ClaimRefund(orderID string) (*domain.Order, int64, error) {
+	var order domain.Order
+	var amount int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOrder(tx, orderID, &order); err != nil {
+			return err
+		}
+		amount = order.OutstandingRefundCents()
+		if amount <= 0 {
+			amount = 0
+			return nil
+		}
+		if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).
+			Update("refund_issued_cents", order.RefundedCents).Error; err != nil {
+			return fmt.Errorf("failed to claim refund: %w", err)
+		}
+		order.RefundIssuedCents = order.RefundedCents
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &order, amount, nil
+}
+
+// ReleaseRefund gives back a claimed refund the provider did not accept, so it stays outstanding
+func (r *OrderRepository) ReleaseRefund(orderID string, amountCents int64) error {
+	if err := r.db.Model(&domain.Order{}).Where("id = ?", orderID).
+		Update("refund_issued_cents", gorm.Expr("refund_issued_cents - ?", amountCents)).Error; err != nil {
+		return fmt.Errorf("failed to release refund: %w", err)
+	}
+	return nil
+}
+
+// GetWithOutstandingRefunds returns the IDs of orders with refunds not yet sent to the provider, oldest first
+func (r *OrderRepository) GetWithOutstandingRefunds(limit int) ([]string, error) {
+	var ids []string
+	if err := r.db.Model(&domain.Order{}).
+		Where("refunded_cents > refund_issued_cents").
+		Order("updated_at").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find outstanding refunds: %w", err)
+	}
+	return ids, nil
+}
+
+// ExpireDue expires all pending orders whose hold lapsed before now and releases their seats.
+// It returns the number of expired orders.
+func (r *OrderRepository) ExpireDue(now time.Time) (int64, error) {
+	var expired int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var orders []domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", domain.OrderStatusPending, now).
+			Find(&orders).Error; err != nil {
+			return fmt.Errorf("failed to find expired orders: %w", err)
+		}
+
+		for i := range orders {
+			if err := closeOrder(tx, &orders[i], domain.OrderStatusExpired, domain.RegistrationStatusExpired); err != nil {
+				return err
+			}
+		}
+		expired = int64(len(orders))
+		return nil
+	})
+	return expired, err
+}
+
+// lockOrder loads an order with a row lock for the rest of the transaction
+func lockOrder(tx *gorm.DB, orderID string, order *domain.Order) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", orderID).First(order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("order not found")
+		}
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+	return nil
+}
+
+// recordLateRefund records the full amount of an order whose seats were released before its
+// payment arrived as refunded, unless that was done already
+func recordLateRefund(tx *gorm.DB, order *domain.Order) error {
+	if order.ProviderIntentID == nil || order.RefundedCents >= order.AmountCents {
+		return nil
+	}
+	if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).
+		Update("refunded_cents", order.AmountCents).Error; err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+	order.RefundedCents = order.AmountCents
+	return nil
+}
+
+// closeOrder sets the final status of a pending order and of the registrations still waiting for it
+func closeOrder(tx *gorm.DB, order *domain.Order, status, registrationStatus string) error {
+	if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	if err := tx.Model(&domain.Registration{}).
+		Where("order_id = ? AND status = ?", order.ID, domain.RegistrationStatusPendingPayment).
+		Update("status", registrationStatus).Error; err != nil {
+		return fmt.Errorf("failed to release registrations: %w", err)
+	}
+	order.Status = status
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// Create inserts an organization with its creator as the first admin, in one transaction
+func (r *OrganizationRepository) Create(org *domain.Organization) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+		admin := &domain.OrganizationMember{
+			ID:             uuid.NewString(),
+			OrganizationID: org.ID,
+			UserID:         *org.CreatedBy,
+			Role:           domain.OrganizationRoleAdmin,
+		}
+		if err := tx.Create(admin).Error; err != nil {
+			return fmt.Errorf("failed to add organization admin: %w", err)
+		}
+		return nil
+	})
+}
+
+// Update saves the name, slug and description of an organization
+func (r *OrganizationRepository) Update(org *domain.Organization) error {
+	if err := r.db.Model(org).Select("name", "slug", "description", "updated_at").Updates(org).Error; err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *OrganizationRepository) GetByID(id string) (*domain.Organization, error) {
+	var org domain.Organization
+	if err := r.db.Where("id = ?", id).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("organization not found")
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &org, nil
+}
+
+// GetBySlug retrieves an organization by its slug
+func (r *OrganizationRepository) GetBySlug(slug string) (*domain.Organization, error) {
+	var org domain.Organization
+	if err := r.db.Where("slug = ?", slug).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("organization not found")
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &org, nil
+}
+
+// SlugTaken checks whether another organization already uses the slug
+func (r *OrganizationRepository) SlugTaken(slug, exceptID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.Organization{}).Where("slug = ? AND id <> ?", slug, exceptID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check organization slug: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetUserOrganizations returns the organizations a user belongs to, by name
+func (r *OrganizationRepository) GetUserOrganizations(userID string) ([]domain.Organization, error) {
+	orgs := []domain.Organization{}
+	if err := r.db.
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.name ASC").
+		Find(&orgs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// GetPublicEvents returns the upcoming published public events of an organization, soonest first
+func (r *OrganizationRepository) GetPublicEvents(orgID string) ([]domain.Event, error) {
+	events := []domain.Event{}
+	if err := r.db.Preload("Category").Preload("Tags").
+		Where("organization_id = ? AND status = ? AND visibility = ? AND end_datetime > ?",
+			orgID, "published", domain.EventVisibilityPublic, time.Now()).
+		Order("start_datetime ASC").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to get organization events: %w", err)
+	}
+	return events, nil
+}
+
+// GetMember returns a user's membership of an organization, or nil if they don't belong to it
+func (r *OrganizationRepository) GetMember(orgID, userID string) (*domain.OrganizationMember, error) {
+	var member domain.OrganizationMember
+	if err := r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return &member, nil
+}
+
+// GetMembers returns the members of an organization with their users, oldest first
+func (r *OrganizationRepository) GetMembers(orgID string) ([]domain.OrganizationMember, error) {
+	members := []domain.OrganizationMember{}
+	if err := r.db.Preload("User").Where("organization_id = ?", orgID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to get organization members: %w", err)
+	}
+	return members, nil
+}
+
+// UpsertMember adds a user to an organization, or changes their role if they already belong to it
+func (r *OrganizationRepository) UpsertMember(member *domain.OrganizationMember) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(member).Error; err != nil {
+		return fmt.Errorf("failed to save organization member: %w", err)
+	}
+	return nil
+}
+
+// CountAdmins returns the number of admins of an organization
+func (r *OrganizationRepository) CountAdmins(orgID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&domain.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", orgID, domain.OrganizationRoleAdmin).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count organization admins: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteMember removes a user from an organization
+func (r *OrganizationRepository) DeleteMember(orgID, userID string) error {
+	result := r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).Delete(&domain.OrganizationMember{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete organization member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoCodeRepository(db *gorm.DB) *PromoCodeRepository {
+	return &PromoCodeRepository{db: db}
+}
+
+// Create inserts a promo code together with its ticket type restriction
+func (r *PromoCodeRepository) Create(promo *domain.PromoCode) error {
+	if err := r.db.Create(promo).Error; err != nil {
+		return fmt.Errorf("failed to create promo code: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to a promo code and replaces its ticket type restriction
+func (r *PromoCodeRepository) Update(promo *domain.PromoCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(promo).Error; err != nil {
+			return fmt.Errorf("failed to update promo code: %w", err)
+		}
+		if err := tx.Model(promo).Association("TicketTypes").Replace(promo.TicketTypes); err != nil {
+			return fmt.Errorf("failed to update promo code ticket types: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete soft-deletes a promo code of the given event
+func (r *PromoCodeRepository) Delete(eventID, promoCodeID string) error {
+	result := r.db.Delete(&domain.PromoCode{}, "id = ? AND event_id = ?", promoCodeID, eventID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete promo code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("promo code not found")
+	}
+	return nil
+}
+
+// GetByID retrieves a promo code of the given event, including its active redemption count
+func (r *PromoCodeRepository) GetByID(eventID, promoCodeID string) (*domain.PromoCode, error) {
+	return r.getOne(r.db.Where("id = ? AND event_id = ?", promoCodeID, eventID))
+}
+
+// GetByCode retrieves a promo code of the given event by its (case-insensitive) code
+func (r *PromoCodeRepository) GetByCode(eventID, code string) (*domain.PromoCode, error) {
+	return r.getOne(r.db.Where("event_id = ? AND code = ?", eventID, domain.NormalizePromoCode(code)))
+}
+
+// GetByEvent retrieves all promo codes of an event with their active redemption counts
+func (r *PromoCodeRepository) GetByEvent(eventID string) ([]domain.PromoCode, error) {
+	var promos []domain.PromoCode
+	if err := r.db.Preload("TicketTypes").Where("event_id = ?", eventID).Order("code ASC").Find(&promos).Error; err != nil {
+		return nil, fmt.Errorf("failed to get promo codes: %w", err)
+	}
+	for i := range promos {
+		count, err := countRedemptions(r.db, promos[i].ID, "")
+		if err != nil {
+			return nil, err
+		}
+		promos[i].Redemptions = count
+	}
+	return promos, nil
+}
+
+// GetStats summarizes the redemptions of a promo code
+func (r *PromoCodeRepository) GetStats(promo *domain.PromoCode) (*domain.PromoCodeStats, error) {
+	type statusRow struct {
+		Status   string
+		Count    int64
+		Discount int64
+	}
+	var rows []statusRow
+	if err := r.db.Table("promo_redemptions").
+		Select("registrations.status AS status, COUNT(*) AS count, COALESCE(SUM(promo_redemptions.discount_cents), 0) AS discount").
+		Joins("JOIN registrations ON registrations.id = promo_redemptions.registration_id").
+		Where("promo_redemptions.promo_code_id = ?", promo.ID).
+		Group("registrations.status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get promo code stats: %w", err)
+	}
+
+	stats := &domain.PromoCodeStats{PromoCodeID: promo.ID, Code: promo.Code}
+	for _, row := range rows {
+		switch row.Status {
+		case domain.RegistrationStatusPendingPayment:
+			stats.PendingRedemptions += row.Count
+		case domain.RegistrationStatusConfirmed, domain.RegistrationStatusCheckedIn:
+			stats.ConfirmedRedemptions += row.Count
+		default:
+			stats.ReleasedRedemptions += row.Count
+			continue
+		}
+		stats.Redemptions += row.Count
+		stats.TotalDiscountCents += row.Discount
+	}
+
+	if err := activeRedemptions(r.db, promo.ID).
+		Distinct("promo_redemptions.user_id").
+		Count(&stats.UniqueUsers).Error; err != nil {
+		return nil, fmt.Errorf("failed to count promo code users: %w", err)
+	}
+
+	if promo.MaxRedemptions != nil {
+		remaining := max(int64(*promo.MaxRedemptions)-stats.Redemptions, 0)
+		stats.Remaining = &remaining
+	}
+	return stats, nil
+}
+
+// GetRedemptionByRegistration returns the promo code redemption of a registration, or nil if it used none
+func (r *PromoCodeRepository) GetRedemptionByRegistration(registrationID string) (*domain.PromoRedemption, error) {
+	var redemption domain.PromoRedemption
+	if err := r.db.Where("registration_id = ?", registrationID).First(&redemption).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get promo code redemption: %w", err)
+	}
+	return &redemption, nil
+}
+
+// redeemPromoCode records a redemption inside a registration transaction.
+// The promo code row is locked, so concurrent registrations cannot exceed its limits.
+func redeemPromoCode(tx *gorm.DB, redemption *domain.PromoRedemption) error {
+	var promo domain.PromoCode
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", redemption.PromoCodeID).
+		First(&promo).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("promo code not found")
+		}
+		return fmt.Errorf("failed to lock promo code: %w", err)
+	}
+
+	if promo.MaxRedemptions != nil {
+		total, err := countRedemptions(tx, promo.ID, "")
+		if err != nil {
+			return err
+		}
+		if total >= int64(*promo.MaxRedemptions) {
+			return fmt.Errorf("promo code has reached its redemption limit")
+		}
+	}
+
+	used, err := countRedemptions(tx, promo.ID, redemption.UserID)
+	if err != nil {
+		return err
+	}
+	if used >= int64(promo.PerUserLimit) {
+		return fmt.Errorf("promo code can only be used %d time(s) per user", promo.PerUserLimit)
+	}
+
+	if err := tx.Create(redemption).Error; err != nil {
+		return fmt.Errorf("failed to redeem promo code: %w", err)
+	}
+	return nil
+}
+
+func (r *PromoCodeRepository) getOne(query *gorm.DB) (*domain.PromoCode, error) {
+	var promo domain.PromoCode
+	if err := query.Preload("TicketTypes").First(&promo).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("promo code not found")
+		}
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	count, err := countRedemptions(r.db, promo.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	promo.Redemptions = count
+	return &promo, nil
+}
+
+// activeRedemptions selects redemptions whose registration still holds (or awaits approval for) a seat
+func activeRedemptions(db *gorm.DB, promoCodeID string) *gorm.DB {
+	return db.Table("promo_redemptions").
+		Joins("JOIN registrations ON registrations.id = promo_redemptions.registration_id").
+		Where("promo_redemptions.promo_code_id = ? AND registrations.status IN ?", promoCodeID, domain.ActiveRegistrationStatuses)
+}
+
+// countRedemptions counts active redemptions of a promo code, optionally for a single user
+func countRedemptions(db *gorm.DB, promoCodeID, userID string) (int64, error) {
+	query := activeRedemptions(db, promoCodeID)
+	if userID != "" {
+		query = query.Where("promo_redemptions.user_id = ?", userID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count promo code redemptions: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationFormRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistrationFormRepository(db *gorm.DB) *RegistrationFormRepository {
+	return &RegistrationFormRepository{db: db}
+}
+
+// GetByEvent retrieves the registration form of an event, or nil if the event has none
+func (r *RegistrationFormRepository) GetByEvent(eventID string) (*domain.RegistrationForm, error) {
+	var form domain.RegistrationForm
+	result := r.db.Where("event_id = ?", eventID).First(&form)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get registration form: %w", result.Error)
+	}
+	return &form, nil
+}
+
+// Upsert creates or replaces the registration form of an event
+func (r *RegistrationFormRepository) Upsert(form *domain.RegistrationForm) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"questions", "answers_editable_until", "updated_at"}),
+	}).Create(form).Error; err != nil {
+		return fmt.Errorf("failed to save registration form: %w", err)
+	}
+	return nil
+}
@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RegistrationRepository struct {
@@ -17,6 +21,9 @@ func NewRegistrationRepository(db *gorm.DB) *RegistrationRepository {
 
 // Create registers a user for an event
 func (r *RegistrationRepository) Create(registration *domain.Registration) error {
+	if registration.TicketToken == "" {
+		registration.TicketToken = domain.NewTicketToken()
+	}
 	result := r.db.Create(registration)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create registration: %w", result.Error)
@@ -24,10 +31,13 @@ func (r *RegistrationRepository) Create(registration *domain.Registration) error
 	return nil
 }
 
-// GetByUserAndEvent checks if a registration exists
+// GetByUserAndEvent returns the user's most recent own registration (not a guest seat) for an event.
+// Earlier registrations may exist when they were cancelled or their payment hold expired.
 func (r *RegistrationRepository) GetByUserAndEvent(userID, eventID string) (*domain.Registration, error) {
 	var registration domain.Registration
-	result := r.db.Where("user_id = ? AND event_id = ?", userID, eventID).First(&registration)
+	result := r.db.Where("user_id = ? AND event_id = ? AND is_guest = ?", userID, eventID, false).
+		Order("created_at DESC").
+		First(&registration)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil // Not found is not an error here, just nil
@@ -41,36 +51,132 @@ func (r *RegistrationRepository) GetByUserAndEvent(userID, eventID string) (*dom
 func (r *RegistrationRepository) GetUserRegistrations(userID string) ([]domain.Registration, error) {
 	var registrations []domain.Registration
 	// Preload Event data
-	result := r.db.Preload("Event").Where("user_id = ?", userID).Find(&registrations)
+	result := r.db.Preload("Event").Preload("TicketType").Where("user_id = ?", userID).Find(&registrations)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get user registrations: %w", result.Error)
 	}
 	return registrations, nil
 }
 
-// CancelRegistration cancels a registration (soft delete or status update)
-// Here we will use soft delete as per GORM default, or update status if we want to keep history.
-// Let's just delete the record for simplicity, or we can update status to 'cancelled'.
-// Based on migration, we have a status field.
-func (r *RegistrationRepository) Cancel(userID, eventID string) error {
-	result := r.db.Model(&domain.Registration{}).
-		Where("user_id = ? AND event_id = ?", userID, eventID).
-		Update("status", "cancelled")
+// GetScheduledEvents returns the published events the user holds their own seat at that end after
+// the given time, by start time. Guest seats and postponed events (whose dates are open) don't count.
+func (r *RegistrationRepository) GetScheduledEvents(userID string, endingAfter time.Time) ([]domain.Event, error) {
+	var events []domain.Event
+	result := r.db.Model(&domain.Event{}).
+		Where("events.status = ? AND events.end_datetime > ?", domain.EventStatusPublished, endingAfter).
+		Where("events.id IN (?)", r.db.Model(&domain.Registration{}).Select("event_id").
+			Where("user_id = ? AND is_guest = ? AND status IN ?", userID, false, domain.ScheduleConflictStatuses)).
+		Order("events.start_datetime").
+		Find(&events)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user schedule: %w", result.Error)
+	}
+	return events, nil
+}
+
+// IsStrictSchedule reports whether the user asked to block registrations that overlap their schedule
+func (r *RegistrationRepository) IsStrictSchedule(userID string) (bool, error) {
+	var user domain.User
+	if err := r.db.Select("strict_schedule").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.StrictSchedule, nil
+}
+
+// Cancel cancels active seats of one booking (or withdraws pending applications) and records
+// the refund granted for each seat: refundPercent of the seat price if the booking's order is paid.
+// The order row is locked first, so concurrent cancellations of the same seats are serialized and
+// only the one that actually cancels them records their refund. An unpaid order is cancelled once
+// none of its seats is left, so a late payment for it gets refunded instead of confirming the seats;
+// for a paid order the refunds are added to its refunded total, to be sent to the payment provider.
+func (r *RegistrationRepository) Cancel(registrations []*domain.Registration, refundPercent int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		orderID := registrations[0].OrderID
+		var refundPerSeat int64
+		if orderID != nil {
+			var order domain.Order
+			if err := lockOrder(tx, *orderID, &order); err != nil {
+				return err
+			}
+			if order.Status == domain.OrderStatusPaid {
+				refundPerSeat = order.SeatPriceCents() * int64(refundPercent) / 100
+			}
+		}
+
+		for _, registration := range registrations {
+			result := tx.Model(&domain.Registration{}).
+				Where("id = ? AND status IN ?", registration.ID, domain.ActiveRegistrationStatuses).
+				Updates(map[string]interface{}{
+					"status":       domain.RegistrationStatusCancelled,
+					"refund_cents": refundPerSeat,
+					"cancelled_at": now,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("failed to cancel registration: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("registration not found")
+			}
+		}
+
+		if orderID != nil {
+			var remaining int64
+			if err := tx.Model(&domain.Registration{}).
+				Where("order_id = ? AND status IN ?", *orderID, domain.SeatHoldingStatuses).
+				Count(&remaining).Error; err != nil {
+				return fmt.Errorf("failed to count remaining seats: %w", err)
+			}
+			if remaining == 0 {
+				if err := tx.Model(&domain.Order{}).
+					Where("id = ? AND status = ?", *orderID, domain.OrderStatusPending).
+					Update("status", domain.OrderStatusCancelled).Error; err != nil {
+					return fmt.Errorf("failed to cancel order: %w", err)
+				}
+			}
+
+			if refund := refundPerSeat * int64(len(registrations)); refund > 0 {
+				if err := tx.Model(&domain.Order{}).
+					Where("id = ?", *orderID).
+					Updates(map[string]interface{}{
+						"refunded_cents": gorm.Expr("refunded_cents + ?", refund),
+						"status":         gorm.Expr("CASE WHEN refunded_cents + ? >= amount_cents THEN ? ELSE status END", refund, domain.OrderStatusRefunded),
+					}).Error; err != nil {
+					return fmt.Errorf("failed to record refund: %w", err)
+				}
+			}
+		}
 
+		for _, registration := range registrations {
+			registration.Status = domain.RegistrationStatusCancelled
+			registration.RefundCents = refundPerSeat
+			registration.CancelledAt = &now
+		}
+		return nil
+	})
+}
+
+// UpdateAnswers replaces the form answers of an active registration
+func (r *RegistrationRepository) UpdateAnswers(registration *domain.Registration, answers domain.FormAnswers) error {
+	result := r.db.Model(&domain.Registration{}).
+		Where("id = ? AND status IN ?", registration.ID, domain.ActiveRegistrationStatuses).
+		Select("answers").
+		Updates(&domain.Registration{Answers: answers})
 	if result.Error != nil {
-		return fmt.Errorf("failed to cancel registration: %w", result.Error)
+		return fmt.Errorf("failed to update answers: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
 		return fmt.Errorf("registration not found")
 	}
+	registration.Answers = answers
 	return nil
 }
 
-// CountByEvent counts confirmed registrations for an event
+// CountByEvent counts seat-holding (pending payment, confirmed or checked-in) registrations for an event
 func (r *RegistrationRepository) CountByEvent(eventID string) (int64, error) {
 	var count int64
 	result := r.db.Model(&domain.Registration{}).
-		Where("event_id = ? AND status = ?", eventID, "confirmed").
+		Where("event_id = ? AND status IN ?", eventID, domain.SeatHoldingStatuses).
 		Count(&count)
 	if result.Error != nil {
 		return 0, fmt.Errorf("failed to count registrations: %w", result.Error)
@@ -78,42 +184,275 @@ func (r *RegistrationRepository) CountByEvent(eventID string) (int64, error) {
 	return count, nil
 }
 
-// CreateWithCapacityCheck performs atomic registration with capacity check using a DB transaction
-func (r *RegistrationRepository) CreateWithCapacityCheck(registration *domain.Registration, capacity int) error {
+// CreateWithCapacityCheck performs atomic registration with capacity check using a DB transaction.
+// The registrations are the seats of one booking, the booking user's own seat first; they are
+// created all-or-nothing. The event row is locked for the whole transaction, so the overall
+// capacity and the stock of the selected ticket type (if any) are checked and consumed under the same lock.
+// For paid tickets the order is created in the same transaction, before the registrations that reference it.
+// A promo code redemption (if any) is checked against the code's limits and recorded under the same lock.
+// Applications awaiting approval ("pending") hold no seat, so capacity and stock are checked on approval instead.
+func (r *RegistrationRepository) CreateWithCapacityCheck(registrations []*domain.Registration, order *domain.Order, redemption *domain.PromoRedemption) error {
+	booking := registrations[0]
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// 1. Lock the Event row to serialize access (prevent concurrent inserts for this event)
-		// We query the EVENTS table to lock the specific event row.
-		// "FOR UPDATE" ensures other transactions waiting for this event must wait.
-		if err := tx.Exec("SELECT id FROM events WHERE id = ? FOR UPDATE", registration.EventID).Error; err != nil {
-			return fmt.Errorf("failed to lock event for registration: %w", err)
+		// "FOR UPDATE" ensures other transactions registering for this event must wait.
+		event, err := lockEvent(tx, booking.EventID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Check the ticket type's sales window
+		var ticketType *domain.TicketType
+		if booking.TicketTypeID != nil {
+			ticketType, err = getEventTicketType(tx, booking.EventID, *booking.TicketTypeID)
+			if err != nil {
+				return err
+			}
+			if err := ticketType.CheckOnSale(time.Now()); err != nil {
+				return err
+			}
+		}
+
+		// 3. Check the overall capacity and the ticket type's stock
+		if booking.Status != domain.RegistrationStatusPending {
+			if err := checkSeatsAvailable(tx, event, ticketType, len(registrations)); err != nil {
+				return err
+			}
+		}
+
+		// 4. Create the order holding the seats until they are paid
+		if order != nil {
+			if err := tx.Create(order).Error; err != nil {
+				return fmt.Errorf("failed to create order: %w", err)
+			}
+			for _, registration := range registrations {
+				registration.OrderID = &order.ID
+			}
+		}
+
+		// 5. Create the registrations
+		for _, registration := range registrations {
+			if err := tx.Create(registration).Error; err != nil {
+				return fmt.Errorf("failed to create registration: %w", err)
+			}
+		}
+
+		// 6. Redeem the promo code for the booking
+		if redemption != nil {
+			redemption.RegistrationID = booking.ID
+			if err := redeemPromoCode(tx, redemption); err != nil {
+				return err
+			}
 		}
 
-		// 2. Count current confirmed registrations inside the transaction
-		var current int64
-		if err := tx.Model(&domain.Registration{}).
-			Where("event_id = ? AND status = ?", registration.EventID, "confirmed").
-			Count(&current).Error; err != nil {
-			return fmt.Errorf("failed to count registrations: %w", err)
+		return nil
+	})
+}
+
+// GetByID retrieves a registration of the given event
+func (r *RegistrationRepository) GetByID(eventID, registrationID string) (*domain.Registration, error) {
+	var registration domain.Registration
+	if err := r.db.Where("id = ? AND event_id = ?", registrationID, eventID).First(&registration).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("registration not found")
 		}
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+	return &registration, nil
+}
 
-		// 3. Check Capacity
-		if int(current) >= capacity {
-			return fmt.Errorf("event is full (capacity reached)")
+// GetUserRegistration retrieves a registration (seat) booked by the given user
+func (r *RegistrationRepository) GetUserRegistration(userID, registrationID string) (*domain.Registration, error) {
+	var registration domain.Registration
+	if err := r.db.Where("id = ? AND user_id = ?", registrationID, userID).First(&registration).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("registration not found")
 		}
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+	return &registration, nil
+}
 
-		// 4. Create Registration
-		if err := tx.Create(registration).Error; err != nil {
-			return fmt.Errorf("failed to create registration: %w", err)
+// GetBooking returns all seats of the booking a registration belongs to, the booking user's own seat first.
+// Registrations made without guests are a booking of their own.
+func (r *RegistrationRepository) GetBooking(registration *domain.Registration) ([]domain.Registration, error) {
+	if registration.GroupID == nil {
+		return []domain.Registration{*registration}, nil
+	}
+	var registrations []domain.Registration
+	if err := r.db.Where("group_id = ?", *registration.GroupID).
+		Order("is_guest ASC, created_at ASC").
+		Find(&registrations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return registrations, nil
+}
+
+// Approve turns a pending application into seat-holding registrations, for all pending seats of its booking.
+// Capacity and ticket stock are re-checked under the event row lock, since they were not
+// consumed when the application was made. For paid tickets the order is created in the
+// same transaction and the promo code redemption (if any) is linked to it.
+func (r *RegistrationRepository) Approve(registration *domain.Registration, order *domain.Order, redemption *domain.PromoRedemption, reviewerID, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, registration.EventID)
+		if err != nil {
+			return err
+		}
+
+		var ticketType *domain.TicketType
+		if registration.TicketTypeID != nil {
+			ticketType, err = getEventTicketType(tx, registration.EventID, *registration.TicketTypeID)
+			if err != nil {
+				return err
+			}
+		}
+		var seats int64
+		if err := bookingScope(tx, registration).
+			Where("status = ?", domain.RegistrationStatusPending).
+			Count(&seats).Error; err != nil {
+			return fmt.Errorf("failed to count booked seats: %w", err)
+		}
+		if err := checkSeatsAvailable(tx, event, ticketType, int(seats)); err != nil {
+			return err
 		}
 
+		status := domain.RegistrationStatusConfirmed
+		updates := map[string]interface{}{}
+		if order != nil {
+			if err := tx.Create(order).Error; err != nil {
+				return fmt.Errorf("failed to create order: %w", err)
+			}
+			if redemption != nil {
+				if err := tx.Model(redemption).Update("order_id", order.ID).Error; err != nil {
+					return fmt.Errorf("failed to link promo code redemption: %w", err)
+				}
+			}
+			status = domain.RegistrationStatusPendingPayment
+			updates["order_id"] = order.ID
+		}
+
+		now := time.Now()
+		updates["status"] = status
+		updates["reviewed_by"] = reviewerID
+		updates["reviewed_at"] = now
+		updates["review_reason"] = reason
+		if err := reviewPending(tx, registration, updates); err != nil {
+			return err
+		}
+
+		registration.Status = status
+		registration.ReviewedBy = &reviewerID
+		registration.ReviewedAt = &now
+		registration.ReviewReason = reason
+		if order != nil {
+			registration.OrderID = &order.ID
+		}
 		return nil
 	})
 }
 
+// Reject declines a pending application with all pending seats of its booking.
+// Its promo code redemption (if any) stops counting.
+func (r *RegistrationRepository) Reject(registration *domain.Registration, reviewerID, reason string) error {
+	now := time.Now()
+	if err := reviewPending(r.db, registration, map[string]interface{}{
+		"status":        domain.RegistrationStatusRejected,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   now,
+		"review_reason": reason,
+	}); err != nil {
+		return err
+	}
+
+	registration.Status = domain.RegistrationStatusRejected
+	registration.ReviewedBy = &reviewerID
+	registration.ReviewedAt = &now
+	registration.ReviewReason = reason
+	return nil
+}
+
+// reviewPending applies the outcome of a review to the seats of a booking that are still pending
+func reviewPending(tx *gorm.DB, registration *domain.Registration, updates map[string]interface{}) error {
+	result := bookingScope(tx, registration).
+		Where("status = ?", domain.RegistrationStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to review registration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("registration is no longer pending")
+	}
+	return nil
+}
+
+// bookingScope selects the seats of the booking a registration belongs to
+func bookingScope(tx *gorm.DB, registration *domain.Registration) *gorm.DB {
+	query := tx.Model(&domain.Registration{})
+	if registration.GroupID != nil {
+		return query.Where("group_id = ?", *registration.GroupID)
+	}
+	return query.Where("id = ?", registration.ID)
+}
+
+// lockEvent locks the event row for the rest of the transaction
+func lockEvent(tx *gorm.DB, eventID string) (*domain.Event, error) {
+	var event domain.Event
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "capacity", "version").
+		Where("id = ?", eventID).
+		First(&event).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock event for registration: %w", err)
+	}
+	return &event, nil
+}
+
+// getEventTicketType loads a ticket type that must belong to the event
+func getEventTicketType(tx *gorm.DB, eventID, ticketTypeID string) (*domain.TicketType, error) {
+	var ticketType domain.TicketType
+	if err := tx.Where("id = ? AND event_id = ?", ticketTypeID, eventID).First(&ticketType).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("ticket type not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket type: %w", err)
+	}
+	return &ticketType, nil
+}
+
+// checkSeatsAvailable checks that the event's capacity and the ticket type's stock (if any)
+// leave room for the given number of seats. It must run under the event row lock.
+func checkSeatsAvailable(tx *gorm.DB, event *domain.Event, ticketType *domain.TicketType, seats int) error {
+	var current int64
+	if err := tx.Model(&domain.Registration{}).
+		Where("event_id = ? AND status IN ?", event.ID, domain.SeatHoldingStatuses).
+		Count(&current).Error; err != nil {
+		return fmt.Errorf("failed to count registrations: %w", err)
+	}
+	if int(current) >= event.Capacity {
+		return fmt.Errorf("event is full (capacity reached)")
+	}
+	if int(current)+seats > event.Capacity {
+		return fmt.Errorf("not enough seats left (%d available)", event.Capacity-int(current))
+	}
+
+	if ticketType != nil {
+		sold, err := countSold(tx, ticketType.ID)
+		if err != nil {
+			return err
+		}
+		if int(sold) >= ticketType.Quantity {
+			return fmt.Errorf("ticket type %q is sold out", ticketType.Name)
+		}
+		if int(sold)+seats > ticketType.Quantity {
+			return fmt.Errorf("not enough %q tickets left (%d available)", ticketType.Name, ticketType.Quantity-int(sold))
+		}
+	}
+	return nil
+}
+
 func (r *RegistrationRepository) CheckIn(userID, eventID string) error {
 	result := r.db.Model(&domain.Registration{}).
-		Where("user_id = ? AND event_id = ?", userID, eventID).
-		Update("status", "checked_in")
+		Where("user_id = ? AND event_id = ? AND is_guest = ? AND status = ?", userID, eventID, false, domain.RegistrationStatusConfirmed).
+		Updates(checkInUpdates())
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to check in registration: %w", result.Error)
@@ -124,11 +463,33 @@ func (r *RegistrationRepository) CheckIn(userID, eventID string) error {
 	return nil
 }
 
+// CheckInSeat marks a single confirmed seat (for example a guest's) as checked in
+func (r *RegistrationRepository) CheckInSeat(eventID, registrationID string) error {
+	result := r.db.Model(&domain.Registration{}).
+		Where("id = ? AND event_id = ? AND status = ?", registrationID, eventID, domain.RegistrationStatusConfirmed).
+		Updates(checkInUpdates())
+	if result.Error != nil {
+		return fmt.Errorf("failed to check in registration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("registration not found")
+	}
+	return nil
+}
+
+// checkInUpdates marks a seat as checked in now
+func checkInUpdates() map[string]interface{} {
+	return map[string]interface{}{
+		"status":        domain.RegistrationStatusCheckedIn,
+		"checked_in_at": time.Now(),
+	}
+}
+
 // GetEventRegistrants gets all registrations for an event with user details
 func (r *RegistrationRepository) GetEventRegistrants(eventID string, status string) ([]domain.Registration, error) {
 	var registrations []domain.Registration
 
-	query := r.db.Preload("User").Where("event_id = ?", eventID)
+	query := r.db.Preload("User").Preload("TicketType").Where("event_id = ?", eventID)
 
 	// Filter by status if provided
 	if status != "" && status != "all" {
@@ -142,3 +503,61 @@ func (r *RegistrationRepository) GetEventRegistrants(eventID string, status stri
 
 	return registrations, nil
 }
+
+// StreamEventRegistrants passes the registrations of an event to fn one at a time, oldest first,
+// reading them row by row so that exports of large events are never held in memory at once.
+// Status filters like GetEventRegistrants; fn stops the stream by returning an error.
+func (r *RegistrationRepository) StreamEventRegistrants(eventID, status string, fn func(*domain.RegistrantRecord) error) error {
+	query := r.db.Table("registrations").
+		Select("registrations.id, users.name, users.email, registrations.is_guest, registrations.guest_name, registrations.guest_email, "+
+			"registrations.status, ticket_types.name, registrations.created_at, registrations.checked_in_at, registrations.answers").
+		Joins("JOIN users ON users.id = registrations.user_id").
+		Joins("LEFT JOIN ticket_types ON ticket_types.id = registrations.ticket_type_id").
+		Where("registrations.event_id = ? AND registrations.deleted_at IS NULL", eventID).
+		Order("registrations.created_at ASC, registrations.id ASC")
+	if status != "" && status != "all" {
+		query = query.Where("registrations.status = ?", status)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to get event registrants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			record                        domain.RegistrantRecord
+			name                          string
+			guestName, guestEmail, ticket sql.NullString
+			checkedInAt                   sql.NullTime
+			answers                       sql.NullString
+		)
+		if err := rows.Scan(&record.RegistrationID, &name, &record.BookedBy, &record.IsGuest, &guestName, &guestEmail,
+			&record.Status, &ticket, &record.RegisteredAt, &checkedInAt, &answers); err != nil {
+			return fmt.Errorf("failed to read registrant: %w", err)
+		}
+
+		record.Name, record.Email = name, record.BookedBy
+		if record.IsGuest {
+			record.Name, record.Email = guestName.String, guestEmail.String
+		}
+		record.TicketType = ticket.String
+		if checkedInAt.Valid {
+			record.CheckedInAt = &checkedInAt.Time
+		}
+		if answers.Valid && answers.String != "" {
+			if err := json.Unmarshal([]byte(answers.String), &record.Answers); err != nil {
+				return fmt.Errorf("failed to read answers of registration %s: %w", record.RegistrationID, err)
+			}
+		}
+
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get event registrants: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistrationTransferRepository(db *gorm.DB) *RegistrationTransferRepository {
+	return &RegistrationTransferRepository{db: db}
+}
+
+// Create stores a new transfer invitation
+func (r *RegistrationTransferRepository) Create(transfer *domain.RegistrationTransfer) error {
+	if err := r.db.Create(transfer).Error; err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a transfer with its event
+func (r *RegistrationTransferRepository) GetByID(id string) (*domain.RegistrationTransfer, error) {
+	var transfer domain.RegistrationTransfer
+	if err := r.db.Preload("Event").Where("id = ?", id).First(&transfer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("transfer not found")
+		}
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+// GetPendingByRegistration returns the pending transfer of a registration, or nil if there is none
+func (r *RegistrationTransferRepository) GetPendingByRegistration(registrationID string) (*domain.RegistrationTransfer, error) {
+	var transfer domain.RegistrationTransfer
+	err := r.db.Where("registration_id = ? AND status = ?", registrationID, domain.TransferStatusPending).First(&transfer).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+// GetUserTransfers returns the transfers a user sent or was invited to, newest first
+func (r *RegistrationTransferRepository) GetUserTransfers(userID, email string) ([]domain.RegistrationTransfer, error) {
+	var transfers []domain.RegistrationTransfer
+	if err := r.db.Preload("Event").
+		Where("from_user_id = ? OR to_email = ?", userID, email).
+		Order("created_at DESC").
+		Find(&transfers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// GetByEvent returns every transfer of an event's registrations, newest first
+func (r *RegistrationTransferRepository) GetByEvent(eventID string) ([]domain.RegistrationTransfer, error) {
+	var transfers []domain.RegistrationTransfer
+	if err := r.db.Where("event_id = ?", eventID).
+		Order("created_at DESC").
+		Find(&transfers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// Close ends a pending transfer without moving the registration (declined or cancelled)
+func (r *RegistrationTransferRepository) Close(transfer *domain.RegistrationTransfer, status string) error {
+	now := time.Now()
+	result := r.db.Model(&domain.RegistrationTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, domain.TransferStatusPending).
+		Updates(map[string]interface{}{"status": status, "responded_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update transfer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("transfer is no longer pending")
+	}
+
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	return nil
+}
+
+// Accept hands the registration over to the recipient in one transaction. The seat itself
+// is reused, so event capacity and ticket stock are unaffected; the seat gets a fresh ticket
+// token so the one held by the previous owner stops working. The seat leaves its group booking
+// and the previous owner's form answers are cleared.
+func (r *RegistrationTransferRepository) Accept(transfer *domain.RegistrationTransfer, toUserID string) (*domain.Registration, error) {
+	var registration domain.Registration
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked domain.RegistrationTransfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", transfer.ID).
+			First(&locked).Error; err != nil {
+			return fmt.Errorf("failed to lock transfer: %w", err)
+		}
+		now := time.Now()
+		if !locked.IsOpen(now) {
+			return fmt.Errorf("transfer is no longer pending")
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", locked.RegistrationID).
+			First(&registration).Error; err != nil {
+			return fmt.Errorf("failed to lock registration: %w", err)
+		}
+		if registration.UserID != locked.FromUserID || registration.Status != domain.RegistrationStatusConfirmed {
+			return fmt.Errorf("registration can no longer be transferred")
+		}
+
+		var existing int64
+		if err := tx.Model(&domain.Registration{}).
+			Where("user_id = ? AND event_id = ? AND is_guest = ? AND status IN ?",
+				toUserID, registration.EventID, false, domain.ActiveRegistrationStatuses).
+			Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check existing registration: %w", err)
+		}
+		if existing > 0 {
+			return fmt.Errorf("you are already registered for this event")
+		}
+
+		token := domain.NewTicketToken()
+		if err := tx.Model(&domain.Registration{}).
+			Where("id = ?", registration.ID).
+			Updates(map[string]interface{}{
+				"user_id":      toUserID,
+				"ticket_token": token,
+				"is_guest":     false,
+				"guest_name":   "",
+				"guest_email":  "",
+				"group_id":     nil,
+				"answers":      nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to transfer registration: %w", err)
+		}
+
+		if err := tx.Model(&domain.RegistrationTransfer{}).
+			Where("id = ?", locked.ID).
+			Updates(map[string]interface{}{
+				"status":       domain.TransferStatusAccepted,
+				"to_user_id":   toUserID,
+				"responded_at": now,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update transfer: %w", err)
+		}
+
+		registration.UserID = toUserID
+		registration.TicketToken = token
+		registration.IsGuest = false
+		registration.GuestName = ""
+		registration.GuestEmail = ""
+		registration.GroupID = nil
+		registration.Answers = nil
+		transfer.Status = domain.TransferStatusAccepted
+		transfer.ToUserID = &toUserID
+		transfer.RespondedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
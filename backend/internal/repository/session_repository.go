@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// CreateSpeaker inserts a new speaker
+func (r *SessionRepository) CreateSpeaker(speaker *domain.Speaker) error {
+	if err := r.db.Create(speaker).Error; err != nil {
+		return fmt.Errorf("failed to create speaker: %w", err)
+	}
+	return nil
+}
+
+// UpdateSpeaker saves changes to a speaker
+func (r *SessionRepository) UpdateSpeaker(speaker *domain.Speaker) error {
+	if err := r.db.Save(speaker).Error; err != nil {
+		return fmt.Errorf("failed to update speaker: %w", err)
+	}
+	return nil
+}
+
+// DeleteSpeaker removes a speaker of the given event from the event and from its sessions
+func (r *SessionRepository) DeleteSpeaker(eventID, speakerID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("session_speakers").Where("speaker_id = ?", speakerID).Delete(nil).Error; err != nil {
+			return fmt.Errorf("failed to remove speaker from sessions: %w", err)
+		}
+		result := tx.Delete(&domain.Speaker{}, "id = ? AND event_id = ?", speakerID, eventID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete speaker: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("speaker not found")
+		}
+		return nil
+	})
+}
+
+// GetSpeaker retrieves a speaker of the given event
+func (r *SessionRepository) GetSpeaker(eventID, speakerID string) (*domain.Speaker, error) {
+	var speaker domain.Speaker
+	if err := r.db.Where("id = ? AND event_id = ?", speakerID, eventID).First(&speaker).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("speaker not found")
+		}
+		return nil, fmt.Errorf("failed to get speaker: %w", err)
+	}
+	return &speaker, nil
+}
+
+// GetSpeakers returns the speakers of an event, by name
+func (r *SessionRepository) GetSpeakers(eventID string) ([]domain.Speaker, error) {
+	speakers := []domain.Speaker{}
+	if err := r.db.Where("event_id = ?", eventID).Order("name ASC").Find(&speakers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get speakers: %w", err)
+	}
+	return speakers, nil
+}
+
+// GetSpeakersByIDs returns the speakers of an event with the given IDs; unknown IDs are skipped
+func (r *SessionRepository) GetSpeakersByIDs(eventID string, ids []string) ([]domain.Speaker, error) {
+	speakers := []domain.Speaker{}
+	if len(ids) == 0 {
+		return speakers, nil
+	}
+	if err := r.db.Where("event_id = ? AND id IN ?", eventID, ids).Find(&speakers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get speakers: %w", err)
+	}
+	return speakers, nil
+}
+
+// SaveSession creates or updates a session and replaces its speakers, in one transaction.
+// Under the event row lock it rejects sessions that overlap another session in the same room
+// (domain.ErrSessionRoomConflict) and capacities below the seats already taken.
+func (r *SessionRepository) SaveSession(session *domain.Session, create bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockEvent(tx, session.EventID); err != nil {
+			return err
+		}
+
+		if session.Room != "" {
+			var sameRoom []domain.Session
+			if err := tx.Where("event_id = ? AND LOWER(room) = LOWER(?)", session.EventID, session.Room).
+				Find(&sameRoom).Error; err != nil {
+				return fmt.Errorf("failed to check room: %w", err)
+			}
+			if other := session.RoomConflict(sameRoom); other != nil {
+				return domain.NewCodedError(domain.ErrSessionRoomConflict.Code,
+					fmt.Sprintf("room %q is already booked for %q at that time", session.Room, other.Title))
+			}
+		}
+
+		if create {
+			if err := tx.Omit(clause.Associations).Create(session).Error; err != nil {
+				return fmt.Errorf("failed to create session: %w", err)
+			}
+		} else {
+			if session.Capacity != nil {
+				registered, err := countSessionRegistrations(tx, session.ID)
+				if err != nil {
+					return err
+				}
+				if int(registered) > *session.Capacity {
+					return fmt.Errorf("capacity cannot be lower than the %d seats already taken", registered)
+				}
+			}
+			if err := tx.Omit(clause.Associations).Save(session).Error; err != nil {
+				return fmt.Errorf("failed to update session: %w", err)
+			}
+		}
+
+		if err := tx.Model(session).Association("Speakers").Replace(session.Speakers); err != nil {
+			return fmt.Errorf("failed to update session speakers: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteSession removes a session of the given event with its speakers and registrations
+func (r *SessionRepository) DeleteSession(eventID, sessionID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Session{}, "id = ? AND event_id = ?", sessionID, eventID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete session: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("session not found")
+		}
+		if err := tx.Table("session_speakers").Where("session_id = ?", sessionID).Delete(nil).Error; err != nil {
+			return fmt.Errorf("failed to delete session speakers: %w", err)
+		}
+		if err := tx.Where("session_id = ?", sessionID).Delete(&domain.SessionRegistration{}).Error; err != nil {
+			return fmt.Errorf("failed to delete session registrations: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetSession retrieves a session of the given event with its speakers and seats taken
+func (r *SessionRepository) GetSession(eventID, sessionID string) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.Preload("Speakers").Where("id = ? AND event_id = ?", sessionID, eventID).First(&session).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	registered, err := countSessionRegistrations(r.db, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.Registered = registered
+	return &session, nil
+}
+
+// GetSessions returns the sessions of an event by start time and room, with their speakers and seats taken
+func (r *SessionRepository) GetSessions(eventID string) ([]domain.Session, error) {
+	sessions := []domain.Session{}
+	if err := r.db.Preload("Speakers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("speakers.name ASC")
+	}).Where("event_id = ?", eventID).Order("start_datetime ASC, room ASC").Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var counts []struct {
+		SessionID string
+		Count     int64
+	}
+	if err := r.db.Model(&domain.SessionRegistration{}).
+		Select("session_registrations.session_id, COUNT(*) AS count").
+		Joins("JOIN sessions ON sessions.id = session_registrations.session_id").
+		Where("sessions.event_id = ?", eventID).
+		Group("session_registrations.session_id").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count session registrations: %w", err)
+	}
+	registered := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		registered[c.SessionID] = c.Count
+	}
+	for i := range sessions {
+		sessions[i].Registered = registered[sessions[i].ID]
+	}
+	return sessions, nil
+}
+
+// Register books a seat in a session for an attendee of its event, in one transaction.
+// The session row is locked while the seats are counted, so the capacity can't be exceeded.
+func (r *SessionRepository) Register(session *domain.Session, registration *domain.SessionRegistration) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked domain.Session
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "capacity").
+			Where("id = ?", session.ID).
+			First(&locked).Error; err != nil {
+			return fmt.Errorf("failed to lock session for registration: %w", err)
+		}
+		if locked.Capacity == nil {
+			return fmt.Errorf("this session does not take registrations")
+		}
+
+		var attending int64
+		if err := tx.Model(&domain.Registration{}).
+			Where("event_id = ? AND user_id = ? AND status IN ?", session.EventID, registration.UserID, domain.SeatHoldingStatuses).
+			Count(&attending).Error; err != nil {
+			return fmt.Errorf("failed to check event registration: %w", err)
+		}
+		if attending == 0 {
+			return fmt.Errorf("only attendees of the event can register for its sessions")
+		}
+
+		var existing int64
+		if err := tx.Model(&domain.SessionRegistration{}).
+			Where("session_id = ? AND user_id = ?", session.ID, registration.UserID).
+			Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check session registration: %w", err)
+		}
+		if existing > 0 {
+			return fmt.Errorf("you are already registered for this session")
+		}
+
+		registered, err := countSessionRegistrations(tx, session.ID)
+		if err != nil {
+			return err
+		}
+		if int(registered) >= *locked.Capacity {
+			return domain.ErrSessionFull
+		}
+
+		if err := tx.Create(registration).Error; err != nil {
+			return fmt.Errorf("failed to register for session: %w", err)
+		}
+		return nil
+	})
+}
+
+// Unregister frees a user's seat in a session
+func (r *SessionRepository) Unregister(sessionID, userID string) error {
+	result := r.db.Where("session_id = ? AND user_id = ?", sessionID, userID).Delete(&domain.SessionRegistration{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to cancel session registration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("you are not registered for this session")
+	}
+	return nil
+}
+
+// countSessionRegistrations returns the number of seats taken in a session
+func countSessionRegistrations(db *gorm.DB, sessionID string) (int64, error) {
+	var count int64
+	if err := db.Model(&domain.SessionRegistration{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count session registrations: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// bookedStatuses are the statuses of seats that were booked: the ones held now and the ones cancelled since
+var bookedStatuses = append([]string{domain.RegistrationStatusCancelled}, domain.SeatHoldingStatuses...)
+
+// StatsRepository computes the analytics of events with SQL aggregates,
+// so the registrations themselves are never loaded.
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// GetEventStats returns the registration stats of an event. Confirmed seats count as no-shows once the event has ended (before now).
+func (r *StatsRepository) GetEventStats(event *domain.Event, now time.Time) (*domain.EventStats, error) {
+	events := r.db.Model(&domain.Event{}).Select("id").Where("id = ?", event.ID)
+
+	stats := &domain.EventStats{EventID: event.ID}
+	if err := r.countRegistrations(events, now, &stats.RegistrationStats); err != nil {
+		return nil, err
+	}
+	stats.Capacity = int64(event.Capacity)
+	stats.SetRates()
+
+	daily, err := r.countDaily(events)
+	if err != nil {
+		return nil, err
+	}
+	stats.Daily = daily
+	return stats, nil
+}
+
+// GetOrganizerStats returns the registration stats of all events starting within the query's days whose
+// stats the user may view, as service.authorizeEvent decides it: the events they organize, those of
+// their event teams and those of the organizations they belong to
+func (r *StatsRepository) GetOrganizerStats(userID string, query *domain.OrganizerStatsQuery, now time.Time) (*domain.OrganizerStats, error) {
+	teams := r.db.Model(&domain.EventMember{}).Select("event_id").
+		Where("user_id = ? AND role IN ?", userID, domain.EventRolesAllowing(domain.EventActionView))
+	orgs := r.db.Model(&domain.OrganizationMember{}).Select("organization_id").Where("user_id = ?", userID)
+	events := r.db.Model(&domain.Event{}).
+		Where("organizer_id = ? OR id IN (?) OR organization_id IN (?)", userID, teams, orgs)
+	if query.From != nil {
+		events = events.Where("start_datetime >= ?", *query.From)
+	}
+	if query.To != nil {
+		events = events.Where("start_datetime < ?", query.To.AddDate(0, 0, 1))
+	}
+
+	stats := &domain.OrganizerStats{From: query.From, To: query.To}
+	var totals struct {
+		Events   int64
+		Capacity int64
+	}
+	if err := events.Session(&gorm.Session{}).
+		Select("COUNT(*) AS events, COALESCE(SUM(capacity), 0) AS capacity").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	eventIDs := events.Session(&gorm.Session{}).Select("id")
+	if err := r.countRegistrations(eventIDs, now, &stats.RegistrationStats); err != nil {
+		return nil, err
+	}
+	stats.Events, stats.Capacity = totals.Events, totals.Capacity
+	stats.SetRates()
+
+	daily, err := r.countDaily(eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	stats.Daily = daily
+	return stats, nil
+}
+
+// countRegistrations fills in the registration counts of the events selected by the eventIDs subquery
+func (r *StatsRepository) countRegistrations(eventIDs *gorm.DB, now time.Time, stats *domain.RegistrationStats) error {
+	if err := r.db.Table("registrations").
+		Select("COUNT(CASE WHEN registrations.status IN ? THEN 1 END) AS registrations, "+
+			"COUNT(CASE WHEN registrations.status IN ? THEN 1 END) AS seats_taken, "+
+			"COUNT(CASE WHEN registrations.status = ? THEN 1 END) AS cancellations, "+
+			"COUNT(CASE WHEN registrations.status = ? THEN 1 END) AS confirmed, "+
+			"COUNT(CASE WHEN registrations.status = ? THEN 1 END) AS checked_in, "+
+			"COUNT(CASE WHEN registrations.status = ? AND events.end_datetime < ? THEN 1 END) AS no_shows, "+
+			"COUNT(CASE WHEN registrations.status = ? THEN 1 END) AS pending_approvals",
+			bookedStatuses, domain.SeatHoldingStatuses, domain.RegistrationStatusCancelled,
+			domain.RegistrationStatusConfirmed, domain.RegistrationStatusCheckedIn,
+			domain.RegistrationStatusConfirmed, now, domain.RegistrationStatusPending).
+		Joins("JOIN events ON events.id = registrations.event_id").
+		Where("registrations.event_id IN (?) AND registrations.deleted_at IS NULL", eventIDs).
+		Scan(stats).Error; err != nil {
+		return fmt.Errorf("failed to count registrations: %w", err)
+	}
+	return nil
+}
+
+// countDaily buckets the seats booked (by booking day) and cancelled (by cancellation day) of the
+// events selected by the eventIDs subquery. Only days with activity are returned, oldest first.
+func (r *StatsRepository) countDaily(eventIDs *gorm.DB) ([]domain.DailyRegistrations, error) {
+	type bucket struct {
+		Date  string
+		Count int64
+	}
+
+	var booked []bucket
+	created := r.dayExpr("created_at")
+	if err := r.db.Model(&domain.Registration{}).
+		Select(created+" AS date, COUNT(*) AS count").
+		Where("event_id IN (?) AND status IN ?", eventIDs, bookedStatuses).
+		Group(created).
+		Scan(&booked).Error; err != nil {
+		return nil, fmt.Errorf("failed to count registrations per day: %w", err)
+	}
+
+	var cancelled []bucket
+	cancelledAt := r.dayExpr("cancelled_at")
+	if err := r.db.Model(&domain.Registration{}).
+		Select(cancelledAt+" AS date, COUNT(*) AS count").
+		Where("event_id IN (?) AND status = ? AND cancelled_at IS NOT NULL", eventIDs, domain.RegistrationStatusCancelled).
+		Group(cancelledAt).
+		Scan(&cancelled).Error; err != nil {
+		return nil, fmt.Errorf("failed to count cancellations per day: %w", err)
+	}
+
+	days := map[string]*domain.DailyRegistrations{}
+	day := func(date string) *domain.DailyRegistrations {
+		if days[date] == nil {
+			days[date] = &domain.DailyRegistrations{Date: date}
+		}
+		return days[date]
+	}
+	for _, b := range booked {
+		day(b.Date).Registrations = b.Count
+	}
+	for _, b := range cancelled {
+		day(b.Date).Cancellations = b.Count
+	}
+
+	daily := make([]domain.DailyRegistrations, 0, len(days))
+	for _, d := range days {
+		daily = append(daily, *d)
+	}
+	sort.Slice(daily, func(i, j int) bool { return daily[i].Date < daily[j].Date })
+	return daily, nil
+}
+
+// dayExpr returns a SQL expression formatting a timestamp column as its YYYY-MM-DD day in UTC
+func (r *StatsRepository) dayExpr(column string) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s)", column)
+	}
+	return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD')", column)
+}
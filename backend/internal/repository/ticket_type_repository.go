@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type TicketTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketTypeRepository(db *gorm.DB) *TicketTypeRepository {
+	return &TicketTypeRepository{db: db}
+}
+
+// Create inserts a new ticket type
+func (r *TicketTypeRepository) Create(ticketType *domain.TicketType) error {
+	if err := r.db.Create(ticketType).Error; err != nil {
+		return fmt.Errorf("failed to create ticket type: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to a ticket type. Under the event row lock, which registrations take as well,
+// it fails when the quantity would drop below the seats sold, so none can be sold in between.
+func (r *TicketTypeRepository) Update(ticketType *domain.TicketType) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockEvent(tx, ticketType.EventID); err != nil {
+			return err
+		}
+		sold, err := countSold(tx, ticketType.ID)
+		if err != nil {
+			return err
+		}
+		if int64(ticketType.Quantity) < sold {
+			return fmt.Errorf("quantity cannot be lower than the %d tickets already sold", sold)
+		}
+		if err := tx.Save(ticketType).Error; err != nil {
+			return fmt.Errorf("failed to update ticket type: %w", err)
+		}
+		ticketType.Sold = sold
+		return nil
+	})
+}
+
+// Delete soft-deletes a ticket type of the given event
+func (r *TicketTypeRepository) Delete(eventID, ticketTypeID string) error {
+	result := r.db.Delete(&domain.TicketType{}, "id = ? AND event_id = ?", ticketTypeID, eventID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete ticket type: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ticket type not found")
+	}
+	return nil
+}
+
+// GetByID retrieves a ticket type of the given event, including the number of seats sold
+func (r *TicketTypeRepository) GetByID(eventID, ticketTypeID string) (*domain.TicketType, error) {
+	var ticketType domain.TicketType
+	result := r.db.Where("id = ? AND event_id = ?", ticketTypeID, eventID).First(&ticketType)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("ticket type not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket type: %w", result.Error)
+	}
+
+	sold, err := countSold(r.db, ticketType.ID)
+	if err != nil {
+		return nil, err
+	}
+	ticketType.Sold = sold
+	return &ticketType, nil
+}
+
+// GetByEvent retrieves all ticket types of an event ordered by price, including seats sold
+func (r *TicketTypeRepository) GetByEvent(eventID string) ([]domain.TicketType, error) {
+	var ticketTypes []domain.TicketType
+	if err := r.db.Where("event_id = ?", eventID).Order("price_cents ASC, name ASC").Find(&ticketTypes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ticket types: %w", err)
+	}
+	if len(ticketTypes) == 0 {
+		return ticketTypes, nil
+	}
+
+	type soldRow struct {
+		TicketTypeID string
+		Sold         int64
+	}
+	var rows []soldRow
+	if err := r.db.Model(&domain.Registration{}).
+		Select("ticket_type_id, COUNT(*) AS sold").
+		Where("event_id = ? AND ticket_type_id IS NOT NULL AND status IN ?", eventID, domain.SeatHoldingStatuses).
+		Group("ticket_type_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count sold tickets: %w", err)
+	}
+	sold := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		sold[row.TicketTypeID] = row.Sold
+	}
+	for i := range ticketTypes {
+		ticketTypes[i].Sold = sold[ticketTypes[i].ID]
+	}
+	return ticketTypes, nil
+}
+
+// CountByEvent counts the ticket types of an event
+func (r *TicketTypeRepository) CountByEvent(eventID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&domain.TicketType{}).Where("event_id = ?", eventID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count ticket types: %w", err)
+	}
+	return count, nil
+}
+
+// countSold counts seat-holding registrations of a ticket type
+func countSold(db *gorm.DB, ticketTypeID string) (int64, error) {
+	var sold int64
+	if err := db.Model(&domain.Registration{}).
+		Where("ticket_type_id = ? AND status IN ?", ticketTypeID, domain.SeatHoldingStatuses).
+		Count(&sold).Error; err != nil {
+		return 0, fmt.Errorf("failed to count sold tickets: %w", err)
+	}
+	return sold, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// TrashRepository deletes soft-deleted rows for good once they have been in the trash long enough
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// PurgeEvents hard-deletes up to limit events deleted before the given time, with their registrations,
+// in one transaction. The other rows of the events (tickets, orders, files, ...) go with them through
+// the foreign keys; the blob store keys of their files are returned so the objects can be removed too.
+func (r *TrashRepository) PurgeEvents(before time.Time, limit int) (*domain.PurgeResult, error) {
+	result := &domain.PurgeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Unscoped().Model(&domain.Event{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Limit(limit).Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find expired events: %w", err)
+		}
+		return purgeEvents(tx, ids, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExpiredUsers returns the IDs of up to limit users deleted before the given time
+func (r *TrashRepository) ExpiredUsers(before time.Time, limit int) ([]string, error) {
+	var ids []string
+	if err := r.db.Unscoped().Model(&domain.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired users: %w", err)
+	}
+	return ids, nil
+}
+
+// PurgeUser hard-deletes a user in one transaction, with the events they organize and every registration
+// of the user or of those events. Events of organizations belong to the organization: they are handed
+// to another admin of it instead, and the user is not purged while an organization has none.
+// Files the user uploaded and organizations they created are kept (the references are cleared).
+func (r *TrashRepository) PurgeUser(userID string) (*domain.PurgeResult, error) {
+	result := &domain.PurgeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := handOverOrganizationEvents(tx, userID); err != nil {
+			return err
+		}
+
+		var eventIDs []string
+		if err := tx.Unscoped().Model(&domain.Event{}).Where("organizer_id = ? AND organization_id IS NULL", userID).Pluck("id", &eventIDs).Error; err != nil {
+			return fmt.Errorf("failed to find the user's events: %w", err)
+		}
+		if err := purgeEvents(tx, eventIDs, result); err != nil {
+			return err
+		}
+
+		registrations := tx.Unscoped().Where("user_id = ?", userID).Delete(&domain.Registration{})
+		if registrations.Error != nil {
+			return fmt.Errorf("failed to purge registrations: %w", registrations.Error)
+		}
+		result.Registrations += registrations.RowsAffected
+
+		users := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", userID).Delete(&domain.User{})
+		if users.Error != nil {
+			return fmt.Errorf("failed to purge user: %w", users.Error)
+		}
+		result.Users = users.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PurgeRegistrations hard-deletes the registrations deleted before the given time
+func (r *TrashRepository) PurgeRegistrations(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&domain.Registration{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge registrations: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// handOverOrganizationEvents makes the longest-standing other admin of each organization the organizer
+// of the organization's events the user organizes
+func handOverOrganizationEvents(tx *gorm.DB, userID string) error {
+	var orgIDs []string
+	if err := tx.Unscoped().Model(&domain.Event{}).Distinct("organization_id").
+		Where("organizer_id = ? AND organization_id IS NOT NULL", userID).
+		Pluck("organization_id", &orgIDs).Error; err != nil {
+		return fmt.Errorf("failed to find the user's organization events: %w", err)
+	}
+
+	for _, orgID := range orgIDs {
+		var admin domain.OrganizationMember
+		err := tx.Where("organization_id = ? AND role = ? AND user_id <> ?", orgID, domain.OrganizationRoleAdmin, userID).
+			Where("user_id IN (?)", tx.Model(&domain.User{}).Select("id")).
+			Order("created_at").First(&admin).Error
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("organization %s has no other admin to hand its events to", orgID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find an organization admin: %w", err)
+		}
+
+		if err := tx.Unscoped().Model(&domain.Event{}).
+			Where("organizer_id = ? AND organization_id = ?", userID, orgID).
+			Updates(map[string]interface{}{"organizer_id": admin.UserID, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return fmt.Errorf("failed to hand over organization events: %w", err)
+		}
+	}
+	return nil
+}
+
+// purgeEvents hard-deletes events and their registrations, adding what it removed to result
+func purgeEvents(tx *gorm.DB, ids []string, result *domain.PurgeResult) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var files []domain.EventFile
+	if err := tx.Select("storage_key", "thumbnail_key").Where("event_id IN ?", ids).Find(&files).Error; err != nil {
+		return fmt.Errorf("failed to find event files: %w", err)
+	}
+	for _, file := range files {
+		result.FileKeys = append(result.FileKeys, file.StorageKey)
+		if file.ThumbnailKey != "" {
+			result.FileKeys = append(result.FileKeys, file.ThumbnailKey)
+		}
+	}
+
+	registrations := tx.Unscoped().Where("event_id IN ?", ids).Delete(&domain.Registration{})
+	if registrations.Error != nil {
+		return fmt.Errorf("failed to purge registrations: %w", registrations.Error)
+	}
+	result.Registrations += registrations.RowsAffected
+
+	events := tx.Unscoped().Where("id IN ?", ids).Delete(&domain.Event{})
+	if events.Error != nil {
+		return fmt.Errorf("failed to purge events: %w", events.Error)
+	}
+	result.Events += events.RowsAffected
+	return nil
+}
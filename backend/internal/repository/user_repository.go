@@ -38,6 +38,18 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 	return &user, nil
 }
 
+// GetByEmails retrieves the users with any of the given (lowercased) email addresses
+func (r *UserRepository) GetByEmails(emails []string) ([]domain.User, error) {
+	var users []domain.User
+	if len(emails) == 0 {
+		return users, nil
+	}
+	if err := r.db.Where("LOWER(email) IN ?", emails).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users by email: %w", err)
+	}
+	return users, nil
+}
+
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(id string) (*domain.User, error) {
 	var user domain.User
@@ -85,6 +97,29 @@ func (r *UserRepository) Delete(userID string) error {
 	return nil
 }
 
+// GetTrash returns the soft-deleted users, most recently deleted first
+func (r *UserRepository) GetTrash() ([]domain.User, error) {
+	users := []domain.User{}
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get deleted users: %w", err)
+	}
+	return users, nil
+}
+
+// Restore takes a soft-deleted user back out of the trash
+func (r *UserRepository) Restore(userID string) (*domain.User, error) {
+	result := r.db.Unscoped().Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", userID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to restore user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("user not found in the trash")
+	}
+	return r.GetByID(userID)
+}
+
 // GetAll retrieves all users (admin operation, можно добавить пагинацию)
 func (r *UserRepository) GetAll(limit, offset int) ([]domain.User, error) {
 	var users []domain.User
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type CategoryService struct {
+	categoryRepo *repository.CategoryRepository
+	events       *EventService
+}
+
+func NewCategoryService(categoryRepo *repository.CategoryRepository, events *EventService) *CategoryService {
+	return &CategoryService{categoryRepo: categoryRepo, events: events}
+}
+
+// GetCategoryTree returns all categories nested under their parents
+func (s *CategoryService) GetCategoryTree() ([]domain.Category, error) {
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories, nil), nil
+}
+
+// CreateCategory creates a new category (admin only)
+func (s *CategoryService) CreateCategory(req *domain.CreateCategoryRequest) (*domain.Category, error) {
+	category := &domain.Category{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	}
+	if req.ParentID != nil && *req.ParentID != "" {
+		if _, err := s.categoryRepo.GetByID(*req.ParentID); err != nil {
+			return nil, fmt.Errorf("parent category not found")
+		}
+		category.ParentID = req.ParentID
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory updates a category (admin only).
+// Moving a category under one of its own descendants is rejected to keep the tree acyclic.
+func (s *CategoryService) UpdateCategory(categoryID string, req *domain.UpdateCategoryRequest) (*domain.Category, error) {
+	category, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		category.Name = *req.Name
+	}
+	if req.Slug != nil {
+		category.Slug = *req.Slug
+	}
+	if req.Description != nil {
+		category.Description = *req.Description
+	}
+	if req.ParentID != nil {
+		if *req.ParentID == "" {
+			category.ParentID = nil
+		} else {
+			descendants, err := s.categoryRepo.GetDescendantIDs(categoryID)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range descendants {
+				if id == *req.ParentID {
+					return nil, fmt.Errorf("category cannot be moved under itself or its sub-categories")
+				}
+			}
+			if _, err := s.categoryRepo.GetByID(*req.ParentID); err != nil {
+				return nil, fmt.Errorf("parent category not found")
+			}
+			category.ParentID = req.ParentID
+		}
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes a category without sub-categories (admin only).
+// Its events lose their category, so their cached copies (and ETags) are dropped.
+func (s *CategoryService) DeleteCategory(categoryID string) error {
+	eventIDs, err := s.categoryRepo.Delete(categoryID)
+	if err != nil {
+		return err
+	}
+	for _, id := range eventIDs {
+		s.events.invalidateCache(id)
+	}
+	return nil
+}
+
+// buildCategoryTree nests categories under their parents, starting from parentID (nil = roots)
+func buildCategoryTree(categories []domain.Category, parentID *string) []domain.Category {
+	tree := []domain.Category{}
+	for _, category := range categories {
+		if (parentID == nil && category.ParentID == nil) ||
+			(parentID != nil && category.ParentID != nil && *category.ParentID == *parentID) {
+			category.Children = buildCategoryTree(categories, &category.ID)
+			tree = append(tree, category)
+		}
+	}
+	return tree
+}
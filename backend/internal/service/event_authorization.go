@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+)
+
+// authorizeEvent loads an event and checks that the user's team role allows the action.
+// Every operation restricted to an event's organizers goes through here; what describes
+// the operation in the error message, e.g. "manage ticket types".
+func authorizeEvent(eventRepo *repository.EventRepository, userID, eventID string, action domain.EventAction, what string) (*domain.Event, error) {
+	event, err := eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+	role, err := eventRole(eventRepo, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.EventRoleAllows(role, action) {
+		return nil, fmt.Errorf("only %s can %s", action.Holders(), what)
+	}
+	return event, nil
+}
+
+// eventRole returns the user's role on the event's team, or "" if they are not on it.
+// The organizer is always an owner, and so are the admins of the organization owning the event;
+// its other members are viewers unless the team gives them a role of their own.
+func eventRole(eventRepo *repository.EventRepository, event *domain.Event, userID string) (string, error) {
+	if userID == "" {
+		return "", nil
+	}
+	if event.OrganizerID == userID {
+		return domain.EventRoleOwner, nil
+	}
+	var orgRole string
+	if event.OrganizationID != nil {
+		var err error
+		if orgRole, err = eventRepo.GetOrganizationRole(*event.OrganizationID, userID); err != nil {
+			return "", err
+		}
+		if orgRole == domain.OrganizationRoleAdmin {
+			return domain.EventRoleOwner, nil
+		}
+	}
+	role, err := eventRepo.GetMemberRole(event.ID, userID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		role = domain.OrganizationEventRole(orgRole)
+	}
+	return role, nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
+	"github.com/google/uuid"
+)
+
+// UploadLimits caps the size of uploaded files, in bytes
+type UploadLimits struct {
+	MaxImageSize      int64 // Cover images
+	MaxAttachmentSize int64 // Attachments of any type
+}
+
+// EventFileService manages the files of events, their cover image and attachments, kept in a blob store.
+// Files are handed out through signed URLs that expire after urlTTL.
+type EventFileService struct {
+	fileRepo     *repository.EventFileRepository
+	eventRepo    *repository.EventRepository
+	eventService *EventService
+	store        storage.BlobStore
+	limits       UploadLimits
+	urlTTL       time.Duration
+}
+
+func NewEventFileService(fileRepo *repository.EventFileRepository, eventRepo *repository.EventRepository, eventService *EventService,
+	store storage.BlobStore, limits UploadLimits, urlTTL time.Duration) *EventFileService {
+	return &EventFileService{
+		fileRepo:     fileRepo,
+		eventRepo:    eventRepo,
+		eventService: eventService,
+		store:        store,
+		limits:       limits,
+		urlTTL:       urlTTL,
+	}
+}
+
+// MaxUploadSize returns the size of the largest file accepted
+func (s *EventFileService) MaxUploadSize() int64 {
+	return max(s.limits.MaxImageSize, s.limits.MaxAttachmentSize)
+}
+
+// GetFiles returns the cover image and attachments of an event, with signed URLs,
+// to anyone who may see the event
+func (s *EventFileService) GetFiles(eventID, viewerID, accessCode string) (*domain.EventFiles, error) {
+	if _, err := s.eventService.GetEventByID(eventID, viewerID, accessCode); err != nil {
+		return nil, err
+	}
+	files, err := s.fileRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.EventFiles{EventID: eventID, Attachments: []domain.EventFile{}}
+	for i := range files {
+		if err := s.sign(&files[i]); err != nil {
+			return nil, err
+		}
+		if files[i].Kind == domain.EventFileKindCover {
+			result.Cover = &files[i]
+		} else {
+			result.Attachments = append(result.Attachments, files[i])
+		}
+	}
+	return result, nil
+}
+
+// SetCover uploads the cover image of an event, replacing the previous one (organizers only)
+func (s *EventFileService) SetCover(userID, eventID string, upload *domain.FileUpload) (*domain.EventFile, error) {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	file, err := s.save(eventID, userID, domain.EventFileKindCover, upload, domain.CoverImageTypes, s.limits.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.fileRepo.ReplaceCover(file)
+	if err != nil {
+		s.deleteObjects(file)
+		return nil, err
+	}
+	if previous != nil {
+		s.deleteObjects(previous)
+	}
+	return file, s.sign(file)
+}
+
+// RemoveCover removes the cover image of an event (organizers only)
+func (s *EventFileService) RemoveCover(userID, eventID string) error {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return err
+	}
+	cover, err := s.fileRepo.GetCover(eventID)
+	if err != nil {
+		return err
+	}
+	return s.remove(cover)
+}
+
+// AddAttachment uploads an attachment, such as a PDF agenda or a map, to an event (organizers only)
+func (s *EventFileService) AddAttachment(userID, eventID string, upload *domain.FileUpload) (*domain.EventFile, error) {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	file, err := s.save(eventID, userID, domain.EventFileKindAttachment, upload, domain.AttachmentTypes, s.limits.MaxAttachmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fileRepo.Create(file); err != nil {
+		s.deleteObjects(file)
+		return nil, err
+	}
+	return file, s.sign(file)
+}
+
+// RemoveAttachment removes an attachment from an event (organizers only)
+func (s *EventFileService) RemoveAttachment(userID, eventID, fileID string) error {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return err
+	}
+	file, err := s.fileRepo.GetByID(eventID, fileID)
+	if err != nil {
+		return err
+	}
+	if file.Kind != domain.EventFileKindAttachment {
+		return fmt.Errorf("file not found")
+	}
+	return s.remove(file)
+}
+
+// save validates an upload and writes it, and the thumbnail of images, to the blob store.
+// The content type is sniffed from the content: the client's claims are not trusted.
+func (s *EventFileService) save(eventID, userID, kind string, upload *domain.FileUpload, allowed []string, maxSize int64) (*domain.EventFile, error) {
+	if upload.Size > maxSize {
+		return nil, tooLarge(maxSize)
+	}
+	data, err := io.ReadAll(io.LimitReader(upload.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, tooLarge(maxSize)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if !slices.Contains(allowed, contentType) {
+		return nil, domain.NewCodedError(domain.ErrUnsupportedFileType.Code,
+			fmt.Sprintf("files of type %s are not accepted here (accepted: %s)", contentType, strings.Join(allowed, ", ")))
+	}
+
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(upload.Name, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		name = kind + domain.FileExtension(contentType)
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+
+	file := &domain.EventFile{
+		ID:          uuid.NewString(),
+		EventID:     eventID,
+		Kind:        kind,
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		UploadedBy:  &userID,
+	}
+	file.StorageKey = "events/" + eventID + "/" + file.ID + domain.FileExtension(contentType)
+
+	var thumbnail []byte
+	if file.IsImage() {
+		if thumbnail, err = storage.MakeThumbnail(bytes.NewReader(data), domain.ThumbnailSize); err != nil {
+			return nil, domain.NewCodedError(domain.ErrUnsupportedFileType.Code, "the image could not be read")
+		}
+		file.ThumbnailKey = "events/" + eventID + "/" + file.ID + "_thumb.jpg"
+	}
+
+	ctx := context.Background()
+	if err := s.store.Put(ctx, file.StorageKey, bytes.NewReader(data), file.Size, contentType); err != nil {
+		return nil, err
+	}
+	if thumbnail != nil {
+		if err := s.store.Put(ctx, file.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), storage.ThumbnailContentType); err != nil {
+			s.deleteObjects(file)
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// remove deletes a file record, then its objects
+func (s *EventFileService) remove(file *domain.EventFile) error {
+	if _, err := s.fileRepo.Delete(file.EventID, file.ID); err != nil {
+		return err
+	}
+	s.deleteObjects(file)
+	return nil
+}
+
+// deleteObjects removes the objects of a file from the blob store. Failures only leave
+// unreferenced objects behind, so they are logged rather than failing the request.
+func (s *EventFileService) deleteObjects(file *domain.EventFile) {
+	for _, key := range []string{file.StorageKey, file.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.store.Delete(context.Background(), key); err != nil {
+			log.Printf("failed to delete object %s: %v", key, err)
+		}
+	}
+}
+
+// sign fills in the signed URLs of a file
+func (s *EventFileService) sign(file *domain.EventFile) error {
+	expires := time.Now().Add(s.urlTTL).Truncate(time.Second)
+	var err error
+	if file.URL, err = s.store.SignedURL(file.StorageKey, expires); err != nil {
+		return err
+	}
+	if file.ThumbnailKey != "" {
+		if file.ThumbnailURL, err = s.store.SignedURL(file.ThumbnailKey, expires); err != nil {
+			return err
+		}
+	}
+	file.URLExpiresAt = &expires
+	return nil
+}
+
+func (s *EventFileService) getManagedEvent(userID, eventID string) (*domain.Event, error) {
+	return authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "manage the event's files")
+}
+
+func tooLarge(maxSize int64) error {
+	limit := fmt.Sprintf("%d KB", maxSize>>10)
+	if maxSize >= 1<<20 {
+		limit = fmt.Sprintf("%d MB", maxSize>>20)
+	}
+	return domain.NewCodedError(domain.ErrFileTooLarge.Code, "the file is too large (at most "+limit+")")
+}
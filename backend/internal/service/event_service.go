@@ -4,11 +4,14 @@ import (
 	"context"
 
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/cache"
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
 	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
 )
 
 type EventService struct {
@@ -31,36 +34,137 @@ func NewEventService(eventRepo *repository.EventRepository, cache *cache.RedisCa
 
 // create event
 func (s *EventService) CreateEvent(userID string, req *domain.CreateEventRequest) (*domain.Event, error) {
+	event, tags, err := s.prepareEvent(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := newRevision(event.ID, userID, domain.EventRevisionCreated, nil, event)
+	if err := s.eventRepo.CreateWithTags(event, tags, revision); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	event.RegistrationState = event.ComputeRegistrationState(0, time.Now())
+	return event, nil
+}
+
+// ValidateEvent runs every check CreateEvent would on the request without creating the event
+func (s *EventService) ValidateEvent(userID string, req *domain.CreateEventRequest) error {
+	_, _, err := s.prepareEvent(userID, req)
+	return err
+}
+
+// prepareEvent builds and validates the event a create request describes, returning it with its normalized tags
+func (s *EventService) prepareEvent(userID string, req *domain.CreateEventRequest) (*domain.Event, []string, error) {
 	event := &domain.Event{
-		OrganizerID:   userID,
-		Title:         req.Title,
-		Description:   req.Description,
-		Location:      req.Location,
-		StartDatetime: req.StartDatetime,
-		EndDatetime:   req.EndDatetime,
-		Capacity:      req.Capacity,
-		Status:        "draft",
+		ID:               uuid.NewString(),
+		OrganizerID:      userID,
+		Title:            req.Title,
+		Description:      req.Description,
+		Location:         req.Location,
+		StartDatetime:    req.StartDatetime,
+		EndDatetime:      req.EndDatetime,
+		Capacity:         req.Capacity,
+		Status:           domain.EventStatusDraft,
+		RequiresApproval: req.RequiresApproval,
+		AllowTransfers:   req.AllowTransfers == nil || *req.AllowTransfers,
+
+		RegistrationOpensAt:  req.RegistrationOpensAt,
+		RegistrationClosesAt: req.RegistrationClosesAt,
+
+		Visibility: req.Visibility,
+		Slug:       domain.NewEventSlug(req.Title),
+		AccessCode: strings.TrimSpace(req.AccessCode),
+		PublishAt:  req.PublishAt,
+		Version:    1,
 	}
 
 	if err := event.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := event.CheckPublishAt(time.Now()); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if req.OrganizationID != nil && *req.OrganizationID != "" {
+		role, err := s.eventRepo.GetOrganizationRole(*req.OrganizationID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if role == "" {
+			return nil, nil, fmt.Errorf("only members of the organization can create events for it")
+		}
+		event.OrganizationID = req.OrganizationID
+	}
+
+	if req.CategoryID != nil && *req.CategoryID != "" {
+		if err := s.checkCategory(*req.CategoryID); err != nil {
+			return nil, nil, err
+		}
+		event.CategoryID = req.CategoryID
+	}
+
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+	event.Tags = tagsNamed(tags)
+	return event, tags, nil
+}
+
+// CloneEvent copies an event and its configuration into a new draft starting at req.StartDatetime.
+// Anyone who manages the source may clone it and becomes the organizer of the copy; the copy stays
+// with the source's organization only when they belong to it. Registrations are never copied.
+func (s *EventService) CloneEvent(userID string, eventID string, req *domain.CloneEventRequest) (*domain.Event, error) {
+	source, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "clone the event")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	clone := source.Clone(userID, req.Title, req.StartDatetime)
+	clone.ID = uuid.NewString()
+	if err := clone.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	if err := s.eventRepo.Create(event); err != nil {
-		return nil, fmt.Errorf("failed to create event: %w", err)
+	if clone.OrganizationID != nil {
+		role, err := s.eventRepo.GetOrganizationRole(*clone.OrganizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if role == "" {
+			clone.OrganizationID = nil
+		}
 	}
 
-	return event, nil
+	revision := newRevision(clone.ID, userID, domain.EventRevisionCreated, nil, clone)
+	if err := s.eventRepo.Clone(source, clone, revision); err != nil {
+		return nil, fmt.Errorf("failed to clone event: %w", err)
+	}
+
+	clone.RegistrationState = clone.ComputeRegistrationState(0, time.Now())
+	return clone, nil
 }
 
 // update event
 func (s *EventService) UpdateEvent(userID string, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error) {
+	return s.UpdateEventIfMatch(userID, eventID, 0, req)
+}
 
-	// fetch existing event
-	event, err := s.eventRepo.GetByID(eventID)
+// UpdateEventIfMatch updates an event only while it is still at the version the changes were made on
+// (0 accepts any version); otherwise it fails with domain.ErrEventVersionMismatch. Changes made
+// concurrently by someone else are never overwritten, whatever the version passed.
+func (s *EventService) UpdateEventIfMatch(userID string, eventID string, version int64, req *domain.UpdateEventRequest) (*domain.Event, error) {
+
+	// fetch existing event; owners and co-organizers may edit it
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "edit the event")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
+	if version != 0 && event.Version != version {
+		return nil, domain.ErrEventVersionMismatch
+	}
+	before := *event
 
 	// update fields if provided in request (only non-nil values)
 	updated := false
@@ -95,63 +199,397 @@ func (s *EventService) UpdateEvent(userID string, eventID string, req *domain.Up
 		updated = true
 	}
 
+	// Pending applications stay pending when the flag is turned off; the organizer still reviews them
+	if req.RequiresApproval != nil {
+		event.RequiresApproval = *req.RequiresApproval
+		updated = true
+	}
+
+	// Transfers already pending can no longer be accepted once they are disabled
+	if req.AllowTransfers != nil {
+		event.AllowTransfers = *req.AllowTransfers
+		updated = true
+	}
+
+	if req.RegistrationOpensAt != nil {
+		event.RegistrationOpensAt = req.RegistrationOpensAt
+		updated = true
+	}
+
+	if req.RegistrationClosesAt != nil {
+		event.RegistrationClosesAt = req.RegistrationClosesAt
+		updated = true
+	}
+
+	if req.Visibility != nil {
+		event.Visibility = *req.Visibility
+		updated = true
+	}
+
+	if req.AccessCode != nil {
+		event.AccessCode = strings.TrimSpace(*req.AccessCode)
+		updated = true
+	}
+
+	if req.CategoryID != nil {
+		if *req.CategoryID == "" {
+			event.CategoryID = nil
+		} else {
+			if err := s.checkCategory(*req.CategoryID); err != nil {
+				return nil, err
+			}
+			event.CategoryID = req.CategoryID
+		}
+		event.Category = nil
+		updated = true
+	}
+
+	var tags []string
+	if req.Tags != nil {
+		if tags, err = domain.NormalizeTags(*req.Tags); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
 	// If no fields were updated, return the existing event
-	if !updated {
-		return event, nil
+	if !updated && req.Tags == nil {
+		return event, s.setRegistrationState(event)
 	}
 
-	// Validate the updated event
-	if err := event.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	if req.Tags != nil {
+		event.Tags = tagsNamed(tags)
 	}
+	if err := s.saveChanges(userID, &before, event, req.Tags != nil, domain.EventRevisionUpdated, nil); err != nil {
+		return nil, err
+	}
+	return event, s.setRegistrationState(event)
+}
 
-	// Save the updated event
-	if err := s.eventRepo.Update(userID, event); err != nil {
-		return nil, fmt.Errorf("failed to update event: %w", err)
+// RevertEvent brings the details of an event back to an earlier version, as a new update recorded in its history:
+// the changes made since are undone field by field. The status, publishing schedule and access code are left as
+// they are (organizers only).
+func (s *EventService) RevertEvent(userID string, eventID string, version int64) (*domain.Event, error) {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "revert the event")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if version >= event.Version {
+		return nil, fmt.Errorf("version %d is not an earlier version of the event (now at %d)", version, event.Version)
 	}
 
-	// Invalidate cache
-	if s.cache != nil {
-		if err := s.cache.Delete(context.Background(), fmt.Sprintf("event:%s", eventID)); err != nil {
-			fmt.Printf("failed to invalidate cache for event %s: %v\n", eventID, err)
+	revisions, err := s.eventRepo.GetRevisionsSince(eventID, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 || revisions[len(revisions)-1].Version != version {
+		return nil, fmt.Errorf("version %d is not in the event's history", version)
+	}
+
+	// undo the later changes, newest first
+	values := domain.EventFieldValues(event)
+	for _, revision := range revisions {
+		if revision.Version == version {
+			break
+		}
+		for _, change := range revision.Changes {
+			values[change.Field] = change.Old
 		}
 	}
 
-	return event, nil
+	before := *event
+	if err := event.RestoreFields(values); err != nil {
+		return nil, err
+	}
+	if event.CategoryID != nil && (before.CategoryID == nil || *event.CategoryID != *before.CategoryID) {
+		if err := s.checkCategory(*event.CategoryID); err != nil {
+			return nil, fmt.Errorf("cannot revert to version %d: %w", version, err)
+		}
+	}
+	if err := s.saveChanges(userID, &before, event, true, domain.EventRevisionReverted, &version); err != nil {
+		return nil, err
+	}
+	return event, s.setRegistrationState(event)
+}
+
+// saveChanges validates and saves the changes made to an event since before, recording them in its history.
+// Events left as they were are not saved, so the version only moves on real changes.
+func (s *EventService) saveChanges(userID string, before, event *domain.Event, replaceTags bool, action string, revertedTo *int64) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	revision := newRevision(event.ID, userID, action, before, event)
+	if len(revision.Changes) == 0 {
+		event.Tags = before.Tags
+		return nil
+	}
+	revision.RevertedTo = revertedTo
+
+	var tags []string // nil keeps the tags as they are
+	if replaceTags {
+		tags = make([]string, len(event.Tags))
+		for i, tag := range event.Tags {
+			tags[i] = tag.Name
+		}
+	}
+	if err := s.eventRepo.Update(event, tags, revision); err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+
+	s.invalidateCache(event.ID)
+	return nil
 }
 
-// publish event
+// publish event (a draft, or a postponed event with its new dates)
 func (s *EventService) PublishEvent(userID string, eventID string) error {
-	if err := s.eventRepo.UpdateStatus(userID, eventID, "published"); err != nil {
+	if err := s.changeStatus(userID, eventID, domain.EventStatusPublished, "", "publish the event"); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
-	
-	// Invalidate cache
-	if err := s.cache.Delete(context.Background(), fmt.Sprintf("event:%s", eventID)); err != nil {
-		fmt.Printf("failed to invalidate cache for event %s: %v\n", eventID, err)
+	return nil
+}
+
+// postpone event: registrations are kept until it is published again with new dates
+func (s *EventService) PostponeEvent(userID string, eventID string, reason string) error {
+	if err := s.changeStatus(userID, eventID, domain.EventStatusPostponed, reason, "postpone the event"); err != nil {
+		return fmt.Errorf("failed to postpone event: %w", err)
+	}
+	return nil
+}
+
+// SchedulePublish sets the time a draft event goes live (see PublishScheduler); scheduling again moves the time
+func (s *EventService) SchedulePublish(userID string, eventID string, publishAt time.Time) (*domain.Event, error) {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "schedule publishing")
+	if err != nil {
+		return nil, err
+	}
+	before := *event
+	event.PublishAt = &publishAt
+	if err := event.CheckPublishAt(time.Now()); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	revision := newRevision(eventID, userID, domain.EventRevisionScheduled, &before, event)
+	if err := s.eventRepo.SetPublishAt(eventID, &publishAt, revision); err != nil {
+		return nil, err
+	}
+	event.Version = revision.Version
+	s.invalidateCache(eventID)
+	return event, s.setRegistrationState(event)
+}
+
+// CancelScheduledPublish removes the publishing schedule of a draft event; it stays a draft
+func (s *EventService) CancelScheduledPublish(userID string, eventID string) error {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "schedule publishing")
+	if err != nil {
+		return err
+	}
+	if event.PublishAt == nil {
+		return fmt.Errorf("event is not scheduled for publishing")
+	}
+
+	after := *event
+	after.PublishAt = nil
+	if err := s.eventRepo.SetPublishAt(eventID, nil, newRevision(eventID, userID, domain.EventRevisionScheduled, event, &after)); err != nil {
+		return err
+	}
+	s.invalidateCache(eventID)
+	return nil
+}
+
+// TransferOwnership hands an event over to a new organizer and/or organization on behalf of a user
+// who may transfer it (see MemberService.TransferOwnership). The transfer is recorded in the event history.
+func (s *EventService) TransferOwnership(userID string, event *domain.Event, organizerID string, organizationID *string) error {
+	after := *event
+	after.OrganizerID, after.OrganizationID = organizerID, organizationID
+	if err := s.eventRepo.TransferOwnership(event.ID, organizerID, organizationID,
+		newRevision(event.ID, userID, domain.EventRevisionTransferred, event, &after)); err != nil {
+		return err
+	}
+	s.invalidateCache(event.ID)
+	return nil
+}
+
+// ClaimDuePublications leases the drafts due for publishing to this replica (see PublishScheduler).
+// Leases aren't part of the event as clients see it, so the cache is left alone.
+func (s *EventService) ClaimDuePublications(lease time.Duration, limit int) ([]domain.Event, error) {
+	return s.eventRepo.ClaimDuePublications(time.Now(), lease, limit)
+}
+
+// PublishScheduled publishes a draft claimed with ClaimDuePublications on behalf of its organizer.
+// It returns false without changing anything when the draft is no longer due or no longer leased to the
+// caller: its schedule was changed or removed meanwhile, or its lease expired and another replica took over.
+// Drafts that can't be published at all fail with domain.ErrInvalidStatusTransition.
+func (s *EventService) PublishScheduled(claimed *domain.Event) (bool, error) {
+	if claimed.PublishClaimedUntil == nil {
+		return false, nil
 	}
+	event, err := s.eventRepo.GetByID(claimed.ID)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if event.PublishAt == nil || event.PublishAt.After(now) {
+		return false, nil
+	}
+	if err := event.CheckTransition(domain.EventStatusPublished, now); err != nil {
+		return false, err
+	}
+
+	after := *event
+	after.Status, after.PublishAt = domain.EventStatusPublished, nil
+	published, err := s.eventRepo.PublishScheduled(&domain.EventStatusChange{
+		ID:         uuid.NewString(),
+		EventID:    event.ID,
+		FromStatus: event.Status,
+		ToStatus:   domain.EventStatusPublished,
+		ChangedBy:  &event.OrganizerID,
+	}, newRevision(event.ID, event.OrganizerID, domain.EventStatusPublished, event, &after), now, *claimed.PublishClaimedUntil)
+	if err != nil || !published {
+		return false, err
+	}
+	s.invalidateCache(event.ID)
+	return true, nil
+}
+
+// ReleasePublication gives up the lease on a claimed draft that was not published this time, so it is retried
+func (s *EventService) ReleasePublication(claimed *domain.Event) error {
+	if claimed.PublishClaimedUntil == nil {
+		return nil
+	}
+	return s.eventRepo.ReleasePublication(claimed.ID, *claimed.PublishClaimedUntil)
+}
+
+// DropScheduledPublish removes the schedule of a draft that can never be published as scheduled.
+// The change is recorded in the event history as an automatic one.
+func (s *EventService) DropScheduledPublish(event *domain.Event) error {
+	after := *event
+	after.PublishAt = nil
+	revision := &domain.EventRevision{
+		EventID: event.ID,
+		Action:  domain.EventRevisionScheduled,
+		Changes: domain.DiffEvents(event, &after),
+	}
+	if err := s.eventRepo.SetPublishAt(event.ID, nil, revision); err != nil {
+		return err
+	}
+	s.invalidateCache(event.ID)
+	return nil
+}
 
+// complete event ahead of the automatic completion once it has ended
+func (s *EventService) CompleteEvent(userID string, eventID string) error {
+	if err := s.changeStatus(userID, eventID, domain.EventStatusCompleted, "", "complete the event"); err != nil {
+		return fmt.Errorf("failed to complete event: %w", err)
+	}
 	return nil
 }
 
 // cancel event
 func (s *EventService) Cancel(userID string, eventID string) error {
-	if err := s.eventRepo.UpdateStatus(userID, eventID, "cancelled"); err != nil {
+	if err := s.changeStatus(userID, eventID, domain.EventStatusCancelled, "", "cancel the event"); err != nil {
 		return fmt.Errorf("failed to cancel event: %w", err)
 	}
 	return nil
 }
 
-// get event by id
-func (s *EventService) GetEventByID(eventID string) (*domain.Event, error) {
+// changeStatus moves an event along its lifecycle (organizers only). Illegal moves fail with
+// domain.ErrInvalidStatusTransition; every change is recorded in the status history.
+func (s *EventService) changeStatus(userID, eventID, status, reason, what string) error {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, what)
+	if err != nil {
+		return err
+	}
+	if err := event.CheckTransition(status, time.Now()); err != nil {
+		return err
+	}
+
+	after := *event
+	after.Status, after.PublishAt = status, nil
+	if err := s.eventRepo.TransitionStatus(&domain.EventStatusChange{
+		ID:         uuid.NewString(),
+		EventID:    eventID,
+		FromStatus: event.Status,
+		ToStatus:   status,
+		ChangedBy:  &userID,
+		Reason:     strings.TrimSpace(reason),
+	}, newRevision(eventID, userID, status, event, &after)); err != nil {
+		return err
+	}
+
+	s.invalidateCache(eventID)
+	return nil
+}
+
+// CompleteEndedEvents marks published events that have ended as completed (run periodically).
+// Returns the number of completed events.
+func (s *EventService) CompleteEndedEvents(ctx context.Context) (int, error) {
+	ids, err := s.eventRepo.CompleteEnded(time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete ended events: %w", err)
+	}
+	for _, id := range ids {
+		s.invalidateCache(id)
+	}
+	return len(ids), nil
+}
+
+// GetStatusHistory returns the status changes of an event (event team only)
+func (s *EventService) GetStatusHistory(userID, eventID string) ([]domain.EventStatusChange, error) {
+	if _, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionView, "view the status history"); err != nil {
+		return nil, err
+	}
+	return s.eventRepo.GetStatusHistory(eventID)
+}
+
+// GetHistory returns the changes made to an event, oldest first (event team only)
+func (s *EventService) GetHistory(userID, eventID string) ([]domain.EventRevision, error) {
+	if _, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionView, "view the history"); err != nil {
+		return nil, err
+	}
+	return s.eventRepo.GetRevisions(eventID)
+}
+
+// newRevision describes a change of an event made by a user, from before (nil for a new event) to after
+func newRevision(eventID, userID, action string, before, after *domain.Event) *domain.EventRevision {
+	return &domain.EventRevision{
+		EventID: eventID,
+		Action:  action,
+		ActorID: &userID,
+		Changes: domain.DiffEvents(before, after),
+	}
+}
+
+// tagsNamed returns unsaved tags with the given names, standing for them until they are resolved
+func tagsNamed(names []string) []domain.Tag {
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+	return tags
+}
+
+// invalidateCache drops the cached copy of an event (ignore error)
+func (s *EventService) invalidateCache(eventID string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(context.Background(), fmt.Sprintf("event:%s", eventID)); err != nil {
+		fmt.Printf("failed to invalidate cache for event %s: %v\n", eventID, err)
+	}
+}
+
+// GetEventByID returns an event if the viewer may see it. Public events are open to everyone;
+// unlisted events only to their organizer, team and organization (others use the share link, see GetEventBySlug);
+// private events to their organizer, team and organization, invitees, registrants and holders of the access code.
+// Events the viewer may not see are reported as not found.
+func (s *EventService) GetEventByID(eventID, viewerID, accessCode string) (*domain.Event, error) {
 	ctx := context.Background()
 	cacheKey := fmt.Sprintf("event:%s", eventID)
 
-	// 1. Try Cache
+	// 1. Try Cache (public events only: access checks of the others need the database)
 	var params domain.Event
 	if s.cache != nil {
-		if err := s.cache.Get(ctx, cacheKey, &params); err == nil {
-			return &params, nil
+		if err := s.cache.Get(ctx, cacheKey, &params); err == nil && params.Visibility == domain.EventVisibilityPublic {
+			return &params, s.setRegistrationState(&params)
 		}
 	}
 
@@ -160,24 +598,70 @@ func (s *EventService) GetEventByID(eventID string) (*domain.Event, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
+	if err := s.checkAccess(event, viewerID, accessCode, false); err != nil {
+		return nil, err
+	}
 
 	// 3. Set Cache (ignore error)
-	if s.cache != nil {
+	if s.cache != nil && event.Visibility == domain.EventVisibilityPublic {
 		if err := s.cache.Set(ctx, cacheKey, event, 10*time.Minute); err != nil {
 			fmt.Printf("failed to set cache for event %s: %v\n", eventID, err)
 		}
 	}
 
-	return event, nil
+	// The state depends on the time and seats taken, so it is never served from the cache
+	return event, s.setRegistrationState(event)
 }
 
-// get all events
-func (s *EventService) GetAllEvents() ([]domain.Event, error) {
-	events, err := s.eventRepo.GetAll()
+// GetEventBySlug returns an event through its share link. Public and unlisted events are
+// open to anyone with the link; private events still need an invitation or the access code.
+func (s *EventService) GetEventBySlug(slug, viewerID, accessCode string) (*domain.Event, error) {
+	event, err := s.eventRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if err := s.checkAccess(event, viewerID, accessCode, true); err != nil {
+		return nil, err
+	}
+	return event, s.setRegistrationState(event)
+}
+
+// checkAccess reports events the viewer may not see as not found, so their existence isn't revealed
+func (s *EventService) checkAccess(event *domain.Event, viewerID, accessCode string, viaShareLink bool) error {
+	if event.Visibility == domain.EventVisibilityPublic || (viaShareLink && event.Visibility == domain.EventVisibilityUnlisted) {
+		return nil
+	}
+	// the organizer, team and organization see everything
+	role, err := eventRole(s.eventRepo, event, viewerID)
+	if err != nil {
+		return err
+	}
+	if role != "" {
+		return nil
+	}
+	// private events also admit invitees, registrants and holders of the access code
+	if event.Visibility == domain.EventVisibilityPrivate {
+		if event.AccessCodeMatches(accessCode) {
+			return nil
+		}
+		allowed, err := s.eventRepo.HasPrivateAccess(event.ID, viewerID)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to get event: event not found")
+}
+
+// GetAllEvents returns the events listed to the viewer: public events, the viewer's own and those of their teams and organizations
+func (s *EventService) GetAllEvents(viewerID string) ([]domain.Event, error) {
+	events, err := s.eventRepo.GetAll(viewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
-	return events, nil
+	return events, s.setRegistrationState(eventPointers(events)...)
 }
 
 // GetEvents returns events with optional pagination and filters
@@ -200,12 +684,24 @@ func (s *EventService) GetEvents(req *domain.EventQueryRequest) (*domain.EventsR
 	if req.MinCapacity != nil && req.MaxCapacity != nil && *req.MaxCapacity < *req.MinCapacity {
 		return nil, fmt.Errorf("max_capacity must be greater than or equal to min_capacity")
 	}
+	if err := validateTagMode(req); err != nil {
+		return nil, err
+	}
+	if err := validateRegistrationState(req); err != nil {
+		return nil, err
+	}
+	if req.SortBy != "" && !slices.Contains(domain.EventSortFields, req.SortBy) {
+		return nil, fmt.Errorf("sort_by must be one of: %s", strings.Join(domain.EventSortFields, ", "))
+	}
 
 	// Get events
 	events, total, err := s.eventRepo.GetEvents(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
+	if err := s.setRegistrationState(eventPointers(events)...); err != nil {
+		return nil, err
+	}
 
 	// Build response
 	totalPages := int((total + int64(req.PageSize) - 1) / int64(req.PageSize))
@@ -224,7 +720,11 @@ func (s *EventService) GetEvents(req *domain.EventQueryRequest) (*domain.EventsR
 
 // delete event
 func (s *EventService) DeleteEvent(userID string, eventID string) error {
-	if err := s.eventRepo.Delete(userID, eventID); err != nil {
+	if _, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionDelete, "delete the event"); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	revision := &domain.EventRevision{EventID: eventID, Action: domain.EventRevisionDeleted, ActorID: &userID, Changes: []domain.FieldChange{}}
+	if err := s.eventRepo.Delete(eventID, revision); err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
 
@@ -237,3 +737,126 @@ func (s *EventService) DeleteEvent(userID string, eventID string) error {
 
 	return nil
 }
+
+// GetTrash returns the deleted events the user may restore, until they are restored or purged
+func (s *EventService) GetTrash(userID string) ([]domain.TrashedEvent, error) {
+	events, err := s.eventRepo.GetTrash(userID)
+	if err != nil {
+		return nil, err
+	}
+	trash := make([]domain.TrashedEvent, len(events))
+	for i, event := range events {
+		trash[i] = domain.TrashedEvent{Event: event, DeletedAt: event.DeletedAt.Time}
+	}
+	return trash, nil
+}
+
+// RestoreEvent takes a deleted event back out of the trash, as it was when deleted
+// (those who may delete the event only)
+func (s *EventService) RestoreEvent(userID string, eventID string) (*domain.Event, error) {
+	event, err := s.eventRepo.GetDeletedByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := eventRole(s.eventRepo, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.EventRoleAllows(role, domain.EventActionDelete) {
+		// like other events the user can't see, the ones they can't restore aren't revealed
+		return nil, fmt.Errorf("event not found in the trash")
+	}
+
+	revision := &domain.EventRevision{EventID: eventID, Action: domain.EventRevisionRestored, ActorID: &userID, Changes: []domain.FieldChange{}}
+	if err := s.eventRepo.Restore(eventID, revision); err != nil {
+		return nil, fmt.Errorf("failed to restore event: %w", err)
+	}
+	s.invalidateCache(eventID)
+
+	restored, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return restored, s.setRegistrationState(restored)
+}
+
+// GetEventFacets returns event counts per category, tag, status and month for the given filters
+func (s *EventService) GetEventFacets(req *domain.EventQueryRequest) (*domain.EventFacets, error) {
+	if req.StartDateFrom != nil && req.StartDateTo != nil && req.StartDateTo.Before(*req.StartDateFrom) {
+		return nil, fmt.Errorf("start_date_to must be after start_date_from")
+	}
+	if err := validateTagMode(req); err != nil {
+		return nil, err
+	}
+	if err := validateRegistrationState(req); err != nil {
+		return nil, err
+	}
+
+	facets, err := s.eventRepo.GetFacets(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event facets: %w", err)
+	}
+	return facets, nil
+}
+
+// checkCategory verifies that a category referenced by an event exists
+func (s *EventService) checkCategory(categoryID string) error {
+	if _, err := uuid.Parse(categoryID); err != nil {
+		return fmt.Errorf("category not found")
+	}
+	exists, err := s.eventRepo.CategoryExists(categoryID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("category not found")
+	}
+	return nil
+}
+
+// validateTagMode checks the tag_mode query parameter and applies the default
+func validateTagMode(req *domain.EventQueryRequest) error {
+	switch req.TagMode {
+	case "":
+		req.TagMode = domain.TagModeAny
+	case domain.TagModeAny, domain.TagModeAll:
+	default:
+		return fmt.Errorf("tag_mode must be one of: any, all")
+	}
+	return nil
+}
+
+// validateRegistrationState checks the registration_state query parameter
+func validateRegistrationState(req *domain.EventQueryRequest) error {
+	if req.RegistrationState != "" && !slices.Contains(domain.RegistrationStates, req.RegistrationState) {
+		return fmt.Errorf("registration_state must be one of: %s", strings.Join(domain.RegistrationStates, ", "))
+	}
+	return nil
+}
+
+// setRegistrationState fills in the computed registration state of events
+func (s *EventService) setRegistrationState(events ...*domain.Event) error {
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	seatsTaken, err := s.eventRepo.CountSeatsTaken(ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		event.RegistrationState = event.ComputeRegistrationState(seatsTaken[event.ID], now)
+	}
+	return nil
+}
+
+// eventPointers returns pointers to the events of a slice, so they can be updated in place
+func eventPointers(events []domain.Event) []*domain.Event {
+	pointers := make([]*domain.Event, len(events))
+	for i := range events {
+		pointers[i] = &events[i]
+	}
+	return pointers
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// EventTemplateService manages the saved event templates of organizers.
+// Events created from a template go through EventService.CreateEvent, so the category and
+// organization of a template are checked when it is used.
+type EventTemplateService struct {
+	templateRepo *repository.EventTemplateRepository
+	eventService *EventService
+}
+
+func NewEventTemplateService(templateRepo *repository.EventTemplateRepository, eventService *EventService) *EventTemplateService {
+	return &EventTemplateService{
+		templateRepo: templateRepo,
+		eventService: eventService,
+	}
+}
+
+// CreateTemplate saves a new event template for the user
+func (s *EventTemplateService) CreateTemplate(userID string, req *domain.CreateEventTemplateRequest) (*domain.EventTemplate, error) {
+	template := &domain.EventTemplate{
+		ID:               uuid.NewString(),
+		OrganizerID:      userID,
+		Name:             req.Name,
+		Title:            req.Title,
+		Description:      req.Description,
+		Location:         req.Location,
+		Capacity:         req.Capacity,
+		DurationMinutes:  req.DurationMinutes,
+		CategoryID:       emptyToNil(req.CategoryID),
+		Tags:             req.Tags,
+		RequiresApproval: req.RequiresApproval,
+		AllowTransfers:   req.AllowTransfers == nil || *req.AllowTransfers,
+		Visibility:       req.Visibility,
+		OrganizationID:   emptyToNil(req.OrganizationID),
+	}
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetTemplates returns the user's event templates
+func (s *EventTemplateService) GetTemplates(userID string) ([]domain.EventTemplate, error) {
+	return s.templateRepo.GetByOrganizer(userID)
+}
+
+// GetTemplate returns one of the user's event templates
+func (s *EventTemplateService) GetTemplate(userID, templateID string) (*domain.EventTemplate, error) {
+	return s.templateRepo.GetByID(userID, templateID)
+}
+
+// UpdateTemplate changes one of the user's event templates
+func (s *EventTemplateService) UpdateTemplate(userID, templateID string, req *domain.UpdateEventTemplateRequest) (*domain.EventTemplate, error) {
+	template, err := s.templateRepo.GetByID(userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Title != nil {
+		template.Title = *req.Title
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Location != nil {
+		template.Location = *req.Location
+	}
+	if req.Capacity != nil {
+		template.Capacity = *req.Capacity
+	}
+	if req.DurationMinutes != nil {
+		template.DurationMinutes = *req.DurationMinutes
+	}
+	if req.CategoryID != nil {
+		template.CategoryID = emptyToNil(req.CategoryID)
+	}
+	if req.Tags != nil {
+		template.Tags = *req.Tags
+	}
+	if req.RequiresApproval != nil {
+		template.RequiresApproval = *req.RequiresApproval
+	}
+	if req.AllowTransfers != nil {
+		template.AllowTransfers = *req.AllowTransfers
+	}
+	if req.Visibility != nil {
+		template.Visibility = *req.Visibility
+	}
+	if req.OrganizationID != nil {
+		template.OrganizationID = emptyToNil(req.OrganizationID)
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes one of the user's event templates; events created from it are not affected
+func (s *EventTemplateService) DeleteTemplate(userID, templateID string) error {
+	return s.templateRepo.Delete(userID, templateID)
+}
+
+// CreateEvent creates a draft event from one of the user's templates, starting at req.StartDatetime
+func (s *EventTemplateService) CreateEvent(userID, templateID string, req *domain.CreateEventFromTemplateRequest) (*domain.Event, error) {
+	template, err := s.templateRepo.GetByID(userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventReq := template.EventRequest(req.StartDatetime)
+	if req.Title != "" {
+		eventReq.Title = req.Title
+	}
+	return s.eventService.CreateEvent(userID, eventReq)
+}
+
+// emptyToNil treats an empty optional ID as unset
+func emptyToNil(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	return id
+}
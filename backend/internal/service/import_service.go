@@ -0,0 +1,369 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// importProgressInterval is how many rows an import processes between progress updates of its job
+const importProgressInterval = 25
+
+// ImportService imports events and registrants from CSV files. Every row goes through the same
+// checks as the API: events are created by EventService.CreateEvent (so Event.Validate applies and
+// the creation is recorded in their history), registrations through the atomic capacity check.
+type ImportService struct {
+	jobRepo       *repository.ImportJobRepository
+	events        *EventService
+	eventRepo     *repository.EventRepository
+	regRepo       *repository.RegistrationRepository
+	ticketRepo    *repository.TicketTypeRepository
+	userRepo      *repository.UserRepository
+	notifications *NotificationService // optional
+	running       sync.WaitGroup
+}
+
+func NewImportService(jobRepo *repository.ImportJobRepository, events *EventService, eventRepo *repository.EventRepository, regRepo *repository.RegistrationRepository, ticketRepo *repository.TicketTypeRepository, userRepo *repository.UserRepository, notifications *NotificationService) *ImportService {
+	return &ImportService{
+		jobRepo:       jobRepo,
+		events:        events,
+		eventRepo:     eventRepo,
+		regRepo:       regRepo,
+		ticketRepo:    ticketRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+	}
+}
+
+// importStep imports one valid row, returning the ID of what it created
+type importStep struct {
+	row int
+	run func() (string, error)
+}
+
+// ValidateEvents runs the validation pass of an event import without creating anything (dry run)
+func (s *ImportService) ValidateEvents(userID string, file io.Reader) (*domain.ImportReport, error) {
+	rows, err := domain.ParseEventImport(file)
+	if err != nil {
+		return nil, err
+	}
+	report, _ := s.planEvents(userID, rows)
+	return report, nil
+}
+
+// StartEventImport accepts an event import and processes it in the background. The events are
+// created as drafts organized by the user.
+func (s *ImportService) StartEventImport(userID string, file io.Reader) (*domain.ImportJob, error) {
+	rows, err := domain.ParseEventImport(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the file has no rows to import")
+	}
+
+	job := newImportJob(domain.ImportKindEvents, nil, userID, len(rows))
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+	accepted := *job
+	s.run(job, func() (*domain.ImportReport, []importStep, error) {
+		report, steps := s.planEvents(userID, rows)
+		return report, steps, nil
+	})
+	return &accepted, nil
+}
+
+// planEvents validates the rows of an event import and returns the steps creating the events
+func (s *ImportService) planEvents(userID string, rows []domain.EventImportRow) (*domain.ImportReport, []importStep) {
+	report := newImportReport(len(rows))
+	var steps []importStep
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = s.events.ValidateEvent(userID, row.Request)
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, domain.ImportRowError{Row: row.Row, Message: err.Error()})
+			continue
+		}
+
+		req := row.Request
+		steps = append(steps, importStep{row: row.Row, run: func() (string, error) {
+			event, err := s.events.CreateEvent(userID, req)
+			if err != nil {
+				return "", err
+			}
+			return event.ID, nil
+		}})
+	}
+	report.ValidRows = len(steps)
+	return report, steps
+}
+
+// ValidateRegistrants runs the validation pass of a registrant import without registering anyone (dry run)
+func (s *ImportService) ValidateRegistrants(userID, eventID string, file io.Reader) (*domain.ImportReport, error) {
+	rows, err := domain.ParseRegistrantImport(file)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.importableEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	report, _, err := s.planRegistrants(event, rows)
+	return report, err
+}
+
+// StartRegistrantImport accepts a registrant import for an event (owners and co-organizers) and processes
+// it in the background. Each row registers an existing user, identified by email, with a confirmed seat:
+// the organizer's import is the approval, and paid ticket types are not charged.
+func (s *ImportService) StartRegistrantImport(userID, eventID string, file io.Reader) (*domain.ImportJob, error) {
+	rows, err := domain.ParseRegistrantImport(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the file has no rows to import")
+	}
+	if _, err := s.importableEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	job := newImportJob(domain.ImportKindRegistrants, &eventID, userID, len(rows))
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+	accepted := *job
+	s.run(job, func() (*domain.ImportReport, []importStep, error) {
+		// the event may have changed since the import was accepted
+		event, err := s.importableEvent(userID, eventID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.planRegistrants(event, rows)
+	})
+	return &accepted, nil
+}
+
+// importableEvent loads an event the user may import registrants into
+func (s *ImportService) importableEvent(userID, eventID string) (*domain.Event, error) {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "import registrants")
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == domain.EventStatusCancelled || event.Status == domain.EventStatusCompleted {
+		return nil, fmt.Errorf("registrants cannot be imported into a %s event", event.Status)
+	}
+	return event, nil
+}
+
+// planRegistrants validates the rows of a registrant import against the event as it is now, counting
+// the seats the earlier rows take, and returns the steps registering the attendees. Capacity and ticket
+// stock are checked again, atomically, when each registration is created.
+func (s *ImportService) planRegistrants(event *domain.Event, rows []domain.RegistrantImportRow) (*domain.ImportReport, []importStep, error) {
+	ticketTypes, err := s.ticketRepo.GetByEvent(event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	seatsTaken, err := s.regRepo.CountByEvent(event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var emails []string
+	for _, row := range rows {
+		if row.Err == nil {
+			emails = append(emails, row.Email)
+		}
+	}
+	found, err := s.userRepo.GetByEmails(emails)
+	if err != nil {
+		return nil, nil, err
+	}
+	users := make(map[string]*domain.User, len(found))
+	for i := range found {
+		users[strings.ToLower(found[i].Email)] = &found[i]
+	}
+
+	report := newImportReport(len(rows))
+	var steps []importStep
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		user, ticketType, err := s.checkRegistrant(event, row, users, seen, ticketTypes, &seatsTaken)
+		if err != nil {
+			report.Errors = append(report.Errors, domain.ImportRowError{Row: row.Row, Message: err.Error()})
+			continue
+		}
+		steps = append(steps, importStep{row: row.Row, run: func() (string, error) {
+			return s.register(event, user, ticketType)
+		}})
+	}
+	report.ValidRows = len(steps)
+	return report, steps, nil
+}
+
+// checkRegistrant validates one row of a registrant import, taking its seat from seatsTaken and the ticket type's stock
+func (s *ImportService) checkRegistrant(event *domain.Event, row domain.RegistrantImportRow, users map[string]*domain.User, seen map[string]int, ticketTypes []domain.TicketType, seatsTaken *int64) (*domain.User, *domain.TicketType, error) {
+	if row.Err != nil {
+		return nil, nil, row.Err
+	}
+	if earlier, ok := seen[row.Email]; ok {
+		return nil, nil, fmt.Errorf("%s is already on row %d", row.Email, earlier)
+	}
+	seen[row.Email] = row.Row
+
+	user, ok := users[row.Email]
+	if !ok {
+		return nil, nil, fmt.Errorf("no user has the email %s", row.Email)
+	}
+	existing, err := s.regRepo.GetByUserAndEvent(user.ID, event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil && slices.Contains(domain.ActiveRegistrationStatuses, existing.Status) {
+		return nil, nil, fmt.Errorf("%s is already registered (status: %s)", row.Email, existing.Status)
+	}
+
+	var ticketType *domain.TicketType
+	switch {
+	case row.TicketType != "":
+		for i := range ticketTypes {
+			if strings.EqualFold(ticketTypes[i].Name, row.TicketType) {
+				ticketType = &ticketTypes[i]
+			}
+		}
+		if ticketType == nil {
+			return nil, nil, fmt.Errorf("the event has no ticket type %q", row.TicketType)
+		}
+	case len(ticketTypes) == 1:
+		ticketType = &ticketTypes[0]
+	case len(ticketTypes) > 1:
+		return nil, nil, fmt.Errorf("ticket_type is required for events with multiple ticket types")
+	}
+
+	if *seatsTaken >= int64(event.Capacity) {
+		return nil, nil, fmt.Errorf("event is full (capacity reached)")
+	}
+	if ticketType != nil && ticketType.Sold >= int64(ticketType.Quantity) {
+		return nil, nil, fmt.Errorf("ticket type %q is sold out", ticketType.Name)
+	}
+	*seatsTaken++
+	if ticketType != nil {
+		ticketType.Sold++
+	}
+	return user, ticketType, nil
+}
+
+// register creates the confirmed registration of an imported attendee and lets them know
+func (s *ImportService) register(event *domain.Event, user *domain.User, ticketType *domain.TicketType) (string, error) {
+	registration := &domain.Registration{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		EventID:     event.ID,
+		Status:      domain.RegistrationStatusConfirmed,
+		TicketToken: domain.NewTicketToken(),
+	}
+	if ticketType != nil {
+		registration.TicketTypeID = &ticketType.ID
+	}
+	if err := s.regRepo.CreateWithCapacityCheck([]*domain.Registration{registration}, nil, nil); err != nil {
+		return "", err
+	}
+
+	s.notifications.Notify(user.ID, "You're registered", fmt.Sprintf("The organizer registered you for %q.", event.Title))
+	return registration.ID, nil
+}
+
+// GetJob returns an import job started by the user
+func (s *ImportService) GetJob(userID, jobID string) (*domain.ImportJob, error) {
+	return s.jobRepo.GetByID(userID, jobID)
+}
+
+// Wait blocks until the imports running in the background have finished
+func (s *ImportService) Wait() {
+	s.running.Wait()
+}
+
+// run processes an import job in the background, which owns the job from then on. The validation pass runs first and fails the job,
+// importing nothing, when any row is invalid; otherwise each row is imported in turn, and rows
+// failing at that point are recorded as errors without stopping the rest.
+func (s *ImportService) run(job *domain.ImportJob, plan func() (*domain.ImportReport, []importStep, error)) {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+
+		job.Status = domain.ImportStatusRunning
+		s.saveJob(job)
+
+		report, steps, err := plan()
+		switch {
+		case err != nil:
+			job.Error = err.Error()
+			s.finishJob(job, domain.ImportStatusFailed)
+			return
+		case len(report.Errors) > 0:
+			job.Errors = report.Errors
+			job.FailedRows = len(report.Errors)
+			s.finishJob(job, domain.ImportStatusFailed)
+			return
+		}
+
+		for _, step := range steps {
+			id, err := step.run()
+			if err != nil {
+				job.Errors = append(job.Errors, domain.ImportRowError{Row: step.row, Message: err.Error()})
+				job.FailedRows++
+			} else {
+				job.CreatedIDs = append(job.CreatedIDs, id)
+				job.ImportedRows++
+			}
+			job.ProcessedRows++
+			if job.ProcessedRows%importProgressInterval == 0 {
+				s.saveJob(job)
+			}
+		}
+		s.finishJob(job, domain.ImportStatusCompleted)
+	}()
+}
+
+// finishJob records the final status of an import job
+func (s *ImportService) finishJob(job *domain.ImportJob, status string) {
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+	s.saveJob(job)
+}
+
+// saveJob saves the progress of an import job. The job runs in the background with no one to
+// report to, so failures are logged.
+func (s *ImportService) saveJob(job *domain.ImportJob) {
+	if err := s.jobRepo.Update(job); err != nil {
+		log.Printf("failed to save import job %s: %v", job.ID, err)
+	}
+}
+
+func newImportJob(kind string, eventID *string, userID string, rows int) *domain.ImportJob {
+	return &domain.ImportJob{
+		ID:         uuid.NewString(),
+		Kind:       kind,
+		EventID:    eventID,
+		UserID:     userID,
+		Status:     domain.ImportStatusPending,
+		TotalRows:  rows,
+		Errors:     []domain.ImportRowError{},
+		CreatedIDs: []string{},
+	}
+}
+
+func newImportReport(rows int) *domain.ImportReport {
+	return &domain.ImportReport{TotalRows: rows, Errors: []domain.ImportRowError{}}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+)
+
+type InvitationService struct {
+	invitationRepo *repository.EventInvitationRepository
+	eventRepo      *repository.EventRepository
+	userRepo       *repository.UserRepository
+	notifications  *NotificationService
+}
+
+func NewInvitationService(invitationRepo *repository.EventInvitationRepository, eventRepo *repository.EventRepository, userRepo *repository.UserRepository, notifications *NotificationService) *InvitationService {
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		notifications:  notifications,
+	}
+}
+
+// InviteByEmail invites a list of email addresses to an event (organizer only). Invitations admit
+// their invitees to private events; invitees who already have an account are notified.
+// Addresses are compared case-insensitively and re-inviting an address is a no-op.
+func (s *InvitationService) InviteByEmail(organizerID, eventID string, req *domain.CreateInvitationsRequest) (*domain.BulkInviteResult, error) {
+	event, err := s.getOwnedEvent(organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(req.Emails))
+	seen := make(map[string]bool, len(req.Emails))
+	for _, email := range req.Emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("at least one email is required")
+	}
+	if len(emails) > domain.MaxInvitationsPerRequest {
+		return nil, fmt.Errorf("at most %d emails can be invited at once", domain.MaxInvitationsPerRequest)
+	}
+
+	invited, alreadyInvited, err := s.invitationRepo.CreateMany(event.ID, organizerID, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyInvitees(event, invited)
+	return &domain.BulkInviteResult{Invited: invited, AlreadyInvited: alreadyInvited}, nil
+}
+
+// GetInvitations returns who can get into an event: its invitations, access code and share link (organizer only)
+func (s *InvitationService) GetInvitations(organizerID, eventID string) (*domain.EventInvitations, error) {
+	event, err := s.getOwnedEvent(organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EventInvitations{
+		Visibility:  event.Visibility,
+		AccessCode:  event.AccessCode,
+		ShareSlug:   event.Slug,
+		Invitations: invitations,
+	}, nil
+}
+
+// RevokeInvitation removes an invitation (organizer only). Registrations already made stay valid.
+func (s *InvitationService) RevokeInvitation(organizerID, eventID, invitationID string) error {
+	if _, err := s.getOwnedEvent(organizerID, eventID); err != nil {
+		return err
+	}
+	return s.invitationRepo.Delete(eventID, invitationID)
+}
+
+// getOwnedEvent loads an event and checks that the user organizes it
+func (s *InvitationService) getOwnedEvent(organizerID, eventID string) (*domain.Event, error) {
+	return authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionManage, "manage invitations")
+}
+
+// notifyInvitees notifies the invitees that have an account. Delivery is best-effort.
+func (s *InvitationService) notifyInvitees(event *domain.Event, invitations []domain.EventInvitation) {
+	if s.notifications == nil || len(invitations) == 0 {
+		return
+	}
+	emails := make([]string, len(invitations))
+	for i, invitation := range invitations {
+		emails[i] = invitation.Email
+	}
+	users, err := s.userRepo.GetByEmails(emails)
+	if err != nil {
+		log.Printf("failed to look up invitees of event %s: %v", event.ID, err)
+		return
+	}
+	for _, user := range users {
+		s.notifications.Notify(user.ID, "Event invitation", fmt.Sprintf("You are invited to %q.", event.Title))
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type MemberService struct {
+	memberRepo    *repository.EventMemberRepository
+	eventRepo     *repository.EventRepository
+	events        *EventService
+	userRepo      *repository.UserRepository
+	notifications *NotificationService
+}
+
+func NewMemberService(memberRepo *repository.EventMemberRepository, eventRepo *repository.EventRepository, events *EventService, userRepo *repository.UserRepository, notifications *NotificationService) *MemberService {
+	return &MemberService{
+		memberRepo:    memberRepo,
+		eventRepo:     eventRepo,
+		events:        events,
+		userRepo:      userRepo,
+		notifications: notifications,
+	}
+}
+
+// AddMember adds the user with the given email address to an event's team (owners only).
+// Adding someone who is already on the team changes their role.
+func (s *MemberService) AddMember(ownerID, eventID string, req *domain.AddEventMemberRequest) (*domain.EventMember, error) {
+	event, err := authorizeEvent(s.eventRepo, ownerID, eventID, domain.EventActionManageMembers, "manage the event team")
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.GetByEmails([]string{strings.ToLower(strings.TrimSpace(req.Email))})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no user with this email address")
+	}
+	user := users[0]
+	if user.ID == event.OrganizerID {
+		return nil, fmt.Errorf("the event organizer is always an owner")
+	}
+
+	member := &domain.EventMember{
+		ID:      uuid.NewString(),
+		EventID: eventID,
+		UserID:  user.ID,
+		Role:    req.Role,
+		AddedBy: ownerID,
+	}
+	if err := s.memberRepo.Upsert(member); err != nil {
+		return nil, err
+	}
+
+	s.notifications.Notify(user.ID, "Event team", fmt.Sprintf("You were added to the team of %q as %s.", event.Title, strings.ReplaceAll(req.Role, "_", " ")))
+	return s.memberRepo.Get(eventID, user.ID)
+}
+
+// GetTeam returns the organizer and members of an event's team (event team only)
+func (s *MemberService) GetTeam(userID, eventID string) (*domain.EventTeam, error) {
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionView, "view the event team")
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.memberRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EventTeam{OrganizerID: event.OrganizerID, Members: members}, nil
+}
+
+// RemoveMember removes a user from an event's team (owners only). The organizer can't be removed.
+func (s *MemberService) RemoveMember(ownerID, eventID, userID string) error {
+	event, err := authorizeEvent(s.eventRepo, ownerID, eventID, domain.EventActionManageMembers, "manage the event team")
+	if err != nil {
+		return err
+	}
+	if userID == event.OrganizerID {
+		return fmt.Errorf("the event organizer cannot be removed from the team")
+	}
+	return s.memberRepo.Delete(eventID, userID)
+}
+
+// TransferOwnership hands an event over to another user or to an organization (owners only).
+// Handing it to a user makes them the organizer and takes the event out of its organization;
+// handing it to an organization keeps the organizer and requires the user to belong to it.
+func (s *MemberService) TransferOwnership(ownerID, eventID string, req *domain.TransferEventOwnershipRequest) (*domain.Event, error) {
+	if (req.Email == "") == (req.OrganizationID == "") {
+		return nil, fmt.Errorf("either email or organization_id is required")
+	}
+	event, err := authorizeEvent(s.eventRepo, ownerID, eventID, domain.EventActionTransfer, "transfer the event")
+	if err != nil {
+		return nil, err
+	}
+
+	organizerID, organizationID := event.OrganizerID, &req.OrganizationID
+	if req.Email != "" {
+		users, err := s.userRepo.GetByEmails([]string{strings.ToLower(strings.TrimSpace(req.Email))})
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("no user with this email address")
+		}
+		organizerID, organizationID = users[0].ID, nil
+	} else {
+		role, err := s.eventRepo.GetOrganizationRole(req.OrganizationID, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		if role == "" {
+			return nil, fmt.Errorf("only members of the organization can transfer events to it")
+		}
+	}
+
+	if err := s.events.TransferOwnership(ownerID, event, organizerID, organizationID); err != nil {
+		return nil, err
+	}
+	if organizerID != event.OrganizerID {
+		s.notifications.Notify(organizerID, "Event transferred", fmt.Sprintf("You are now the organizer of %q.", event.Title))
+	}
+	return s.eventRepo.GetByID(eventID)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"log"
 	"time"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
@@ -46,6 +47,20 @@ func (s *NotificationService) SendNotification(userID string, req *domain.Create
 	return notification, nil
 }
 
+// Notify sends a notification to a user on behalf of another service. Delivery is best-effort:
+// failures are logged and never fail the caller, and a nil service (notifications off) sends nothing.
+func (s *NotificationService) Notify(userID, title, message string) {
+	if s == nil {
+		return
+	}
+	if _, err := s.SendNotification(userID, &domain.CreateNotificationRequest{
+		Title:   title,
+		Message: message,
+	}); err != nil {
+		log.Printf("failed to notify user %s: %v", userID, err)
+	}
+}
+
 // Получение всех уведомлений пользователя
 func (s *NotificationService) GetUserNotifications(userID string) ([]domain.Notification, error) {
 	return s.notificationRepo.GetByUserID(userID)
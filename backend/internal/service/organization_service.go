@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type OrganizationService struct {
+	orgRepo  *repository.OrganizationRepository
+	userRepo *repository.UserRepository
+}
+
+func NewOrganizationService(orgRepo *repository.OrganizationRepository, userRepo *repository.UserRepository) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateOrganization creates an organization with the user as its first admin
+func (s *OrganizationService) CreateOrganization(userID string, req *domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	org := &domain.Organization{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		CreatedBy:   &userID,
+	}
+	if err := org.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.checkSlug(org); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.Create(org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// UpdateOrganization updates an organization (admins only)
+func (s *OrganizationService) UpdateOrganization(userID, slug string, req *domain.UpdateOrganizationRequest) (*domain.Organization, error) {
+	org, err := s.getAdministeredOrganization(userID, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		org.Name = *req.Name
+	}
+	if req.Slug != nil {
+		org.Slug = *req.Slug
+	}
+	if req.Description != nil {
+		org.Description = *req.Description
+	}
+	if err := org.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.checkSlug(org); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetProfile returns the public profile of an organization with its upcoming public events
+func (s *OrganizationService) GetProfile(slug string) (*domain.OrganizationProfile, error) {
+	org, err := s.orgRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.orgRepo.GetPublicEvents(org.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OrganizationProfile{Organization: *org, Events: events}, nil
+}
+
+// GetUserOrganizations returns the organizations a user belongs to
+func (s *OrganizationService) GetUserOrganizations(userID string) ([]domain.Organization, error) {
+	return s.orgRepo.GetUserOrganizations(userID)
+}
+
+// GetMembers returns the members of an organization (members only)
+func (s *OrganizationService) GetMembers(userID, slug string) ([]domain.OrganizationMember, error) {
+	org, err := s.orgRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.orgRepo.GetMember(org.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, fmt.Errorf("only members of the organization can view its members")
+	}
+	return s.orgRepo.GetMembers(org.ID)
+}
+
+// AddMember adds the user with the given email address to an organization (admins only).
+// Adding someone who already belongs to it changes their role.
+func (s *OrganizationService) AddMember(adminID, slug string, req *domain.AddOrganizationMemberRequest) (*domain.OrganizationMember, error) {
+	org, err := s.getAdministeredOrganization(adminID, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.GetByEmails([]string{strings.ToLower(strings.TrimSpace(req.Email))})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no user with this email address")
+	}
+	user := users[0]
+
+	if req.Role != domain.OrganizationRoleAdmin {
+		if err := s.checkNotLastAdmin(org.ID, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	member := &domain.OrganizationMember{
+		ID:             uuid.NewString(),
+		OrganizationID: org.ID,
+		UserID:         user.ID,
+		Role:           req.Role,
+	}
+	if err := s.orgRepo.UpsertMember(member); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.GetMember(org.ID, user.ID)
+}
+
+// RemoveMember removes a user from an organization (admins only; members may leave on their own).
+// The last admin can't leave, so the organization's events are never left unmanaged.
+func (s *OrganizationService) RemoveMember(userID, slug, memberID string) error {
+	org, err := s.orgRepo.GetBySlug(slug)
+	if err != nil {
+		return err
+	}
+	if userID != memberID {
+		if _, err := s.getAdministeredOrganization(userID, slug); err != nil {
+			return err
+		}
+	}
+	if err := s.checkNotLastAdmin(org.ID, memberID); err != nil {
+		return err
+	}
+	return s.orgRepo.DeleteMember(org.ID, memberID)
+}
+
+// getAdministeredOrganization loads an organization and checks that the user is one of its admins
+func (s *OrganizationService) getAdministeredOrganization(userID, slug string) (*domain.Organization, error) {
+	org, err := s.orgRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.orgRepo.GetMember(org.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role != domain.OrganizationRoleAdmin {
+		return nil, fmt.Errorf("only organization admins can manage the organization")
+	}
+	return org, nil
+}
+
+// checkNotLastAdmin rejects demoting or removing the only admin of an organization
+func (s *OrganizationService) checkNotLastAdmin(orgID, userID string) error {
+	member, err := s.orgRepo.GetMember(orgID, userID)
+	if err != nil || member == nil || member.Role != domain.OrganizationRoleAdmin {
+		return err
+	}
+	admins, err := s.orgRepo.CountAdmins(orgID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return fmt.Errorf("an organization needs at least one admin")
+	}
+	return nil
+}
+
+// checkSlug verifies that no other organization uses the slug
+func (s *OrganizationService) checkSlug(org *domain.Organization) error {
+	taken, err := s.orgRepo.SlugTaken(org.Slug, org.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("organization slug %q is already taken", org.Slug)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type PaymentService struct {
+	orderRepo *repository.OrderRepository
+	provider  payment.PaymentProvider
+	holdTTL   time.Duration
+}
+
+func NewPaymentService(orderRepo *repository.OrderRepository, provider payment.PaymentProvider, holdTTL time.Duration) *PaymentService {
+	return &PaymentService{
+		orderRepo: orderRepo,
+		provider:  provider,
+		holdTTL:   holdTTL,
+	}
+}
+
+// NewOrder prepares (but does not store) a pending order for seats of a paid ticket type,
+// applying the promo code discount if any. The seats are held until the returned order's ExpiresAt.
+func (s *PaymentService) NewOrder(userID string, ticketType *domain.TicketType, quantity int, redemption *domain.PromoRedemption) *domain.Order {
+	order := &domain.Order{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		EventID:      ticketType.EventID,
+		TicketTypeID: ticketType.ID,
+		Quantity:     quantity,
+		AmountCents:  ticketType.PriceCents * int64(quantity),
+		Currency:     ticketType.Currency,
+		Status:       domain.OrderStatusPending,
+		Provider:     s.provider.Name(),
+		ExpiresAt:    time.Now().Add(s.holdTTL),
+	}
+	if redemption != nil {
+		order.DiscountCents = redemption.DiscountCents
+		order.AmountCents -= redemption.DiscountCents
+		order.PromoCodeID = &redemption.PromoCodeID
+		redemption.OrderID = &order.ID
+	}
+	return order
+}
+
+// StartCheckout creates the provider payment intent for a stored order.
+// On success the order carries the client secret the front end needs to pay.
+// If the provider fails, the order is cancelled so its seats are released right away.
+func (s *PaymentService) StartCheckout(order *domain.Order) error {
+	intent, err := s.provider.CreateIntent(context.Background(), payment.IntentRequest{
+		OrderID:     order.ID,
+		AmountCents: order.AmountCents,
+		Currency:    order.Currency,
+		Description: fmt.Sprintf("Order %s", order.ID),
+	})
+	if err == nil {
+		err = s.orderRepo.SetIntent(order.ID, intent.ID)
+	}
+	if err != nil {
+		if _, closeErr := s.orderRepo.Close(order.ID, domain.OrderStatusCancelled, domain.RegistrationStatusCancelled); closeErr != nil {
+			log.Printf("failed to release order %s after checkout error: %v", order.ID, closeErr)
+		}
+		return fmt.Errorf("failed to start payment: %w", err)
+	}
+
+	order.ProviderIntentID = &intent.ID
+	order.ClientSecret = intent.ClientSecret
+	return nil
+}
+
+// HandleWebhook verifies and applies a provider webhook. Deliveries are idempotent:
+// replays of an already applied event are accepted without changing anything.
+// A payment that succeeds after its hold expired (or was cancelled) is refunded, once.
+func (s *PaymentService) HandleWebhook(payload []byte, signature string) error {
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	order, err := s.orderRepo.GetByIntentID(event.IntentID)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		order, err = s.orderRepo.MarkPaid(order.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if order.Status != domain.OrderStatusPaid {
+			// The seats were already released and the refund recorded; give the money back.
+			// Replays find nothing outstanding unless sending it failed before.
+			if err := s.IssueRefund(order.ID); err != nil {
+				return fmt.Errorf("failed to refund late payment: %w", err)
+			}
+		}
+	case payment.EventPaymentFailed:
+		if _, err := s.orderRepo.Close(order.ID, domain.OrderStatusFailed, domain.RegistrationStatusCancelled); err != nil {
+			return err
+		}
+	default:
+		// Unknown event types are acknowledged so the provider stops retrying
+	}
+	return nil
+}
+
+// IssueRefund sends the refunds recorded on an order but not sent yet to the payment provider.
+// It does nothing when no refund is outstanding, so it is safe to retry. A refund the provider
+// rejects stays outstanding and is picked up again by RetryRefunds.
+func (s *PaymentService) IssueRefund(orderID string) error {
+	order, amount, err := s.orderRepo.ClaimRefund(orderID)
+	if err != nil || amount == 0 {
+		return err
+	}
+	if order.ProviderIntentID == nil {
+		return fmt.Errorf("order has no payment intent")
+	}
+	if _, err := s.provider.Refund(context.Background(), *order.ProviderIntentID, amount); err != nil {
+		if releaseErr := s.orderRepo.ReleaseRefund(order.ID, amount); releaseErr != nil {
+			log.Printf("failed to release refund of order %s: %v", order.ID, releaseErr)
+		}
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+	return nil
+}
+
+// RetryRefunds sends the outstanding refunds of orders whose earlier attempt failed.
+// It returns the number of orders refunded.
+func (s *PaymentService) RetryRefunds(ctx context.Context) (int64, error) {
+	ids, err := s.orderRepo.GetWithOutstandingRefunds(100)
+	if err != nil {
+		return 0, err
+	}
+	var refunded int64
+	for _, id := range ids {
+		if err := s.IssueRefund(id); err != nil {
+			log.Printf("failed to refund order %s: %v", id, err)
+			continue
+		}
+		refunded++
+	}
+	return refunded, nil
+}
+
+// GetOrder returns an order by ID
+func (s *PaymentService) GetOrder(orderID string) (*domain.Order, error) {
+	return s.orderRepo.GetByID(orderID)
+}
+
+// ExpireHolds releases seats of unpaid orders whose hold has lapsed
+func (s *PaymentService) ExpireHolds(ctx context.Context) (int64, error) {
+	return s.orderRepo.ExpireDue(time.Now())
+}
+
+// GetUserOrders returns the orders of a user
+func (s *PaymentService) GetUserOrders(userID string) ([]domain.Order, error) {
+	return s.orderRepo.GetUserOrders(userID)
+}
+
+// GetUserOrder returns a single order of a user
+func (s *PaymentService) GetUserOrder(userID, orderID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, fmt.Errorf("order not found")
+	}
+	return order, nil
+}
+
+// SimulatePayment completes the payment of a user's pending order on the fake provider.
+// It stands in for the provider's hosted payment page during development and tests.
+// Without a configured webhook URL the signed webhook is applied in-process.
+func (s *PaymentService) SimulatePayment(userID, orderID string, succeed bool) (*domain.Order, error) {
+	fake, ok := s.provider.(*payment.FakeProvider)
+	if !ok {
+		return nil, fmt.Errorf("payment simulation is only available with the fake provider")
+	}
+
+	order, err := s.GetUserOrder(userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.ProviderIntentID == nil {
+		return nil, fmt.Errorf("order has no payment intent")
+	}
+
+	intentID := *order.ProviderIntentID
+	eventType := payment.EventPaymentSucceeded
+	if succeed {
+		_, err = fake.Confirm(context.Background(), intentID)
+	} else {
+		eventType = payment.EventPaymentFailed
+		_, err = fake.Decline(context.Background(), intentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fake.WebhookURL == "" {
+		payload, signature, err := fake.BuildWebhook(intentID, eventType)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.HandleWebhook(payload, signature); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.orderRepo.GetByID(orderID)
+}
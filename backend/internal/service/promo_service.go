@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type PromoService struct {
+	promoRepo  *repository.PromoCodeRepository
+	ticketRepo *repository.TicketTypeRepository
+	eventRepo  *repository.EventRepository
+}
+
+func NewPromoService(promoRepo *repository.PromoCodeRepository, ticketRepo *repository.TicketTypeRepository, eventRepo *repository.EventRepository) *PromoService {
+	return &PromoService{
+		promoRepo:  promoRepo,
+		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+// GetPromoCodes returns all promo codes of an event (organizer only)
+func (s *PromoService) GetPromoCodes(userID, eventID string) ([]domain.PromoCode, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+	return s.promoRepo.GetByEvent(eventID)
+}
+
+// CreatePromoCode adds a promo code to an event (organizer only)
+func (s *PromoService) CreatePromoCode(userID, eventID string, req *domain.CreatePromoCodeRequest) (*domain.PromoCode, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	promo := &domain.PromoCode{
+		ID:             uuid.NewString(),
+		EventID:        eventID,
+		Code:           req.Code,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		MaxRedemptions: req.MaxRedemptions,
+		PerUserLimit:   req.PerUserLimit,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		Active:         true,
+	}
+	if err := promo.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if existing, _ := s.promoRepo.GetByCode(eventID, promo.Code); existing != nil {
+		return nil, fmt.Errorf("promo code %s already exists for this event", promo.Code)
+	}
+
+	ticketTypes, err := s.resolveTicketTypes(eventID, req.TicketTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+	promo.TicketTypes = ticketTypes
+
+	if err := s.promoRepo.Create(promo); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// UpdatePromoCode updates a promo code (organizer only).
+// The code itself cannot be changed, since attendees may already have received it.
+func (s *PromoService) UpdatePromoCode(userID, eventID, promoCodeID string, req *domain.UpdatePromoCodeRequest) (*domain.PromoCode, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	promo, err := s.promoRepo.GetByID(eventID, promoCodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DiscountType != nil {
+		promo.DiscountType = *req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		promo.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxRedemptions != nil {
+		promo.MaxRedemptions = req.MaxRedemptions
+	}
+	if req.PerUserLimit != nil {
+		promo.PerUserLimit = *req.PerUserLimit
+	}
+	if req.ValidFrom != nil {
+		promo.ValidFrom = req.ValidFrom
+	}
+	if req.ValidUntil != nil {
+		promo.ValidUntil = req.ValidUntil
+	}
+	if req.Active != nil {
+		promo.Active = *req.Active
+	}
+	if req.TicketTypeIDs != nil {
+		ticketTypes, err := s.resolveTicketTypes(eventID, *req.TicketTypeIDs)
+		if err != nil {
+			return nil, err
+		}
+		promo.TicketTypes = ticketTypes
+	}
+
+	if err := promo.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.promoRepo.Update(promo); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// DeletePromoCode removes a promo code (organizer only).
+// Registrations that already used it keep their discount.
+func (s *PromoService) DeletePromoCode(userID, eventID, promoCodeID string) error {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return err
+	}
+	return s.promoRepo.Delete(eventID, promoCodeID)
+}
+
+// GetPromoCodeStats reports how often a promo code was redeemed (organizer only)
+func (s *PromoService) GetPromoCodeStats(userID, eventID, promoCodeID string) (*domain.PromoCodeStats, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	promo, err := s.promoRepo.GetByID(eventID, promoCodeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.promoRepo.GetStats(promo)
+}
+
+// PrepareRedemption checks that a code can be applied to a ticket type and computes the discount
+// for a booking of the given number of seats. A redemption covers the whole booking.
+// Redemption limits are enforced atomically when the registration is stored.
+func (s *PromoService) PrepareRedemption(userID string, ticketType *domain.TicketType, quantity int, code string) (*domain.PromoRedemption, error) {
+	promo, err := s.promoRepo.GetByCode(ticketType.EventID, code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid promo code")
+	}
+	if err := promo.CheckUsable(time.Now(), ticketType.ID); err != nil {
+		return nil, err
+	}
+
+	return &domain.PromoRedemption{
+		ID:            uuid.NewString(),
+		PromoCodeID:   promo.ID,
+		UserID:        userID,
+		DiscountCents: promo.Discount(ticketType.PriceCents * int64(quantity)),
+	}, nil
+}
+
+// GetRedemption returns the promo code redemption of a registration, or nil if it used none
+func (s *PromoService) GetRedemption(registrationID string) (*domain.PromoRedemption, error) {
+	return s.promoRepo.GetRedemptionByRegistration(registrationID)
+}
+
+// resolveTicketTypes loads the ticket types a code is restricted to, all of which must belong to the event
+func (s *PromoService) resolveTicketTypes(eventID string, ticketTypeIDs []string) ([]domain.TicketType, error) {
+	if len(ticketTypeIDs) == 0 {
+		return []domain.TicketType{}, nil
+	}
+
+	eventTicketTypes, err := s.ticketRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.TicketType, len(eventTicketTypes))
+	for _, ticketType := range eventTicketTypes {
+		byID[ticketType.ID] = ticketType
+	}
+
+	ticketTypes := make([]domain.TicketType, 0, len(ticketTypeIDs))
+	seen := make(map[string]bool, len(ticketTypeIDs))
+	for _, id := range ticketTypeIDs {
+		ticketType, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("ticket type %s not found for this event", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ticketTypes = append(ticketTypes, ticketType)
+		}
+	}
+	return ticketTypes, nil
+}
+
+// getOwnedEvent loads an event and checks that the user organizes it
+func (s *PromoService) getOwnedEvent(userID, eventID string) (*domain.Event, error) {
+	return authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "manage promo codes")
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+// publishBatchSize limits how many scheduled events one scheduler run publishes
+const publishBatchSize = 100
+
+// publishLease is how long a replica has to publish the events it claimed before others may claim them again
+const publishLease = 5 * time.Minute
+
+// PublishScheduler publishes draft events whose publish_at has come (run periodically).
+// Events go live through EventService.PublishScheduled on behalf of their organizer, as if published
+// by hand (the transition is guarded, recorded and the cache is invalidated), but only while they are
+// still due and leased to this replica.
+type PublishScheduler struct {
+	eventService  *EventService
+	notifications *NotificationService
+}
+
+func NewPublishScheduler(eventService *EventService, notifications *NotificationService) *PublishScheduler {
+	return &PublishScheduler{
+		eventService:  eventService,
+		notifications: notifications,
+	}
+}
+
+// PublishDue publishes the drafts that are due and notifies their organizers.
+// Each event is leased in the database first, so replicas running the scheduler never publish it twice,
+// and its schedule is only cleared once it is published. Events whose schedule changed meanwhile are
+// skipped without notice (the new schedule applies). Events that failed for a passing reason are
+// retried on the next run; those that can never be published as scheduled (e.g. the lifecycle no longer
+// allows it) lose their schedule and their organizer is told. Returns the number of published events.
+func (s *PublishScheduler) PublishDue(ctx context.Context) (int, error) {
+	due, err := s.eventService.ClaimDuePublications(publishLease, publishBatchSize)
+	published := 0
+	for i := range due {
+		event := &due[i]
+		if ctx.Err() != nil {
+			// hand the event back so the next run picks it up
+			s.release(event)
+			continue
+		}
+		ok, perr := s.eventService.PublishScheduled(event)
+		switch {
+		case perr == nil && ok:
+			published++
+			s.notifications.Notify(event.OrganizerID, "Event published", fmt.Sprintf("%q is now live, as scheduled.", event.Title))
+		case perr == nil:
+			s.release(event)
+		case errors.Is(perr, domain.ErrInvalidStatusTransition):
+			log.Printf("dropping the publishing schedule of event %s: %v", event.ID, perr)
+			if derr := s.eventService.DropScheduledPublish(event); derr != nil {
+				log.Printf("failed to unschedule event %s: %v", event.ID, derr)
+			}
+			s.notifications.Notify(event.OrganizerID, "Scheduled publishing failed",
+				fmt.Sprintf("%q could not be published as scheduled (%v), so its schedule was removed.", event.Title, perr))
+		default:
+			log.Printf("failed to publish scheduled event %s: %v", event.ID, perr)
+			s.release(event)
+		}
+	}
+	if err != nil {
+		return published, fmt.Errorf("failed to publish scheduled events: %w", err)
+	}
+	return published, nil
+}
+
+// release gives up the lease on an event that wasn't published this time (if that fails too, the lease expires)
+func (s *PublishScheduler) release(event *domain.Event) {
+	if err := s.eventService.ReleasePublication(event); err != nil {
+		log.Printf("failed to release event %s: %v", event.ID, err)
+	}
+}
@@ -2,89 +2,645 @@ package service
 
 import (
 	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
 	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
 )
 
 type RegistrationService struct {
-	regRepo   *repository.RegistrationRepository
-	eventRepo *repository.EventRepository
+	regRepo       *repository.RegistrationRepository
+	eventRepo     *repository.EventRepository
+	ticketRepo    *repository.TicketTypeRepository
+	policyRepo    *repository.CancellationPolicyRepository
+	formRepo      *repository.RegistrationFormRepository
+	payments      *PaymentService
+	promos        *PromoService
+	notifications *NotificationService
 }
 
-func NewRegistrationService(regRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository) *RegistrationService {
+func NewRegistrationService(regRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, ticketRepo *repository.TicketTypeRepository, policyRepo *repository.CancellationPolicyRepository, formRepo *repository.RegistrationFormRepository, payments *PaymentService, promos *PromoService, notifications *NotificationService) *RegistrationService {
 	return &RegistrationService{
-		regRepo:   regRepo,
-		eventRepo: eventRepo,
+		regRepo:       regRepo,
+		eventRepo:     eventRepo,
+		ticketRepo:    ticketRepo,
+		policyRepo:    policyRepo,
+		formRepo:      formRepo,
+		payments:      payments,
+		promos:        promos,
+		notifications: notifications,
 	}
 }
 
-// RegisterUser registers a user for an event
-func (s *RegistrationService) RegisterUser(userID, eventID string) (*domain.Registration, error) {
+// RegisterUser registers a user for an event.
+// For events with ticket types the request selects the tier; it may be omitted when there is only one.
+// Paid tiers hold the seat as "pending_payment" and return the order to pay; the registration
+// is confirmed by the payment provider's webhook or released when the hold expires.
+// Events that require approval take a "pending" application instead, which holds no seat
+// until the organizer approves it.
+// A request can book several seats: the user's own plus guest seats, all or nothing. The
+// returned registration is the user's own seat, with the guest seats in Guests.
+func (s *RegistrationService) RegisterUser(userID, eventID string, req *domain.RegisterRequest) (*domain.Registration, error) {
 	// 1. Check if event exists
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return nil, fmt.Errorf("event not found")
 	}
 
-	// 2. Check if event is published
+	// 2. Check if event is published and its registration window is open
 	if event.Status != "published" {
 		return nil, fmt.Errorf("cannot register for unpublished event")
 	}
+	if err := event.CheckRegistrationWindow(time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Private events admit the event team and organization, invitees and holders of the access code
+	// (unlisted events anyone with the link)
+	if event.Visibility == domain.EventVisibilityPrivate {
+		if err := s.checkPrivateAccess(event, userID, req); err != nil {
+			return nil, err
+		}
+	}
 
 	// 3. Check if already registered
 	existing, err := s.regRepo.GetByUserAndEvent(userID, eventID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing registration: %w", err)
 	}
-	if existing != nil {
-		if existing.Status == "cancelled" {
-			// If previously cancelled, we could re-activate, but for now let's just say "already registered"
-			// or we could update the status back to confirmed.
-			// Let's keep it simple: error.
-			return nil, fmt.Errorf("user already registered (status: %s)", existing.Status)
+	// Cancelled or rejected registrations and lapsed payment holds don't block registering again
+	if existing != nil && slices.Contains(domain.ActiveRegistrationStatuses, existing.Status) {
+		return nil, fmt.Errorf("user already registered (status: %s)", existing.Status)
+	}
+
+	// Events at the same time on the user's schedule only warn, unless the user opted for strict mode
+	conflicts, err := s.scheduleConflicts(userID, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Work out the seats to book
+	quantity, guests, err := bookingSize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Validate the answers to the registration form
+	var submitted domain.FormAnswers
+	if req != nil {
+		submitted = req.Answers
+	}
+	form, err := s.GetRegistrationForm(eventID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := form.ValidateAnswers(submitted)
+	if err != nil {
+		return nil, fmt.Errorf("invalid answers: %w", err)
+	}
+
+	// 6. Select the ticket type
+	ticketType, err := s.resolveTicketType(eventID, req)
+	if err != nil {
+		return nil, err
+	}
+	if ticketType != nil {
+		if err := ticketType.CheckOrderSize(quantity); err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("user already registered")
 	}
 
-	// 4 & 5. Atomic Capacity Check and Creation
-	// We pass the registration object (with UserID, EventID, Status) and the capacity limit.
-	// The repository handles the locking and transaction.
+	// 7. Apply the promo code
+	var redemption *domain.PromoRedemption
+	if req != nil && req.PromoCode != "" {
+		if ticketType == nil || s.promos == nil {
+			return nil, fmt.Errorf("promo codes are not available for this event")
+		}
+		redemption, err = s.promos.PrepareRedemption(userID, ticketType, quantity, req.PromoCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 8 & 9. Atomic Capacity Check and Creation
+	// We pass the registration objects (with UserID, EventID, TicketTypeID, Status), one per seat.
+	// The repository handles the locking, capacity, per-tier stock and promo code limits.
+
+	status := domain.RegistrationStatusConfirmed
+	var order *domain.Order
+	if event.RequiresApproval {
+		// The seats (and the payment) are settled when the application is approved
+		status = domain.RegistrationStatusPending
+	} else {
+		order, err = s.newOrder(userID, ticketType, quantity, redemption)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			status = domain.RegistrationStatusPendingPayment
+		}
+	}
 
 	registration := &domain.Registration{
-		UserID:  userID,
-		EventID: eventID,
-		Status:  "confirmed",
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		EventID:     eventID,
+		Status:      status,
+		Answers:     answers,
+		TicketToken: domain.NewTicketToken(),
+	}
+	seats := []*domain.Registration{registration}
+	for i := 1; i < quantity; i++ {
+		guest := &domain.Registration{
+			ID:          uuid.NewString(),
+			UserID:      userID,
+			EventID:     eventID,
+			Status:      status,
+			IsGuest:     true,
+			TicketToken: domain.NewTicketToken(),
+		}
+		if i <= len(guests) {
+			guest.GuestName = strings.TrimSpace(guests[i-1].Name)
+			guest.GuestEmail = strings.TrimSpace(guests[i-1].Email)
+		}
+		seats = append(seats, guest)
+	}
+	for _, seat := range seats {
+		if ticketType != nil {
+			seat.TicketTypeID = &ticketType.ID
+		}
+		if quantity > 1 {
+			seat.GroupID = &registration.ID
+		}
 	}
 
-	if err := s.regRepo.CreateWithCapacityCheck(registration, event.Capacity); err != nil {
+	if err := s.regRepo.CreateWithCapacityCheck(seats, order, redemption); err != nil {
 		return nil, err // error is already formatted in repo
 	}
+	for _, guest := range seats[1:] {
+		registration.Guests = append(registration.Guests, *guest)
+	}
+
+	// 10. Start the checkout for paid tickets
+	if order != nil {
+		if err := s.payments.StartCheckout(order); err != nil {
+			return nil, err
+		}
+		registration.Order = order
+	}
 
+	if len(conflicts) > 0 {
+		registration.Conflicts = conflicts
+	}
 	return registration, nil
 }
 
-// CancelRegistration cancels a user's registration
-func (s *RegistrationService) CancelRegistration(userID, eventID string) error {
-	return s.regRepo.Cancel(userID, eventID)
+// checkPrivateAccess returns domain.ErrInvitationRequired unless the user may register for the private event
+func (s *RegistrationService) checkPrivateAccess(event *domain.Event, userID string, req *domain.RegisterRequest) error {
+	role, err := eventRole(s.eventRepo, event, userID)
+	if err != nil {
+		return err
+	}
+	if role != "" {
+		return nil
+	}
+	if req != nil && event.AccessCodeMatches(req.AccessCode) {
+		return nil
+	}
+	allowed, err := s.eventRepo.HasPrivateAccess(event.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrInvitationRequired
+	}
+	return nil
 }
 
-// GetUserRegistrations returns all events a user is registered for
-func (s *RegistrationService) GetUserRegistrations(userID string) ([]domain.Registration, error) {
-	return s.regRepo.GetUserRegistrations(userID)
+// scheduleConflicts returns the events on the user's schedule that overlap event.
+// Users in strict mode get domain.ErrScheduleConflict naming the first one instead.
+func (s *RegistrationService) scheduleConflicts(userID string, event *domain.Event) ([]domain.ScheduleConflict, error) {
+	schedule, err := s.regRepo.GetScheduledEvents(userID, event.StartDatetime)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := domain.ScheduleConflicts(event, schedule)
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	strict, err := s.regRepo.IsStrictSchedule(userID)
+	if err != nil {
+		return nil, err
+	}
+	if strict {
+		return nil, domain.NewCodedError(domain.ErrScheduleConflict.Code,
+			fmt.Sprintf("you are already attending %q at that time", conflicts[0].Title))
+	}
+	return conflicts, nil
 }
 
-// CheckInAttendee marks user's attendance (organizer initiative)
-func (s *RegistrationService) CheckInAttendee(organizerID, eventID, attendeeID string) error {
+// bookingSize returns the number of seats a registration request books and the details of its guests.
+// Without an explicit quantity the user books their own seat plus one per listed guest.
+func bookingSize(req *domain.RegisterRequest) (int, []domain.GuestRequest, error) {
+	if req == nil {
+		return 1, nil, nil
+	}
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1 + len(req.Guests)
+	}
+	if quantity > domain.MaxSeatsPerBooking {
+		return 0, nil, fmt.Errorf("at most %d seats can be booked at once", domain.MaxSeatsPerBooking)
+	}
+	if len(req.Guests) > quantity-1 {
+		return 0, nil, fmt.Errorf("too many guests for %d seat(s)", quantity)
+	}
+	return quantity, req.Guests, nil
+}
+
+// newOrder prepares the order for seats of a paid ticket type.
+// Paid tickets wait for payment; free admission and fully discounted tickets need none (nil order).
+func (s *RegistrationService) newOrder(userID string, ticketType *domain.TicketType, quantity int, redemption *domain.PromoRedemption) (*domain.Order, error) {
+	if ticketType == nil {
+		return nil, nil
+	}
+	discount := int64(0)
+	if redemption != nil {
+		discount = redemption.DiscountCents
+	}
+	if ticketType.PriceCents*int64(quantity)-discount <= 0 {
+		return nil, nil
+	}
+	if s.payments == nil {
+		return nil, fmt.Errorf("payments are not available")
+	}
+	return s.payments.NewOrder(userID, ticketType, quantity, redemption), nil
+}
+
+// resolveTicketType picks the ticket type for a registration.
+// Events without ticket types use plain (free) admission; a single ticket type is selected implicitly.
+func (s *RegistrationService) resolveTicketType(eventID string, req *domain.RegisterRequest) (*domain.TicketType, error) {
+	if s.ticketRepo == nil {
+		return nil, nil
+	}
+
+	if req != nil && req.TicketTypeID != nil && *req.TicketTypeID != "" {
+		ticketType, err := s.ticketRepo.GetByID(eventID, *req.TicketTypeID)
+		if err != nil {
+			return nil, err
+		}
+		return ticketType, nil
+	}
+
+	ticketTypes, err := s.ticketRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	switch len(ticketTypes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &ticketTypes[0], nil
+	default:
+		return nil, fmt.Errorf("ticket_type_id is required for events with multiple ticket types")
+	}
+}
+
+// CancelRegistration cancels a user's booking for an event (their own seat and any guest seats)
+// according to the event's cancellation policy. Disallowed cancellations are rejected with a
+// domain.CodedError. For paid registrations the refund percentage of the policy is applied to
+// the seat price and refunded through the provider. Applications still awaiting approval can always be withdrawn.
+func (s *RegistrationService) CancelRegistration(userID, eventID string) (*domain.Registration, error) {
+	// 1. Find the active registration
+	registration, err := s.regRepo.GetByUserAndEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if registration == nil || !slices.Contains(domain.ActiveRegistrationStatuses, registration.Status) {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	// 2. Collect the active seats of the booking
+	booking, err := s.regRepo.GetBooking(registration)
+	if err != nil {
+		return nil, err
+	}
+	seats := []*domain.Registration{registration}
+	for i := range booking {
+		if booking[i].IsGuest && slices.Contains(domain.ActiveRegistrationStatuses, booking[i].Status) {
+			seats = append(seats, &booking[i])
+		}
+	}
+
+	// 3. Cancel them under the policy
+	if err := s.cancelSeats(eventID, seats); err != nil {
+		return nil, err
+	}
+	for _, guest := range seats[1:] {
+		registration.Guests = append(registration.Guests, *guest)
+	}
+	return registration, nil
+}
+
+// CancelGuestSeat cancels a single guest seat of a user's booking according to the event's cancellation policy.
+// While the booking's payment is pending only the whole booking can be cancelled.
+func (s *RegistrationService) CancelGuestSeat(userID, eventID, registrationID string) (*domain.Registration, error) {
+	seat, err := s.regRepo.GetByID(eventID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	if seat.UserID != userID || !seat.IsGuest || !slices.Contains(domain.ActiveRegistrationStatuses, seat.Status) {
+		return nil, fmt.Errorf("guest seat not found")
+	}
+	if seat.Status == domain.RegistrationStatusPendingPayment {
+		return nil, fmt.Errorf("guest seats can be cancelled once the booking is paid; cancel the whole booking instead")
+	}
+
+	if err := s.cancelSeats(eventID, []*domain.Registration{seat}); err != nil {
+		return nil, err
+	}
+	return seat, nil
+}
+
+// cancelSeats cancels seats of one booking. Pending applications are withdrawn right away;
+// seat-holding registrations are subject to the cancellation policy and paid seats are refunded.
+func (s *RegistrationService) cancelSeats(eventID string, seats []*domain.Registration) error {
+	if seats[0].Status == domain.RegistrationStatusPending {
+		return s.regRepo.Cancel(seats, 0)
+	}
 
-	// 1. Check if event exists and user is the organizer
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return fmt.Errorf("event not found")
 	}
 
-	if event.OrganizerID != organizerID {
-		return fmt.Errorf("only the event organizer can check-in attendees")
+	// Evaluate the cancellation policy
+	policy, err := s.GetCancellationPolicy(eventID)
+	if err != nil {
+		return err
+	}
+	quote, err := policy.Evaluate(event.StartDatetime, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Cancel and record the refund of paid seats (unpaid holds are simply released)
+	if err := s.regRepo.Cancel(seats, quote.RefundPercent); err != nil {
+		return err
+	}
+
+	// Send the recorded refund; if the provider fails it stays outstanding and is retried later
+	if seats[0].OrderID != nil && seats[0].RefundCents > 0 && s.payments != nil {
+		if err := s.payments.IssueRefund(*seats[0].OrderID); err != nil {
+			log.Printf("failed to refund order %s: %v", *seats[0].OrderID, err)
+		}
+	}
+	return nil
+}
+
+// ApproveRegistration accepts a pending application with all seats of its booking (organizer only).
+// Capacity and ticket stock are re-checked at this point. Free registrations are confirmed
+// right away; paid ones get an order that must be paid within the usual payment hold.
+// The applicant is notified of the outcome, including the optional reason.
+func (s *RegistrationService) ApproveRegistration(organizerID, eventID, registrationID, reason string) (*domain.Registration, error) {
+	event, registration, guests, err := s.getPendingApplication(organizerID, eventID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticketType *domain.TicketType
+	if registration.TicketTypeID != nil {
+		ticketType, err = s.ticketRepo.GetByID(eventID, *registration.TicketTypeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var redemption *domain.PromoRedemption
+	if s.promos != nil {
+		redemption, err = s.promos.GetRedemption(registration.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := s.newOrder(registration.UserID, ticketType, 1+len(guests), redemption)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.regRepo.Approve(registration, order, redemption, organizerID, reason); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your registration for %q has been approved.", event.Title)
+	if order != nil {
+		if err := s.payments.StartCheckout(order); err != nil {
+			return nil, err
+		}
+		registration.Order = order
+		message += fmt.Sprintf(" Please complete the payment of order %s to secure your seat.", order.ID)
+	}
+	for _, guest := range guests {
+		guest.Status = registration.Status
+		guest.OrderID = registration.OrderID
+		registration.Guests = append(registration.Guests, guest)
+	}
+	if reason != "" {
+		message += " Note: " + reason
+	}
+	s.notifications.Notify(registration.UserID, "Registration approved", message)
+
+	return registration, nil
+}
+
+// RejectRegistration declines a pending application with all seats of its booking (organizer only).
+// The applicant is notified of the outcome, including the optional reason.
+func (s *RegistrationService) RejectRegistration(organizerID, eventID, registrationID, reason string) (*domain.Registration, error) {
+	event, registration, _, err := s.getPendingApplication(organizerID, eventID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.regRepo.Reject(registration, organizerID, reason); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your registration for %q was not approved.", event.Title)
+	if reason != "" {
+		message += " Reason: " + reason
+	}
+	s.notifications.Notify(registration.UserID, "Registration declined", message)
+
+	return registration, nil
+}
+
+// getPendingApplication loads an application awaiting approval and checks that the user organizes its event.
+// Any seat of a booking identifies the application: the booking user's own seat is returned with the pending guest seats.
+func (s *RegistrationService) getPendingApplication(organizerID, eventID, registrationID string) (*domain.Event, *domain.Registration, []domain.Registration, error) {
+	event, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionManage, "review registrations")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	registration, err := s.regRepo.GetByID(eventID, registrationID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if registration.Status != domain.RegistrationStatusPending {
+		return nil, nil, nil, fmt.Errorf("registration is not pending approval (status: %s)", registration.Status)
+	}
+
+	booking, err := s.regRepo.GetBooking(registration)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var guests []domain.Registration
+	for i := range booking {
+		switch {
+		case !booking[i].IsGuest:
+			registration = &booking[i]
+		case booking[i].Status == domain.RegistrationStatusPending:
+			guests = append(guests, booking[i])
+		}
+	}
+	if registration.Status != domain.RegistrationStatusPending {
+		return nil, nil, nil, fmt.Errorf("registration is not pending approval (status: %s)", registration.Status)
+	}
+	return event, registration, guests, nil
+}
+
+// GetCancellationPolicy returns the cancellation policy of an event, or the default policy if none is set
+func (s *RegistrationService) GetCancellationPolicy(eventID string) (*domain.CancellationPolicy, error) {
+	if s.policyRepo == nil {
+		return domain.DefaultCancellationPolicy(eventID), nil
+	}
+	policy, err := s.policyRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return domain.DefaultCancellationPolicy(eventID), nil
+	}
+	return policy, nil
+}
+
+// SetCancellationPolicy replaces the cancellation policy of an event (organizer only)
+func (s *RegistrationService) SetCancellationPolicy(userID, eventID string, req *domain.UpdateCancellationPolicyRequest) (*domain.CancellationPolicy, error) {
+	if _, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "change the cancellation policy"); err != nil {
+		return nil, err
+	}
+
+	policy := &domain.CancellationPolicy{
+		EventID:           eventID,
+		AllowCancellation: *req.AllowCancellation,
+		CutoffHours:       req.CutoffHours,
+		RefundTiers:       req.RefundTiers,
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.policyRepo.Upsert(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetRegistrationForm returns the registration form of an event, or an empty form if none is set
+func (s *RegistrationService) GetRegistrationForm(eventID string) (*domain.RegistrationForm, error) {
+	if s.formRepo == nil {
+		return domain.EmptyRegistrationForm(eventID), nil
+	}
+	form, err := s.formRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return domain.EmptyRegistrationForm(eventID), nil
+	}
+	return form, nil
+}
+
+// SetRegistrationForm replaces the registration form of an event (organizer only).
+// Answers already given to removed or changed questions are kept as they are.
+func (s *RegistrationService) SetRegistrationForm(userID, eventID string, req *domain.UpdateRegistrationFormRequest) (*domain.RegistrationForm, error) {
+	if _, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "change the registration form"); err != nil {
+		return nil, err
+	}
+
+	form := &domain.RegistrationForm{
+		EventID:              eventID,
+		Questions:            req.Questions,
+		AnswersEditableUntil: req.AnswersEditableUntil,
+	}
+	if err := form.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.formRepo.Upsert(form); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// UpdateAnswers replaces a user's answers to the registration form of an event.
+// Answers are editable until the form's deadline (at the latest until the event starts);
+// later changes are rejected with domain.ErrAnswersLocked.
+func (s *RegistrationService) UpdateAnswers(userID, eventID string, answers domain.FormAnswers) (*domain.Registration, error) {
+	registration, err := s.regRepo.GetByUserAndEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if registration == nil || !slices.Contains(domain.ActiveRegistrationStatuses, registration.Status) {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+	form, err := s.GetRegistrationForm(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !form.AnswersEditable(event.StartDatetime, time.Now()) {
+		return nil, domain.ErrAnswersLocked
+	}
+
+	normalized, err := form.ValidateAnswers(answers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid answers: %w", err)
+	}
+	if err := s.regRepo.UpdateAnswers(registration, normalized); err != nil {
+		return nil, err
+	}
+	return registration, nil
+}
+
+// GetUserRegistrations returns all events a user is registered for
+func (s *RegistrationService) GetUserRegistrations(userID string) ([]domain.Registration, error) {
+	return s.regRepo.GetUserRegistrations(userID)
+}
+
+// GetScheduleConflicts returns the pairs of overlapping upcoming events the user is attending
+func (s *RegistrationService) GetScheduleConflicts(userID string) ([]domain.ScheduleOverlap, error) {
+	schedule, err := s.regRepo.GetScheduledEvents(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return domain.FindScheduleOverlaps(schedule), nil
+}
+
+// CheckInAttendee marks user's attendance (organizers and check-in staff)
+func (s *RegistrationService) CheckInAttendee(organizerID, eventID, attendeeID string) error {
+
+	// 1. Check if event exists and user may check in its attendees
+	if _, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionCheckIn, "check-in attendees"); err != nil {
+		return err
 	}
 
 	// 2. Get the registration to verify it exists and is confirmed
@@ -109,17 +665,33 @@ func (s *RegistrationService) CheckInAttendee(organizerID, eventID, attendeeID s
 	return nil
 }
 
-// GetEventRegistrants returns all registrants for a specific event (organizer only)
-func (s *RegistrationService) GetEventRegistrants(organizerID, eventID, status string) ([]domain.Registration, error) {
+// CheckInSeat marks a single seat of a booking as attended, identified by its registration (ticket) ID.
+// Guest seats have no user of their own, so they are checked in this way (organizers and check-in staff).
+func (s *RegistrationService) CheckInSeat(organizerID, eventID, registrationID string) error {
+	if _, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionCheckIn, "check-in attendees"); err != nil {
+		return err
+	}
 
-	// 1. Check if event exists and user is the organizer
-	event, err := s.eventRepo.GetByID(eventID)
+	registration, err := s.regRepo.GetByID(eventID, registrationID)
 	if err != nil {
-		return nil, fmt.Errorf("event not found")
+		return err
+	}
+	if registration.Status != domain.RegistrationStatusConfirmed {
+		return fmt.Errorf("can only check-in confirmed registrations (current status: %s)", registration.Status)
+	}
+
+	if err := s.regRepo.CheckInSeat(eventID, registrationID); err != nil {
+		return fmt.Errorf("failed to check-in attendee: %w", err)
 	}
+	return nil
+}
+
+// GetEventRegistrants returns all registrants for a specific event (event team only)
+func (s *RegistrationService) GetEventRegistrants(organizerID, eventID, status string) ([]domain.Registration, error) {
 
-	if event.OrganizerID != organizerID {
-		return nil, fmt.Errorf("only the event organizer can view registrants")
+	// 1. Check if event exists and user is on its team
+	if _, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionView, "view registrants"); err != nil {
+		return nil, err
 	}
 
 	// 2. Get registrations with filtering
@@ -130,3 +702,30 @@ func (s *RegistrationService) GetEventRegistrants(organizerID, eventID, status s
 
 	return registrations, nil
 }
+
+// RegistrantExport is an export of an event's registrants that streams them from the database
+type RegistrantExport struct {
+	Event     *domain.Event
+	Questions []domain.FormQuestion // Questions of the registration form, one answer column each
+	regRepo   *repository.RegistrationRepository
+	status    string
+}
+
+// Each passes the exported registrants to fn one at a time, oldest first
+func (e *RegistrantExport) Each(fn func(*domain.RegistrantRecord) error) error {
+	return e.regRepo.StreamEventRegistrants(e.Event.ID, e.status, fn)
+}
+
+// ExportEventRegistrants prepares an export of an event's registrants, optionally filtered by status
+// (event team only). Nothing is read until the export is streamed with Each.
+func (s *RegistrationService) ExportEventRegistrants(organizerID, eventID, status string) (*RegistrantExport, error) {
+	event, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionView, "export registrants")
+	if err != nil {
+		return nil, err
+	}
+	form, err := s.GetRegistrationForm(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrantExport{Event: event, Questions: form.Questions, regRepo: s.regRepo, status: status}, nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// SessionService manages the agenda of multi-session events: speakers, sessions and
+// attendees' seats in sessions with limited capacity.
+type SessionService struct {
+	sessionRepo  *repository.SessionRepository
+	eventRepo    *repository.EventRepository
+	eventService *EventService
+}
+
+func NewSessionService(sessionRepo *repository.SessionRepository, eventRepo *repository.EventRepository, eventService *EventService) *SessionService {
+	return &SessionService{
+		sessionRepo:  sessionRepo,
+		eventRepo:    eventRepo,
+		eventService: eventService,
+	}
+}
+
+// GetAgenda returns the sessions and speakers of an event to anyone who may see the event
+func (s *SessionService) GetAgenda(eventID, viewerID, accessCode string) (*domain.Agenda, error) {
+	if _, err := s.eventService.GetEventByID(eventID, viewerID, accessCode); err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionRepo.GetSessions(eventID)
+	if err != nil {
+		return nil, err
+	}
+	speakers, err := s.sessionRepo.GetSpeakers(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Agenda{EventID: eventID, Sessions: sessions, Speakers: speakers}, nil
+}
+
+// AddSpeaker adds a speaker to an event (organizers only)
+func (s *SessionService) AddSpeaker(userID, eventID string, req *domain.CreateSpeakerRequest) (*domain.Speaker, error) {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	speaker := &domain.Speaker{
+		ID:       uuid.NewString(),
+		EventID:  eventID,
+		Name:     req.Name,
+		Headline: req.Headline,
+		Bio:      req.Bio,
+		PhotoURL: req.PhotoURL,
+	}
+	if err := s.sessionRepo.CreateSpeaker(speaker); err != nil {
+		return nil, err
+	}
+	return speaker, nil
+}
+
+// UpdateSpeaker updates a speaker of an event (organizers only)
+func (s *SessionService) UpdateSpeaker(userID, eventID, speakerID string, req *domain.UpdateSpeakerRequest) (*domain.Speaker, error) {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+	speaker, err := s.sessionRepo.GetSpeaker(eventID, speakerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		speaker.Name = *req.Name
+	}
+	if req.Headline != nil {
+		speaker.Headline = *req.Headline
+	}
+	if req.Bio != nil {
+		speaker.Bio = *req.Bio
+	}
+	if req.PhotoURL != nil {
+		speaker.PhotoURL = *req.PhotoURL
+	}
+
+	if err := s.sessionRepo.UpdateSpeaker(speaker); err != nil {
+		return nil, err
+	}
+	return speaker, nil
+}
+
+// RemoveSpeaker removes a speaker from an event and its sessions (organizers only)
+func (s *SessionService) RemoveSpeaker(userID, eventID, speakerID string) error {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return err
+	}
+	return s.sessionRepo.DeleteSpeaker(eventID, speakerID)
+}
+
+// AddSession adds a session to an event's agenda (organizers only).
+// Overlapping sessions in the same room fail with domain.ErrSessionRoomConflict.
+func (s *SessionService) AddSession(userID, eventID string, req *domain.CreateSessionRequest) (*domain.Session, error) {
+	event, err := s.getManagedEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		ID:            uuid.NewString(),
+		EventID:       eventID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Track:         req.Track,
+		Room:          req.Room,
+		StartDatetime: req.StartDatetime,
+		EndDatetime:   req.EndDatetime,
+		Capacity:      req.Capacity,
+	}
+	if session.Speakers, err = s.getSpeakers(eventID, req.SpeakerIDs); err != nil {
+		return nil, err
+	}
+	if err := session.Validate(event); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.sessionRepo.SaveSession(session, true); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// UpdateSession updates a session of an event (organizers only).
+// The capacity can't be lowered below the seats already taken; a capacity of 0 removes it.
+func (s *SessionService) UpdateSession(userID, eventID, sessionID string, req *domain.UpdateSessionRequest) (*domain.Session, error) {
+	event, err := s.getManagedEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.sessionRepo.GetSession(eventID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		session.Title = *req.Title
+	}
+	if req.Description != nil {
+		session.Description = *req.Description
+	}
+	if req.Track != nil {
+		session.Track = *req.Track
+	}
+	if req.Room != nil {
+		session.Room = *req.Room
+	}
+	if req.StartDatetime != nil {
+		session.StartDatetime = *req.StartDatetime
+	}
+	if req.EndDatetime != nil {
+		session.EndDatetime = *req.EndDatetime
+	}
+	if req.Capacity != nil {
+		session.Capacity = req.Capacity
+		if *req.Capacity == 0 {
+			session.Capacity = nil
+		}
+	}
+	if req.SpeakerIDs != nil {
+		if session.Speakers, err = s.getSpeakers(eventID, *req.SpeakerIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := session.Validate(event); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.sessionRepo.SaveSession(session, false); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// RemoveSession removes a session and its registrations from an event (organizers only)
+func (s *SessionService) RemoveSession(userID, eventID, sessionID string) error {
+	if _, err := s.getManagedEvent(userID, eventID); err != nil {
+		return err
+	}
+	return s.sessionRepo.DeleteSession(eventID, sessionID)
+}
+
+// RegisterForSession books the user a seat in a session with limited capacity.
+// Only attendees holding a seat at the event can register, until the session starts;
+// a full session fails with domain.ErrSessionFull.
+func (s *SessionService) RegisterForSession(userID, eventID, sessionID string) (*domain.SessionRegistration, error) {
+	session, err := s.sessionRepo.GetSession(eventID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(session.StartDatetime) {
+		return nil, fmt.Errorf("the session has already started")
+	}
+
+	registration := &domain.SessionRegistration{
+		ID:        uuid.NewString(),
+		SessionID: session.ID,
+		UserID:    userID,
+	}
+	if err := s.sessionRepo.Register(session, registration); err != nil {
+		return nil, err
+	}
+	return registration, nil
+}
+
+// CancelSessionRegistration frees the user's seat in a session
+func (s *SessionService) CancelSessionRegistration(userID, eventID, sessionID string) error {
+	session, err := s.sessionRepo.GetSession(eventID, sessionID)
+	if err != nil {
+		return err
+	}
+	return s.sessionRepo.Unregister(session.ID, userID)
+}
+
+// getSpeakers resolves speaker IDs to speakers of the event; unknown IDs fail
+func (s *SessionService) getSpeakers(eventID string, ids []string) ([]domain.Speaker, error) {
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	speakers, err := s.sessionRepo.GetSpeakersByIDs(eventID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(speakers) != len(ids) {
+		return nil, fmt.Errorf("speaker not found")
+	}
+	return speakers, nil
+}
+
+func (s *SessionService) getManagedEvent(userID, eventID string) (*domain.Event, error) {
+	return authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "manage the agenda")
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/cache"
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+)
+
+// statsCacheTTL is how long computed stats are served from the cache. Kept short so that
+// dashboards follow registrations and check-ins closely without recounting on every refresh.
+const statsCacheTTL = time.Minute
+
+// StatsService serves the analytics dashboards of organizers
+type StatsService struct {
+	statsRepo *repository.StatsRepository
+	eventRepo *repository.EventRepository
+	cache     *cache.RedisCache // optional
+}
+
+func NewStatsService(statsRepo *repository.StatsRepository, eventRepo *repository.EventRepository, cache *cache.RedisCache) *StatsService {
+	return &StatsService{
+		statsRepo: statsRepo,
+		eventRepo: eventRepo,
+		cache:     cache,
+	}
+}
+
+// GetEventStats returns the dashboard of an event to its team
+func (s *StatsService) GetEventStats(userID, eventID string) (*domain.EventStats, error) {
+	// Access is checked before the cache is looked at: cached stats are shared by the whole team
+	event, err := authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionView, "view event stats")
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("stats:event:%s", eventID)
+	var stats domain.EventStats
+	if s.getCached(cacheKey, &stats) {
+		return &stats, nil
+	}
+
+	computed, err := s.statsRepo.GetEventStats(event, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event stats: %w", err)
+	}
+	s.setCached(cacheKey, computed)
+	return computed, nil
+}
+
+// GetOrganizerStats returns the dashboard of all events the user organizes or runs with their event
+// teams and organizations (those whose stats GetEventStats shows them), optionally only
+// those starting between query.From and query.To (both days included)
+func (s *StatsService) GetOrganizerStats(userID string, query *domain.OrganizerStatsQuery) (*domain.OrganizerStats, error) {
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+
+	cacheKey := fmt.Sprintf("stats:organizer:%s:%s:%s", userID, formatDay(query.From), formatDay(query.To))
+	var stats domain.OrganizerStats
+	if s.getCached(cacheKey, &stats) {
+		return &stats, nil
+	}
+
+	computed, err := s.statsRepo.GetOrganizerStats(userID, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer stats: %w", err)
+	}
+	s.setCached(cacheKey, computed)
+	return computed, nil
+}
+
+// getCached reports whether the value was found in the cache
+func (s *StatsService) getCached(key string, dest interface{}) bool {
+	if s.cache == nil {
+		return false
+	}
+	return s.cache.Get(context.Background(), key, dest) == nil
+}
+
+// setCached caches computed stats (ignore error)
+func (s *StatsService) setCached(key string, value interface{}) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Set(context.Background(), key, value, statsCacheTTL); err != nil {
+		fmt.Printf("failed to set cache for %s: %v\n", key, err)
+	}
+}
+
+// formatDay formats an optional day for cache keys
+func formatDay(day *time.Time) string {
+	if day == nil {
+		return ""
+	}
+	return day.Format("2006-01-02")
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type TicketService struct {
+	ticketRepo *repository.TicketTypeRepository
+	eventRepo  *repository.EventRepository
+}
+
+func NewTicketService(ticketRepo *repository.TicketTypeRepository, eventRepo *repository.EventRepository) *TicketService {
+	return &TicketService{
+		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+// GetTicketTypes returns all ticket types of an event with the number of seats sold
+func (s *TicketService) GetTicketTypes(eventID string) ([]domain.TicketType, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+	return s.ticketRepo.GetByEvent(eventID)
+}
+
+// CreateTicketType adds a ticket tier to an event (organizer only)
+func (s *TicketService) CreateTicketType(userID, eventID string, req *domain.CreateTicketTypeRequest) (*domain.TicketType, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	ticketType := &domain.TicketType{
+		ID:          uuid.NewString(),
+		EventID:     eventID,
+		Name:        req.Name,
+		Description: req.Description,
+		PriceCents:  req.PriceCents,
+		Currency:    req.Currency,
+		Quantity:    req.Quantity,
+		SalesStart:  req.SalesStart,
+		SalesEnd:    req.SalesEnd,
+		MaxPerOrder: req.MaxPerOrder,
+	}
+	if err := ticketType.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.ticketRepo.Create(ticketType); err != nil {
+		return nil, err
+	}
+	return ticketType, nil
+}
+
+// UpdateTicketType updates a ticket tier (organizer only).
+// The quantity cannot be lowered below the number of seats already sold (checked when saving,
+// under the same lock as registrations).
+func (s *TicketService) UpdateTicketType(userID, eventID, ticketTypeID string, req *domain.UpdateTicketTypeRequest) (*domain.TicketType, error) {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return nil, err
+	}
+
+	ticketType, err := s.ticketRepo.GetByID(eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		ticketType.Name = *req.Name
+	}
+	if req.Description != nil {
+		ticketType.Description = *req.Description
+	}
+	if req.PriceCents != nil {
+		ticketType.PriceCents = *req.PriceCents
+	}
+	if req.Currency != nil {
+		ticketType.Currency = *req.Currency
+	}
+	if req.Quantity != nil {
+		ticketType.Quantity = *req.Quantity
+	}
+	if req.SalesStart != nil {
+		ticketType.SalesStart = req.SalesStart
+	}
+	if req.SalesEnd != nil {
+		ticketType.SalesEnd = req.SalesEnd
+	}
+	if req.MaxPerOrder != nil {
+		ticketType.MaxPerOrder = *req.MaxPerOrder
+	}
+
+	if err := ticketType.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.ticketRepo.Update(ticketType); err != nil {
+		return nil, err
+	}
+	return ticketType, nil
+}
+
+// DeleteTicketType removes a ticket tier (organizer only).
+// Existing registrations keep referencing the deleted tier.
+func (s *TicketService) DeleteTicketType(userID, eventID, ticketTypeID string) error {
+	if _, err := s.getOwnedEvent(userID, eventID); err != nil {
+		return err
+	}
+	return s.ticketRepo.Delete(eventID, ticketTypeID)
+}
+
+// getOwnedEvent loads an event and checks that the user organizes it
+func (s *TicketService) getOwnedEvent(userID, eventID string) (*domain.Event, error) {
+	return authorizeEvent(s.eventRepo, userID, eventID, domain.EventActionManage, "manage ticket types")
+}
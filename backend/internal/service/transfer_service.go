@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type TransferService struct {
+	transferRepo  *repository.RegistrationTransferRepository
+	regRepo       *repository.RegistrationRepository
+	eventRepo     *repository.EventRepository
+	userRepo      *repository.UserRepository
+	notifications *NotificationService
+}
+
+func NewTransferService(transferRepo *repository.RegistrationTransferRepository, regRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, userRepo *repository.UserRepository, notifications *NotificationService) *TransferService {
+	return &TransferService{
+		transferRepo:  transferRepo,
+		regRepo:       regRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+	}
+}
+
+// CreateTransfer invites someone, by email, to take over one of the user's confirmed seats.
+// The seat stays with the user until the recipient accepts. The invitation expires after
+// TransferInvitationTTL or when the event starts, whichever comes first.
+func (s *TransferService) CreateTransfer(userID, registrationID string, req *domain.CreateTransferRequest) (*domain.RegistrationTransfer, error) {
+	registration, err := s.regRepo.GetUserRegistration(userID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status != domain.RegistrationStatusConfirmed {
+		return nil, fmt.Errorf("only confirmed registrations can be transferred (current status: %s)", registration.Status)
+	}
+
+	event, err := s.eventRepo.GetByID(registration.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+	if !event.AllowTransfers {
+		return nil, domain.ErrTransfersDisabled
+	}
+	now := time.Now()
+	if !now.Before(event.StartDatetime) {
+		return nil, fmt.Errorf("registrations cannot be transferred once the event has started")
+	}
+
+	sender, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == strings.ToLower(sender.Email) {
+		return nil, fmt.Errorf("cannot transfer a registration to yourself")
+	}
+
+	pending, err := s.transferRepo.GetPendingByRegistration(registration.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		if pending.IsOpen(now) {
+			return nil, fmt.Errorf("a transfer of this registration is already pending")
+		}
+		// Lapsed invitations are closed so the seat can be offered again
+		if err := s.transferRepo.Close(pending, domain.TransferStatusCancelled); err != nil {
+			return nil, err
+		}
+	}
+
+	expiresAt := now.Add(domain.TransferInvitationTTL)
+	if event.StartDatetime.Before(expiresAt) {
+		expiresAt = event.StartDatetime
+	}
+	transfer := &domain.RegistrationTransfer{
+		ID:             uuid.NewString(),
+		RegistrationID: registration.ID,
+		EventID:        event.ID,
+		FromUserID:     userID,
+		ToEmail:        email,
+		Status:         domain.TransferStatusPending,
+		ExpiresAt:      expiresAt,
+	}
+	if err := s.transferRepo.Create(transfer); err != nil {
+		return nil, err
+	}
+
+	// Recipients without an account see the invitation once they sign up with that email
+	if recipient, err := s.userRepo.GetByEmail(email); err == nil {
+		s.notifications.Notify(recipient.ID, "Ticket transfer",
+			fmt.Sprintf("%s wants to transfer their ticket for %q to you.", sender.Name, event.Title))
+	}
+	return transfer, nil
+}
+
+// AcceptTransfer moves the seat to the recipient, who must be signed in with the invited email.
+// The previous owner's ticket token stops working and a new one is issued.
+func (s *TransferService) AcceptTransfer(userID, transferID string) (*domain.Registration, error) {
+	user, transfer, err := s.getIncomingTransfer(userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.IsOpen(time.Now()) {
+		return nil, fmt.Errorf("transfer is no longer pending")
+	}
+	if transfer.Event == nil || !transfer.Event.AllowTransfers {
+		return nil, domain.ErrTransfersDisabled
+	}
+
+	registration, err := s.transferRepo.Accept(transfer, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifications.Notify(transfer.FromUserID, "Ticket transferred",
+		fmt.Sprintf("Your ticket for %q now belongs to %s.", transfer.Event.Title, transfer.ToEmail))
+	return registration, nil
+}
+
+// DeclineTransfer turns down a transfer invitation; the seat stays with its owner
+func (s *TransferService) DeclineTransfer(userID, transferID string) (*domain.RegistrationTransfer, error) {
+	_, transfer, err := s.getIncomingTransfer(userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transferRepo.Close(transfer, domain.TransferStatusDeclined); err != nil {
+		return nil, err
+	}
+
+	if transfer.Event != nil {
+		s.notifications.Notify(transfer.FromUserID, "Ticket transfer declined",
+			fmt.Sprintf("%s declined your ticket for %q.", transfer.ToEmail, transfer.Event.Title))
+	}
+	return transfer, nil
+}
+
+// CancelTransfer withdraws a pending transfer invitation (sender only)
+func (s *TransferService) CancelTransfer(userID, transferID string) (*domain.RegistrationTransfer, error) {
+	transfer, err := s.transferRepo.GetByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID != userID {
+		return nil, fmt.Errorf("transfer not found")
+	}
+	if err := s.transferRepo.Close(transfer, domain.TransferStatusCancelled); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetUserTransfers returns the transfers the user sent or was invited to
+func (s *TransferService) GetUserTransfers(userID string) ([]domain.RegistrationTransfer, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.transferRepo.GetUserTransfers(userID, strings.ToLower(user.Email))
+}
+
+// GetEventTransfers returns the audit trail of all transfers of an event's seats (event team only)
+func (s *TransferService) GetEventTransfers(organizerID, eventID string) ([]domain.RegistrationTransfer, error) {
+	if _, err := authorizeEvent(s.eventRepo, organizerID, eventID, domain.EventActionView, "view transfers"); err != nil {
+		return nil, err
+	}
+	return s.transferRepo.GetByEvent(eventID)
+}
+
+// getIncomingTransfer loads a transfer addressed to the user's email
+func (s *TransferService) getIncomingTransfer(userID, transferID string) (*domain.User, *domain.RegistrationTransfer, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transfer, err := s.transferRepo.GetByID(transferID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transfer.ToEmail != strings.ToLower(user.Email) {
+		return nil, nil, fmt.Errorf("transfer not found")
+	}
+	return user, transfer, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
+)
+
+// purgeBatchSize limits how many events, and how many users, one purge run deletes
+const purgeBatchSize = 100
+
+// TrashPurger deletes for good the events, users and registrations that have been in the trash
+// longer than the retention period (run periodically). Events go with their registrations, users with
+// the events they organize (except organization events, which are handed to another organization admin)
+// and their registrations; the objects of purged event files are removed from
+// the blob store.
+type TrashPurger struct {
+	trashRepo *repository.TrashRepository
+	store     storage.BlobStore // optional
+	retention time.Duration
+}
+
+func NewTrashPurger(trashRepo *repository.TrashRepository, store storage.BlobStore, retention time.Duration) *TrashPurger {
+	return &TrashPurger{
+		trashRepo: trashRepo,
+		store:     store,
+		retention: retention,
+	}
+}
+
+// PurgeExpired deletes what has been in the trash longer than the retention period and returns what was deleted.
+// A user that can't be purged (e.g. the last admin of an organization with events) is logged and skipped.
+func (p *TrashPurger) PurgeExpired(ctx context.Context) (*domain.PurgeResult, error) {
+	before := time.Now().Add(-p.retention)
+	purged := &domain.PurgeResult{}
+	defer p.deleteObjects(purged)
+
+	events, err := p.trashRepo.PurgeEvents(before, purgeBatchSize)
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge events: %w", err)
+	}
+	purged.Add(events)
+
+	userIDs, err := p.trashRepo.ExpiredUsers(before, purgeBatchSize)
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge users: %w", err)
+	}
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return purged, nil
+		}
+		user, err := p.trashRepo.PurgeUser(userID)
+		if err != nil {
+			log.Printf("failed to purge user %s: %v", userID, err)
+			continue
+		}
+		purged.Add(user)
+	}
+
+	registrations, err := p.trashRepo.PurgeRegistrations(before)
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge registrations: %w", err)
+	}
+	purged.Registrations += registrations
+	return purged, nil
+}
+
+// deleteObjects removes the objects of purged files from the blob store. Failures only leave
+// unreferenced objects behind, so they are logged.
+func (p *TrashPurger) deleteObjects(purged *domain.PurgeResult) {
+	if p.store == nil {
+		return
+	}
+	for _, key := range purged.FileKeys {
+		if err := p.store.Delete(context.Background(), key); err != nil {
+			log.Printf("failed to delete object %s: %v", key, err)
+		}
+	}
+}
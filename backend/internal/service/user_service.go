@@ -77,6 +77,9 @@ func (s *UserService) UpdateMe(userID string, req *domain.UpdateUserRequest) (*d
 		}
 		user.PasswordHash = hashed
 	}
+	if req.StrictSchedule != nil {
+		user.StrictSchedule = *req.StrictSchedule
+	}
 
 	if err := s.userRepo.Update(user); err != nil {
 		return nil, err
@@ -84,6 +87,24 @@ func (s *UserService) UpdateMe(userID string, req *domain.UpdateUserRequest) (*d
 	return user, nil
 }
 
+// GetTrash returns the deleted user accounts, until they are restored or purged (admin operation)
+func (s *UserService) GetTrash() ([]domain.TrashedUser, error) {
+	users, err := s.userRepo.GetTrash()
+	if err != nil {
+		return nil, err
+	}
+	trash := make([]domain.TrashedUser, len(users))
+	for i, user := range users {
+		trash[i] = domain.TrashedUser{User: user, DeletedAt: user.DeletedAt.Time}
+	}
+	return trash, nil
+}
+
+// RestoreUser takes a deleted user account back out of the trash (admin operation)
+func (s *UserService) RestoreUser(userID string) (*domain.User, error) {
+	return s.userRepo.Restore(userID)
+}
+
 func (s *UserService) GetMe(userID string) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
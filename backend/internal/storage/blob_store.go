@@ -0,0 +1,41 @@
+// Package storage defines the abstraction over blob storage for uploaded files, with a
+// local filesystem implementation and one for S3-compatible object stores (AWS S3, MinIO, ...).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound         = errors.New("object not found")
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// BlobStore is implemented by every storage backend.
+// Implementations must be safe for concurrent use.
+type BlobStore interface {
+	// Name identifies the backend
+	Name() string
+	// Put stores size bytes read from body under key, replacing any object already there
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key; a missing object fails with ErrNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL granting read access to the object until expires
+	SignedURL(key string, expires time.Time) (string, error)
+}
+
+// validateKey rejects keys that could escape the store's namespace
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutGetDeleteAndSignedURLs(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:8080/", "secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "events/1/agenda.pdf", strings.NewReader("%PDF-1.4"), 8, "application/pdf"))
+	assert.Error(t, store.Put(ctx, "events/1/short.pdf", strings.NewReader("%PDF"), 8, "application/pdf"))
+	assert.Error(t, store.Put(ctx, "../outside", strings.NewReader("x"), 1, "text/plain"))
+
+	body, err := store.Get(ctx, "events/1/agenda.pdf")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	expires := time.Now().Add(time.Minute)
+	signed, err := store.SignedURL("events/1/agenda.pdf", expires)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/files/events/1/agenda.pdf", u.Path)
+	query := u.Query()
+
+	assert.NoError(t, store.VerifySignature("events/1/agenda.pdf", query.Get("expires"), query.Get("signature"), time.Now()))
+	// another object, a tampered expiry, a late request or another secret
+	assert.ErrorIs(t, store.VerifySignature("events/1/other.pdf", query.Get("expires"), query.Get("signature"), time.Now()), ErrInvalidSignature)
+	assert.ErrorIs(t, store.VerifySignature("events/1/agenda.pdf", "9999999999", query.Get("signature"), time.Now()), ErrInvalidSignature)
+	assert.ErrorIs(t, store.VerifySignature("events/1/agenda.pdf", query.Get("expires"), query.Get("signature"), expires.Add(time.Second)), ErrInvalidSignature)
+	other, err := NewLocalStore(t.TempDir(), "http://localhost:8080", "other-secret")
+	require.NoError(t, err)
+	assert.ErrorIs(t, other.VerifySignature("events/1/agenda.pdf", query.Get("expires"), query.Get("signature"), time.Now()), ErrInvalidSignature)
+
+	require.NoError(t, store.Delete(ctx, "events/1/agenda.pdf"))
+	require.NoError(t, store.Delete(ctx, "events/1/agenda.pdf"))
+	_, err = store.Get(ctx, "events/1/agenda.pdf")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 400; y++ {
+			src.Set(x, y, color.NRGBA{R: 255, A: 255}) // left half red, right half transparent
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	thumb, err := MakeThumbnail(&buf, 200)
+	require.NoError(t, err)
+	img, format, err := image.Decode(bytes.NewReader(thumb))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, image.Rect(0, 0, 200, 100), img.Bounds())
+
+	r, g, b, _ := img.At(20, 50).RGBA()
+	assert.True(t, r>>8 > 200 && g>>8 < 60 && b>>8 < 60, "expected red, got %d/%d/%d", r>>8, g>>8, b>>8)
+	r, g, b, _ = img.At(180, 50).RGBA()
+	assert.True(t, r>>8 > 200 && g>>8 > 200 && b>>8 > 200, "expected white, got %d/%d/%d", r>>8, g>>8, b>>8)
+
+	_, err = MakeThumbnail(strings.NewReader("%PDF-1.4"), 200)
+	assert.Error(t, err)
+}
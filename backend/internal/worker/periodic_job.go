@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PeriodicJob runs a task at a fixed interval in the background (e.g. releasing expired holds)
+type PeriodicJob struct {
+	Name     string
+	Interval time.Duration
+	run      func(ctx context.Context) error
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewPeriodicJob creates a new periodic job
+func NewPeriodicJob(name string, interval time.Duration, run func(ctx context.Context) error) *PeriodicJob {
+	return &PeriodicJob{
+		Name:     name,
+		Interval: interval,
+		run:      run,
+	}
+}
+
+// Start runs the job in a goroutine until Stop is called
+func (j *PeriodicJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		ticker := time.NewTicker(j.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.run(ctx); err != nil {
+					fmt.Printf("[Job %s] failed: %v\n", j.Name, err)
+				}
+			}
+		}
+	}()
+	fmt.Printf("Job %s started (every %s)\n", j.Name, j.Interval)
+}
+
+// Stop signals the job to stop and waits for a running iteration to finish
+func (j *PeriodicJob) Stop() {
+	if j.cancel == nil {
+		return
+	}
+	j.cancel()
+	j.wg.Wait()
+	j.cancel = nil
+	fmt.Printf("Job %s stopped\n", j.Name)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS event_tags;
+DROP TABLE IF EXISTS tags;
+ALTER TABLE events DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,2 @@
+ALTER TABLE registrations DROP COLUMN IF EXISTS ticket_type_id;
+DROP TABLE IF EXISTS ticket_types;
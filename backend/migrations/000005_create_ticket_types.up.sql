@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS ticket_types (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    price_cents BIGINT NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    quantity INT NOT NULL CHECK (quantity > 0),
+    sales_start TIMESTAMP WITH TIME ZONE,
+    sales_end TIMESTAMP WITH TIME ZONE,
+    max_per_order INT NOT NULL DEFAULT 10 CHECK (max_per_order > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_ticket_types_event_id ON ticket_types(event_id);
+
+ALTER TABLE registrations ADD COLUMN ticket_type_id UUID REFERENCES ticket_types(id);
+CREATE INDEX idx_registrations_ticket_type_id ON registrations(ticket_type_id);
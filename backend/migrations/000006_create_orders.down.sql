@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_registrations_active_user_event;
+DELETE FROM registrations WHERE status IN ('pending_payment', 'expired');
+ALTER TABLE registrations ADD CONSTRAINT registrations_user_id_event_id_key UNIQUE (user_id, event_id);
+ALTER TABLE registrations DROP COLUMN IF EXISTS order_id;
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_type_id UUID NOT NULL REFERENCES ticket_types(id),
+    quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, paid, failed, expired, cancelled, refunded
+    provider VARCHAR(50) NOT NULL,
+    provider_intent_id VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_orders_user_id ON orders(user_id);
+CREATE INDEX idx_orders_event_id ON orders(event_id);
+CREATE UNIQUE INDEX idx_orders_provider_intent_id ON orders(provider_intent_id);
+CREATE INDEX idx_orders_pending_expiry ON orders(expires_at) WHERE status = 'pending';
+
+ALTER TABLE registrations ADD COLUMN order_id UUID REFERENCES orders(id) ON DELETE SET NULL;
+CREATE INDEX idx_registrations_order_id ON registrations(order_id);
+
+-- A user may register again once an earlier registration was cancelled or its payment hold expired,
+-- so uniqueness only applies to active registrations.
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_user_id_event_id_key;
+CREATE UNIQUE INDEX idx_registrations_active_user_event ON registrations(user_id, event_id)
+    WHERE status IN ('pending_payment', 'confirmed', 'checked_in') AND deleted_at IS NULL;
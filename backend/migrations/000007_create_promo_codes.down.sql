@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_cents;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code_id;
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_code_ticket_types;
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL, -- stored upper-cased, matched case-insensitively
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value BIGINT NOT NULL CHECK (discount_value > 0), -- percent (1-100) or minor units
+    max_redemptions INT CHECK (max_redemptions > 0), -- NULL = unlimited
+    per_user_limit INT NOT NULL DEFAULT 1 CHECK (per_user_limit > 0),
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_promo_codes_event_code ON promo_codes(event_id, code) WHERE deleted_at IS NULL;
+
+-- Ticket types a code is restricted to (no rows = valid for every ticket type)
+CREATE TABLE IF NOT EXISTS promo_code_ticket_types (
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    ticket_type_id UUID NOT NULL REFERENCES ticket_types(id) ON DELETE CASCADE,
+    PRIMARY KEY (promo_code_id, ticket_type_id)
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    registration_id UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    discount_cents BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_promo_redemptions_promo_code_id ON promo_redemptions(promo_code_id);
+CREATE INDEX idx_promo_redemptions_user_id ON promo_redemptions(user_id);
+
+ALTER TABLE orders ADD COLUMN promo_code_id UUID REFERENCES promo_codes(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN discount_cents BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_cents;
+ALTER TABLE registrations DROP COLUMN IF EXISTS cancelled_at;
+ALTER TABLE registrations DROP COLUMN IF EXISTS refund_cents;
+DROP TABLE IF EXISTS cancellation_policies;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS cancellation_policies (
+    event_id UUID PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    allow_cancellation BOOLEAN NOT NULL DEFAULT TRUE,
+    cutoff_hours INT NOT NULL DEFAULT 0 CHECK (cutoff_hours >= 0), -- no cancellations within N hours of the start
+    refund_tiers JSONB NOT NULL DEFAULT '[]', -- [{"hours_before": 168, "refund_percent": 100}, ...]
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE registrations ADD COLUMN refund_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE registrations ADD COLUMN cancelled_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE orders ADD COLUMN refunded_cents BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_registrations_active_user_event;
+DELETE FROM registrations WHERE status IN ('pending', 'rejected');
+CREATE UNIQUE INDEX idx_registrations_active_user_event ON registrations(user_id, event_id)
+    WHERE status IN ('pending_payment', 'confirmed', 'checked_in') AND deleted_at IS NULL;
+
+ALTER TABLE registrations DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE registrations DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE registrations DROP COLUMN IF EXISTS reviewed_by;
+
+ALTER TABLE events DROP COLUMN IF EXISTS requires_approval;
@@ -0,0 +1,10 @@
+ALTER TABLE events ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE registrations ADD COLUMN reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE registrations ADD COLUMN reviewed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE registrations ADD COLUMN review_reason TEXT;
+
+-- Applications awaiting approval also block a second registration of the same user
+DROP INDEX IF EXISTS idx_registrations_active_user_event;
+CREATE UNIQUE INDEX idx_registrations_active_user_event ON registrations(user_id, event_id)
+    WHERE status IN ('pending', 'pending_payment', 'confirmed', 'checked_in') AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE registrations DROP COLUMN IF EXISTS answers;
+
+DROP TABLE IF EXISTS registration_forms;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS registration_forms (
+    event_id UUID PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    questions JSONB NOT NULL DEFAULT '[]', -- [{"id": "tshirt_size", "label": "...", "type": "single_choice", "required": true, "options": [...]}, ...]
+    answers_editable_until TIMESTAMP WITH TIME ZONE, -- NULL = until the event starts
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE registrations ADD COLUMN answers JSONB; -- {"tshirt_size": "M", "dietary": ["vegan"], ...}
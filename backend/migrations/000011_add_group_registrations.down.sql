@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_registrations_active_user_event;
+DELETE FROM registrations WHERE is_guest;
+CREATE UNIQUE INDEX idx_registrations_active_user_event ON registrations(user_id, event_id)
+    WHERE status IN ('pending', 'pending_payment', 'confirmed', 'checked_in') AND deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_registrations_group_id;
+
+ALTER TABLE registrations DROP COLUMN IF EXISTS guest_email;
+ALTER TABLE registrations DROP COLUMN IF EXISTS guest_name;
+ALTER TABLE registrations DROP COLUMN IF EXISTS is_guest;
+ALTER TABLE registrations DROP COLUMN IF EXISTS group_id;
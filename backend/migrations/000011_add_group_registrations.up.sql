@@ -0,0 +1,13 @@
+-- A booking of several seats stores one registration per seat, linked by group_id.
+-- Guest seats belong to the booking user but carry the guest's name and email.
+ALTER TABLE registrations ADD COLUMN group_id UUID;
+ALTER TABLE registrations ADD COLUMN is_guest BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE registrations ADD COLUMN guest_name VARCHAR(255);
+ALTER TABLE registrations ADD COLUMN guest_email VARCHAR(255);
+
+CREATE INDEX idx_registrations_group_id ON registrations(group_id);
+
+-- Only the booking user's own seat is limited to one active registration per event
+DROP INDEX IF EXISTS idx_registrations_active_user_event;
+CREATE UNIQUE INDEX idx_registrations_active_user_event ON registrations(user_id, event_id)
+    WHERE status IN ('pending', 'pending_payment', 'confirmed', 'checked_in') AND NOT is_guest AND deleted_at IS NULL;
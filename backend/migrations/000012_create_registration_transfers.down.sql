@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS registration_transfers;
+
+DROP INDEX IF EXISTS idx_registrations_ticket_token;
+ALTER TABLE registrations DROP COLUMN IF EXISTS ticket_token;
+
+ALTER TABLE events DROP COLUMN IF EXISTS allow_transfers;
@@ -0,0 +1,26 @@
+ALTER TABLE events ADD COLUMN allow_transfers BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Every seat gets a ticket token (shown on the ticket, used at the door); transfers replace it
+ALTER TABLE registrations ADD COLUMN ticket_token VARCHAR(64);
+UPDATE registrations SET ticket_token = replace(uuid_generate_v4()::text, '-', '') WHERE ticket_token IS NULL;
+CREATE UNIQUE INDEX idx_registrations_ticket_token ON registrations(ticket_token);
+
+-- Transfers are kept as an audit trail of every handover attempt
+CREATE TABLE IF NOT EXISTS registration_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    registration_id UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_email VARCHAR(255) NOT NULL,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- set once accepted
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, declined, cancelled
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_registration_transfers_event_id ON registration_transfers(event_id);
+CREATE INDEX idx_registration_transfers_to_email ON registration_transfers(to_email);
+-- At most one open transfer per seat
+CREATE UNIQUE INDEX idx_registration_transfers_pending ON registration_transfers(registration_id) WHERE status = 'pending';
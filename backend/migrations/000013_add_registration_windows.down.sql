@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN IF EXISTS registration_closes_at;
+ALTER TABLE events DROP COLUMN IF EXISTS registration_opens_at;
@@ -0,0 +1,3 @@
+-- Registration window; NULL opens registration on publish and closes it when the event starts
+ALTER TABLE events ADD COLUMN registration_opens_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN registration_closes_at TIMESTAMP WITH TIME ZONE;
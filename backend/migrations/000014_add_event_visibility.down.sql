@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS event_invitations;
+
+DROP INDEX IF EXISTS idx_events_slug;
+ALTER TABLE events DROP COLUMN IF EXISTS slug;
+ALTER TABLE events DROP COLUMN IF EXISTS access_code;
+ALTER TABLE events DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,23 @@
+ALTER TABLE events ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+ALTER TABLE events ADD COLUMN access_code VARCHAR(64);
+
+-- Share link slug: the title in URL form plus a random suffix
+ALTER TABLE events ADD COLUMN slug VARCHAR(100);
+UPDATE events
+SET slug = COALESCE(NULLIF(trim(both '-' from left(regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 80)), '') || '-', '')
+    || left(md5(id::text || random()::text), 10)
+WHERE slug IS NULL;
+ALTER TABLE events ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX idx_events_slug ON events(slug);
+
+-- Invitations to private events, by email (the invitee may not have an account yet)
+CREATE TABLE IF NOT EXISTS event_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL, -- lowercased
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_event_invitations_event_email ON event_invitations(event_id, email);
+CREATE INDEX idx_event_invitations_email ON event_invitations(email);
@@ -0,0 +1,14 @@
+-- Event teams: roles of users other than the organizer on an event.
+-- The organizer (events.organizer_id) is always an owner and has no row here.
+CREATE TABLE IF NOT EXISTS event_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'co_organizer', 'checkin_staff', 'viewer')),
+    added_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_event_members_event_user ON event_members(event_id, user_id);
+CREATE INDEX idx_event_members_user ON event_members(user_id);
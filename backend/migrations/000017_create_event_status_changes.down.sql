@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_published_end;
+DROP TABLE IF EXISTS event_status_changes;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_status;
@@ -0,0 +1,18 @@
+-- Event lifecycle: draft -> published <-> postponed, published -> completed, cancelled from any non-final status
+ALTER TABLE events ADD CONSTRAINT chk_events_status
+    CHECK (status IN ('draft', 'published', 'postponed', 'completed', 'cancelled')) NOT VALID;
+
+-- History of every status change; changed_by is NULL for automatic changes (e.g. completion)
+CREATE TABLE IF NOT EXISTS event_status_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_status_changes_event_id ON event_status_changes(event_id, created_at);
+-- Speeds up the auto-completion job
+CREATE INDEX idx_events_published_end ON events(end_datetime) WHERE status = 'published';
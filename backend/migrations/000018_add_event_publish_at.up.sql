@@ -0,0 +1,3 @@
+-- Scheduled publishing: drafts with a publish_at go live at that time
+ALTER TABLE events ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_events_publish_at ON events(publish_at) WHERE status = 'draft' AND publish_at IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Saved event details organizers reuse to create similar events
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    location VARCHAR(255) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    tags JSONB NOT NULL DEFAULT '[]',
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_transfers BOOLEAN NOT NULL DEFAULT TRUE,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public' CHECK (visibility IN ('public', 'unlisted', 'private')),
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_templates_organizer_id ON event_templates(organizer_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS session_registrations;
+DROP TABLE IF EXISTS session_speakers;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS speakers;
@@ -0,0 +1,45 @@
+-- Agendas of multi-session events: speakers, sessions and seats in sessions with limited capacity
+CREATE TABLE IF NOT EXISTS speakers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    headline VARCHAR(255),
+    bio TEXT,
+    photo_url VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_speakers_event_id ON speakers(event_id);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    track VARCHAR(100),
+    room VARCHAR(100),
+    start_datetime TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_datetime TIMESTAMP WITH TIME ZONE NOT NULL,
+    capacity INTEGER CHECK (capacity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_datetime > start_datetime)
+);
+
+CREATE INDEX idx_sessions_event_start ON sessions(event_id, start_datetime);
+
+CREATE TABLE IF NOT EXISTS session_speakers (
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    speaker_id UUID NOT NULL REFERENCES speakers(id) ON DELETE CASCADE,
+    PRIMARY KEY (session_id, speaker_id)
+);
+
+CREATE TABLE IF NOT EXISTS session_registrations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_session_registrations_session_user ON session_registrations(session_id, user_id);
@@ -0,0 +1,2 @@
+-- Users in strict mode can't register for events that overlap events they are attending
+ALTER TABLE users ADD COLUMN strict_schedule BOOLEAN NOT NULL DEFAULT FALSE;
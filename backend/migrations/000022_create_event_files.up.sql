@@ -0,0 +1,17 @@
+-- Files of events: the cover image and attachments, whose content lives in the blob store
+CREATE TABLE IF NOT EXISTS event_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('cover', 'attachment')),
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    storage_key VARCHAR(500) NOT NULL,
+    thumbnail_key VARCHAR(500),
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_files_event_id ON event_files(event_id);
+-- One cover image per event
+CREATE UNIQUE INDEX idx_event_files_cover ON event_files(event_id) WHERE kind = 'cover';
@@ -0,0 +1,2 @@
+-- Optimistic concurrency: every change to an event increments its version, sent to clients as the ETag
+ALTER TABLE events ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+-- Field-level history of every change to an event; actor_id is NULL for automatic changes (e.g. completion)
+CREATE TABLE IF NOT EXISTS event_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    version BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    reverted_to BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_revisions_event_version ON event_revisions(event_id, version);
@@ -0,0 +1,2 @@
+-- When the seat was checked in (NULL for seats checked in before this was recorded)
+ALTER TABLE registrations ADD COLUMN checked_in_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,3 @@
+-- Lease of the scheduler replica publishing a due draft: others skip the event until it expires,
+-- so a replica that dies mid-publish only delays the event instead of losing its schedule
+ALTER TABLE events ADD COLUMN publish_claimed_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,6 @@
+-- Refunds are recorded on the order (refunded_cents) before they are sent to the provider;
+-- refund_issued_cents is the part already sent, so a failed refund can be retried without paying twice
+ALTER TABLE orders ADD COLUMN refund_issued_cents BIGINT NOT NULL DEFAULT 0;
+
+-- Refunds recorded so far were sent before being recorded
+UPDATE orders SET refund_issued_cents = refunded_cents;
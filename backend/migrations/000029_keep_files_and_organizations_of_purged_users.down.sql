@@ -0,0 +1,7 @@
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_created_by_fkey;
+ALTER TABLE organizations ADD CONSTRAINT organizations_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES users(id);
+
+ALTER TABLE event_files DROP CONSTRAINT IF EXISTS event_files_uploaded_by_fkey;
+ALTER TABLE event_files ADD CONSTRAINT event_files_uploaded_by_fkey
+    FOREIGN KEY (uploaded_by) REFERENCES users(id);
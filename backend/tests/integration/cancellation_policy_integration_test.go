@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellationPolicy_RefundsPaidRegistrations(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+	paymentService := service.NewPaymentService(orderRepo, payment.NewFakeProvider("test-secret", ""), 15*time.Minute)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo,
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), paymentService, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	// the event starts in three days
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"start_datetime": time.Now().Add(72 * time.Hour),
+		"end_datetime":   time.Now().Add(75 * time.Hour),
+	}).Error)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+	_, err := service.NewTicketService(ticketRepo, eventRepo).CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "General", PriceCents: 8000, Quantity: 10,
+	})
+	require.NoError(t, err)
+
+	allow := true
+	_, err = regService.SetCancellationPolicy(uuid.NewString(), event.ID, &domain.UpdateCancellationPolicyRequest{AllowCancellation: &allow})
+	require.ErrorContains(t, err, "only the event organizer")
+
+	policy, err := regService.SetCancellationPolicy(organizerID, event.ID, &domain.UpdateCancellationPolicyRequest{
+		AllowCancellation: &allow,
+		CutoffHours:       24,
+		RefundTiers: []domain.RefundTier{
+			{HoursBefore: 48, RefundPercent: 50},
+			{HoursBefore: 168, RefundPercent: 100},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 168, policy.RefundTiers[0].HoursBefore)
+
+	// a paid registration three days out gets half of its price back
+	userID := uuid.NewString()
+	reg, err := regService.RegisterUser(userID, event.ID, nil)
+	require.NoError(t, err)
+	_, err = paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+
+	cancelled, err := regService.CancelRegistration(userID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusCancelled, cancelled.Status)
+	require.Equal(t, int64(4000), cancelled.RefundCents)
+	require.NotNil(t, cancelled.CancelledAt)
+
+	order, err := orderRepo.GetByID(reg.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4000), order.RefundedCents)
+	require.Equal(t, domain.OrderStatusPaid, order.Status)
+	require.Zero(t, order.OutstandingRefundCents(), "the refund was sent")
+
+	_, err = regService.CancelRegistration(userID, event.ID)
+	require.ErrorContains(t, err, "registration not found")
+
+	// a concurrent cancellation that read the seat before it was cancelled refunds nothing more
+	require.NotNil(t, reg.OrderID)
+	err = repository.NewRegistrationRepository(db).Cancel([]*domain.Registration{reg}, 50)
+	require.ErrorContains(t, err, "registration not found")
+	order, err = orderRepo.GetByID(reg.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4000), order.RefundedCents)
+
+	// an unpaid hold is released without a refund
+	pendingUser := uuid.NewString()
+	_, err = regService.RegisterUser(pendingUser, event.ID, nil)
+	require.NoError(t, err)
+	cancelled, err = regService.CancelRegistration(pendingUser, event.ID)
+	require.NoError(t, err)
+	require.Zero(t, cancelled.RefundCents)
+
+	// disallowed cancellations are rejected with a code and leave the registration untouched
+	otherUser := uuid.NewString()
+	_, err = regService.RegisterUser(otherUser, event.ID, nil)
+	require.NoError(t, err)
+	deny := false
+	_, err = regService.SetCancellationPolicy(organizerID, event.ID, &domain.UpdateCancellationPolicyRequest{AllowCancellation: &deny})
+	require.NoError(t, err)
+	_, err = regService.CancelRegistration(otherUser, event.ID)
+	require.ErrorIs(t, err, domain.ErrCancellationNotAllowed)
+
+	reg, err = repository.NewRegistrationRepository(db).GetByUserAndEvent(otherUser, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusPendingPayment, reg.Status)
+}
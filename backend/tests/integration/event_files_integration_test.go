@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFiles_UploadValidateThumbnailAndSignedURLs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	dir := t.TempDir()
+
+	store, err := storage.NewLocalStore(dir, "http://files.test", "storage-secret")
+	require.NoError(t, err)
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	fileService := service.NewEventFileService(repository.NewEventFileRepository(db), eventRepo, eventService, store,
+		service.UploadLimits{MaxImageSize: 64 << 10, MaxAttachmentSize: 128 << 10}, time.Hour)
+
+	r := gin.New()
+	handler.NewEventFileHandler(r, fileService, middleware.Auth("test-secret"), middleware.OptionalAuth("test-secret"))
+	handler.NewBlobHandler(r, store)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+	event := insertEventDirectly(t, db, organizer.ID)
+
+	tokenFor := func(user *domain.User) string {
+		token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+		require.NoError(t, err)
+		return token
+	}
+	upload := func(user *domain.User, method, path, name string, content []byte) (int, domain.EventFile, string) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", name)
+		require.NoError(t, err)
+		part.Write(content)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+tokenFor(user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var resp struct {
+			Data  domain.EventFile `json:"data"`
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp.Data, resp.Error.Code
+	}
+	download := func(signed string) (int, []byte) {
+		u, err := url.Parse(signed)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code, w.Body.Bytes()
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for x := 0; x < 640; x++ {
+		img.Set(x, x%480, color.RGBA{B: 255, A: 255})
+	}
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+	pdfData := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF")
+
+	coverPath := "/events/" + event.ID + "/cover"
+	attachmentsPath := "/events/" + event.ID + "/attachments"
+
+	// only organizers upload; the type comes from the content, not the file name
+	code, _, _ := upload(outsider, http.MethodPut, coverPath, "cover.png", pngData.Bytes())
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _, errCode := upload(organizer, http.MethodPut, coverPath, "cover.png", pdfData)
+	require.Equal(t, 422, code)
+	require.Equal(t, domain.ErrUnsupportedFileType.Code, errCode)
+	code, _, errCode = upload(organizer, http.MethodPost, attachmentsPath, "agenda.pdf", []byte("just some text"))
+	require.Equal(t, 422, code)
+	require.Equal(t, domain.ErrUnsupportedFileType.Code, errCode)
+	code, _, errCode = upload(organizer, http.MethodPost, attachmentsPath, "big.pdf", append(pdfData, make([]byte, 128<<10)...))
+	require.Equal(t, 422, code)
+	require.Equal(t, domain.ErrFileTooLarge.Code, errCode)
+
+	code, cover, _ := upload(organizer, http.MethodPut, coverPath, `C:\photos\cover.png`, pngData.Bytes())
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "cover.png", cover.Name)
+	require.Equal(t, "image/png", cover.ContentType)
+	require.NotEmpty(t, cover.ThumbnailURL)
+	require.NotNil(t, cover.URLExpiresAt)
+
+	code, body := download(cover.URL)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, pngData.Bytes(), body)
+	code, body = download(cover.ThumbnailURL)
+	require.Equal(t, http.StatusOK, code)
+	thumb, format, err := image.Decode(bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, "jpeg", format)
+	require.Equal(t, image.Rect(0, 0, domain.ThumbnailSize, domain.ThumbnailSize*3/4), thumb.Bounds())
+	code, _ = download(strings.Replace(cover.URL, "signature=", "signature=0", 1))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code, agenda, _ := upload(organizer, http.MethodPost, attachmentsPath, "agenda.pdf", pdfData)
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, "application/pdf", agenda.ContentType)
+	require.Empty(t, agenda.ThumbnailURL)
+
+	// a new cover replaces the old one, objects included
+	code, newCover, _ := upload(organizer, http.MethodPut, coverPath, "new.png", pngData.Bytes())
+	require.Equal(t, http.StatusOK, code)
+	code, _ = download(cover.URL)
+	require.Equal(t, http.StatusNotFound, code)
+
+	files, err := fileService.GetFiles(event.ID, organizer.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, newCover.ID, files.Cover.ID)
+	require.Len(t, files.Attachments, 1)
+	require.Equal(t, agenda.ID, files.Attachments[0].ID)
+
+	require.ErrorContains(t, fileService.RemoveAttachment(outsider.ID, event.ID, agenda.ID), "only the event organizers")
+	require.ErrorContains(t, fileService.RemoveAttachment(organizer.ID, event.ID, newCover.ID), "file not found")
+	require.NoError(t, fileService.RemoveAttachment(organizer.ID, event.ID, agenda.ID))
+	require.NoError(t, fileService.RemoveCover(organizer.ID, event.ID))
+	require.ErrorContains(t, fileService.RemoveCover(organizer.ID, event.ID), "no cover image")
+
+	// nothing is left behind in the store
+	var left []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			left = append(left, path)
+		}
+		return nil
+	})
+	require.Empty(t, left)
+}
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHistory_RecordsChangesAndReverts(t *testing.T) {
+	db := setupFileDB(t)
+	eventService := service.NewEventService(repository.NewEventRepository(db), nil)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	editor := createTestUser(t, db, "editor@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+
+	start := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	event, err := eventService.CreateEvent(organizer.ID, &domain.CreateEventRequest{
+		Title:         "Go Meetup",
+		Location:      "Hall A",
+		StartDatetime: start,
+		EndDatetime:   start.Add(2 * time.Hour),
+		Capacity:      50,
+		Tags:          []string{"go"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&domain.EventMember{
+		ID: uuid.NewString(), EventID: event.ID, UserID: editor.ID, Role: domain.EventRoleCoOrganizer, AddedBy: organizer.ID,
+	}).Error)
+
+	newTitle, newCapacity, newStart, newEnd := "Go Meetup: Generics", 80, start.Add(time.Hour), start.Add(3*time.Hour)
+	_, err = eventService.UpdateEvent(editor.ID, event.ID, &domain.UpdateEventRequest{
+		Title: &newTitle, Capacity: &newCapacity, StartDatetime: &newStart, EndDatetime: &newEnd, Tags: &[]string{"go", "generics"},
+	})
+	require.NoError(t, err)
+	// saving the same values again is no change
+	updated, err := eventService.UpdateEvent(editor.ID, event.ID, &domain.UpdateEventRequest{Title: &newTitle})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+	require.NoError(t, eventService.PublishEvent(organizer.ID, event.ID))
+
+	history, err := eventService.GetHistory(organizer.ID, event.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	require.Equal(t, domain.EventRevisionCreated, history[0].Action)
+	require.Equal(t, int64(1), history[0].Version)
+	require.Equal(t, organizer.ID, *history[0].ActorID)
+
+	edit := history[1]
+	require.Equal(t, domain.EventRevisionUpdated, edit.Action)
+	require.Equal(t, int64(2), edit.Version)
+	require.Equal(t, editor.ID, *edit.ActorID)
+	fields := map[string]domain.FieldChange{}
+	for _, change := range edit.Changes {
+		fields[change.Field] = change
+	}
+	require.Len(t, fields, 5)
+	require.Equal(t, "Go Meetup", fields["title"].Old)
+	require.Equal(t, newTitle, fields["title"].New)
+	require.Equal(t, start.Format(time.RFC3339Nano), fields["start_datetime"].Old)
+	require.Equal(t, newStart.Format(time.RFC3339Nano), fields["start_datetime"].New)
+	require.Equal(t, float64(50), fields["capacity"].Old)
+	require.Equal(t, []any{"generics", "go"}, fields["tags"].New)
+
+	require.Equal(t, domain.EventStatusPublished, history[2].Action)
+	require.Equal(t, int64(3), history[2].Version)
+	require.Equal(t, []domain.FieldChange{{Field: "status", Old: "draft", New: "published"}}, history[2].Changes)
+
+	_, err = eventService.GetHistory(outsider.ID, event.ID)
+	require.ErrorContains(t, err, "can view the history")
+	_, err = eventService.RevertEvent(outsider.ID, event.ID, 1)
+	require.ErrorContains(t, err, "can revert the event")
+	_, err = eventService.RevertEvent(organizer.ID, event.ID, 3)
+	require.ErrorContains(t, err, "not an earlier version")
+
+	// the details come back, the status stays
+	reverted, err := eventService.RevertEvent(organizer.ID, event.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, "Go Meetup", reverted.Title)
+	require.Equal(t, 50, reverted.Capacity)
+	require.True(t, start.Equal(reverted.StartDatetime))
+	require.Equal(t, domain.EventStatusPublished, reverted.Status)
+	require.Equal(t, int64(4), reverted.Version)
+
+	stored, err := eventService.GetEventByID(event.ID, organizer.ID, "")
+	require.NoError(t, err)
+	require.Len(t, stored.Tags, 1)
+	require.Equal(t, "go", stored.Tags[0].Name)
+
+	history, err = eventService.GetHistory(organizer.ID, event.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+	revert := history[3]
+	require.Equal(t, domain.EventRevisionReverted, revert.Action)
+	require.Equal(t, int64(1), *revert.RevertedTo)
+	require.Equal(t, newTitle, revert.Changes[0].Old)
+	require.Equal(t, "Go Meetup", revert.Changes[0].New)
+
+	// a revert can itself be reverted
+	reverted, err = eventService.RevertEvent(organizer.ID, event.ID, 3)
+	require.NoError(t, err)
+	require.Equal(t, newTitle, reverted.Title)
+	require.Equal(t, 80, reverted.Capacity)
+
+	require.NoError(t, eventService.DeleteEvent(organizer.ID, event.ID))
+	var deleted domain.EventRevision
+	require.NoError(t, db.Where("event_id = ?", event.ID).Order("version DESC").First(&deleted).Error)
+	require.Equal(t, domain.EventRevisionDeleted, deleted.Action)
+	require.Equal(t, int64(6), deleted.Version)
+}
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLifecycle_GuardedTransitionsAndHistory(t *testing.T) {
+	db := setupFileDB(t)
+	eventService := service.NewEventService(repository.NewEventRepository(db), nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+
+	require.ErrorIs(t, eventService.PostponeEvent(organizerID, event.ID, ""), domain.ErrInvalidStatusTransition)
+	require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+	require.ErrorIs(t, eventService.PublishEvent(organizerID, event.ID), domain.ErrInvalidStatusTransition)
+	// not started yet
+	require.ErrorIs(t, eventService.CompleteEvent(organizerID, event.ID), domain.ErrInvalidStatusTransition)
+
+	require.NoError(t, eventService.PostponeEvent(organizerID, event.ID, "venue flooded"))
+	require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+	require.NoError(t, eventService.Cancel(organizerID, event.ID))
+	require.ErrorIs(t, eventService.Cancel(organizerID, event.ID), domain.ErrInvalidStatusTransition)
+	require.ErrorIs(t, eventService.PublishEvent(organizerID, event.ID), domain.ErrInvalidStatusTransition)
+
+	_, err := eventService.GetStatusHistory(uuid.NewString(), event.ID)
+	require.ErrorContains(t, err, "only the event organizers")
+	history, err := eventService.GetStatusHistory(organizerID, event.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+	require.Equal(t, domain.EventStatusDraft, history[0].FromStatus)
+	require.Equal(t, domain.EventStatusPostponed, history[1].ToStatus)
+	require.Equal(t, "venue flooded", history[1].Reason)
+	require.Equal(t, organizerID, *history[3].ChangedBy)
+	require.Equal(t, domain.EventStatusCancelled, history[3].ToStatus)
+}
+
+func TestEventLifecycle_CompleteEndedEvents(t *testing.T) {
+	db := setupFileDB(t)
+	eventService := service.NewEventService(repository.NewEventRepository(db), nil)
+
+	organizerID := uuid.NewString()
+	ended := insertEventDirectly(t, db, organizerID)
+	upcoming := insertEventDirectly(t, db, organizerID)
+	postponed := insertEventDirectly(t, db, organizerID)
+	for _, event := range []*domain.Event{ended, upcoming, postponed} {
+		require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+	}
+	require.NoError(t, eventService.PostponeEvent(organizerID, postponed.ID, ""))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, db.Table("events").Where("id IN ?", []string{ended.ID, postponed.ID}).Updates(map[string]interface{}{
+		"start_datetime": past.Add(-2 * time.Hour),
+		"end_datetime":   past,
+	}).Error)
+
+	completed, err := eventService.CompleteEndedEvents(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, completed)
+
+	for id, want := range map[string]string{
+		ended.ID:     domain.EventStatusCompleted,
+		upcoming.ID:  domain.EventStatusPublished,
+		postponed.ID: domain.EventStatusPostponed,
+	} {
+		got, err := eventService.GetEventByID(id, "", "")
+		require.NoError(t, err)
+		require.Equal(t, want, got.Status)
+	}
+
+	history, err := eventService.GetStatusHistory(organizerID, ended.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Nil(t, history[1].ChangedBy)
+	require.Equal(t, domain.EventStatusCompleted, history[1].ToStatus)
+
+	// nothing left to do
+	completed, err = eventService.CompleteEndedEvents(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, completed)
+}
@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventMembers_RolesAreEnforced(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), nil)
+	memberService := service.NewMemberService(repository.NewEventMemberRepository(db), eventRepo, eventService,
+		repository.NewUserRepository(db), notificationService)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizer := createTestUser(t, db, "owner@example.com")
+	coOwner := createTestUser(t, db, "coowner@example.com")
+	coOrganizer := createTestUser(t, db, "co@example.com")
+	staff := createTestUser(t, db, "staff@example.com")
+	viewer := createTestUser(t, db, "viewer@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+
+	event := insertEventDirectly(t, db, organizer.ID)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("visibility", domain.EventVisibilityPrivate).Error)
+
+	add := func(actorID, email, role string) error {
+		_, err := memberService.AddMember(actorID, event.ID, &domain.AddEventMemberRequest{Email: email, Role: role})
+		return err
+	}
+	require.NoError(t, add(organizer.ID, "CoOwner@Example.com", domain.EventRoleOwner))
+	require.NoError(t, add(coOwner.ID, coOrganizer.Email, domain.EventRoleCoOrganizer))
+	require.NoError(t, add(organizer.ID, staff.Email, domain.EventRoleViewer))
+	// re-adding changes the role
+	require.NoError(t, add(organizer.ID, staff.Email, domain.EventRoleCheckInStaff))
+	require.NoError(t, add(organizer.ID, viewer.Email, domain.EventRoleViewer))
+
+	require.ErrorContains(t, add(coOrganizer.ID, attendee.Email, domain.EventRoleViewer), "only the event owners")
+	require.ErrorContains(t, add(organizer.ID, "nobody@example.com", domain.EventRoleViewer), "no user")
+	require.ErrorContains(t, add(coOwner.ID, organizer.Email, domain.EventRoleViewer), "always an owner")
+
+	team, err := memberService.GetTeam(viewer.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, organizer.ID, team.OrganizerID)
+	require.Len(t, team.Members, 4)
+	_, err = memberService.GetTeam(attendee.ID, event.ID)
+	require.ErrorContains(t, err, "only the event organizers and their team")
+
+	notifications, err := notificationService.GetUserNotifications(staff.ID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+
+	// co-organizers run the event, staff and viewers can't edit it
+	require.NoError(t, eventService.PublishEvent(coOrganizer.ID, event.ID))
+	title := "Renamed"
+	_, err = eventService.UpdateEvent(coOrganizer.ID, event.ID, &domain.UpdateEventRequest{Title: &title})
+	require.NoError(t, err)
+	_, err = eventService.UpdateEvent(staff.ID, event.ID, &domain.UpdateEventRequest{Title: &title})
+	require.ErrorContains(t, err, "only the event organizers can edit the event")
+	require.ErrorContains(t, eventService.Cancel(viewer.ID, event.ID), "only the event organizers")
+	require.ErrorContains(t, eventService.DeleteEvent(coOrganizer.ID, event.ID), "only the event owners")
+
+	// the team sees the private event
+	_, err = eventService.GetEventByID(event.ID, viewer.ID, "")
+	require.NoError(t, err)
+	listed, err := eventService.GetEvents(&domain.EventQueryRequest{ViewerID: staff.ID})
+	require.NoError(t, err)
+	require.Len(t, listed.Events, 1)
+
+	// check-in staff check in, viewers only look
+	_, err = regService.RegisterUser(attendee.ID, event.ID, &domain.RegisterRequest{})
+	require.ErrorIs(t, err, domain.ErrInvitationRequired)
+	require.NoError(t, db.Create(&domain.Registration{UserID: attendee.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed}).Error)
+
+	registrants, err := regService.GetEventRegistrants(viewer.ID, event.ID, "")
+	require.NoError(t, err)
+	require.Len(t, registrants, 1)
+	require.ErrorContains(t, regService.CheckInAttendee(viewer.ID, event.ID, attendee.ID), "only the event organizers and check-in staff")
+	require.NoError(t, regService.CheckInAttendee(staff.ID, event.ID, attendee.ID))
+
+	// removed members lose access; the organizer can't be removed
+	require.ErrorContains(t, memberService.RemoveMember(coOwner.ID, event.ID, organizer.ID), "cannot be removed")
+	require.NoError(t, memberService.RemoveMember(coOwner.ID, event.ID, staff.ID))
+	_, err = regService.GetEventRegistrants(staff.ID, event.ID, "")
+	require.ErrorContains(t, err, "only the event organizers and their team")
+
+	require.NoError(t, eventService.DeleteEvent(coOwner.ID, event.ID))
+}
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func insertCategory(t *testing.T, db *gorm.DB, slug string, parentID *string) string {
+	t.Helper()
+
+	category := categoryTestModel{ID: uuid.NewString(), ParentID: parentID, Name: slug, Slug: slug}
+	require.NoError(t, db.Create(&category).Error)
+	return category.ID
+}
+
+func TestEventTaxonomy_FiltersAndFacets(t *testing.T) {
+	db, svc, cleanup := setupEventServiceTest(t)
+	defer cleanup()
+
+	tech := insertCategory(t, db, "tech", nil)
+	golang := insertCategory(t, db, "golang", &tech)
+	music := insertCategory(t, db, "music", nil)
+
+	userID := uuid.NewString()
+	start := time.Date(2030, 3, 10, 18, 0, 0, 0, time.UTC)
+	create := func(title, categoryID string, tags ...string) {
+		_, err := svc.CreateEvent(userID, &domain.CreateEventRequest{
+			Title:         title,
+			Location:      "Hall",
+			StartDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			Capacity:      10,
+			CategoryID:    &categoryID,
+			Tags:          tags,
+		})
+		require.NoError(t, err)
+	}
+
+	create("Go Meetup", golang, "Go", "backend")
+	create("Tech Talk", tech, "backend")
+	create("Jazz Night", music, "live")
+
+	// parent category also matches events in sub-categories
+	res, err := svc.GetEvents(&domain.EventQueryRequest{Category: "tech"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Pagination.Total)
+
+	res, err = svc.GetEvents(&domain.EventQueryRequest{Category: golang})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.Pagination.Total)
+	require.Len(t, res.Events[0].Tags, 2)
+
+	res, err = svc.GetEvents(&domain.EventQueryRequest{Tags: "go,live"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Pagination.Total)
+
+	res, err = svc.GetEvents(&domain.EventQueryRequest{Tags: "go,backend", TagMode: "all"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.Pagination.Total)
+	require.Equal(t, "Go Meetup", res.Events[0].Title)
+
+	_, err = svc.GetEvents(&domain.EventQueryRequest{Tags: "go", TagMode: "some"})
+	require.Error(t, err)
+
+	facets, err := svc.GetEventFacets(&domain.EventQueryRequest{Category: "tech"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), facets.Total)
+	require.Equal(t, []domain.FacetCount{{Value: "backend", Count: 2}, {Value: "go", Count: 1}}, facets.Tags)
+	require.Equal(t, []domain.FacetCount{{Value: "draft", Count: 2}}, facets.Statuses)
+	require.Equal(t, []domain.FacetCount{{Value: "2030-03", Count: 2}}, facets.Months)
+	require.Len(t, facets.Categories, 2)
+
+	// sorting only accepts the documented fields
+	res, err = svc.GetEvents(&domain.EventQueryRequest{SortBy: "start_date", SortOrder: "asc"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Pagination.Total)
+	res, err = svc.GetEvents(&domain.EventQueryRequest{SortBy: "capacity"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Pagination.Total)
+	_, err = svc.GetEvents(&domain.EventQueryRequest{SortBy: "title; DROP TABLE events"})
+	require.ErrorContains(t, err, "sort_by must be one of: start_date, capacity, created_at")
+	_, err = svc.GetEvents(&domain.EventQueryRequest{SortBy: "start_datetime"})
+	require.Error(t, err)
+}
+
+func TestEventTaxonomy_DeleteCategoryDetachesEvents(t *testing.T) {
+	db, svc, cleanup := setupEventServiceTest(t)
+	defer cleanup()
+	categories := service.NewCategoryService(repository.NewCategoryRepository(db), svc)
+
+	music := insertCategory(t, db, "music", nil)
+	start := time.Date(2030, 3, 10, 18, 0, 0, 0, time.UTC)
+	event, err := svc.CreateEvent(uuid.NewString(), &domain.CreateEventRequest{
+		Title: "Jazz Night", Location: "Hall", StartDatetime: start, EndDatetime: start.Add(2 * time.Hour), Capacity: 10,
+		CategoryID: &music,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, categories.DeleteCategory(music))
+	got, err := svc.GetEventByID(event.ID, event.OrganizerID, "")
+	require.NoError(t, err)
+	require.Nil(t, got.CategoryID)
+	require.Equal(t, event.Version+1, got.Version)
+	require.ErrorContains(t, categories.DeleteCategory(music), "category not found")
+}
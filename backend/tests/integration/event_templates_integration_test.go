@@ -0,0 +1,187 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneEvent_CopiesConfigurationWithShiftedDates(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	promoRepo := repository.NewPromoCodeRepository(db)
+	promoService := service.NewPromoService(promoRepo, ticketRepo, eventRepo)
+	policyRepo := repository.NewCancellationPolicyRepository(db)
+	formRepo := repository.NewRegistrationFormRepository(db)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo,
+		policyRepo, formRepo, nil, nil, nil)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+
+	start := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	opens := time.Now().Add(-time.Hour).Truncate(time.Second)
+	source, err := eventService.CreateEvent(organizer.ID, &domain.CreateEventRequest{
+		Title:               "Monthly Meetup",
+		Description:         "Talks and pizza",
+		Location:            "Hall",
+		StartDatetime:       start,
+		EndDatetime:         start.Add(2 * time.Hour),
+		Capacity:            50,
+		Tags:                []string{"go", "meetup"},
+		RegistrationOpensAt: &opens,
+		Visibility:          domain.EventVisibilityUnlisted,
+	})
+	require.NoError(t, err)
+
+	salesEnd := start.Add(-time.Hour)
+	vip, err := ticketService.CreateTicketType(organizer.ID, source.ID, &domain.CreateTicketTypeRequest{Name: "VIP", PriceCents: 5000, Quantity: 5, SalesEnd: &salesEnd})
+	require.NoError(t, err)
+	general, err := ticketService.CreateTicketType(organizer.ID, source.ID, &domain.CreateTicketTypeRequest{Name: "General", Quantity: 45})
+	require.NoError(t, err)
+	_, err = promoService.CreatePromoCode(organizer.ID, source.ID, &domain.CreatePromoCodeRequest{
+		Code: "VIPHALF", DiscountType: "percent", DiscountValue: 50, ValidUntil: &salesEnd, TicketTypeIDs: []string{vip.ID},
+	})
+	require.NoError(t, err)
+	allow := true
+	_, err = regService.SetCancellationPolicy(organizer.ID, source.ID, &domain.UpdateCancellationPolicyRequest{AllowCancellation: &allow, CutoffHours: 24})
+	require.NoError(t, err)
+	_, err = regService.SetRegistrationForm(organizer.ID, source.ID, &domain.UpdateRegistrationFormRequest{
+		Questions: []domain.FormQuestion{{ID: "company", Label: "Company", Type: domain.QuestionTypeText}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, eventService.PublishEvent(organizer.ID, source.ID))
+	_, err = regService.RegisterUser(attendee.ID, source.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, Answers: domain.FormAnswers{"company": "Acme"}})
+	require.NoError(t, err)
+
+	_, err = eventService.CloneEvent(outsider.ID, source.ID, &domain.CloneEventRequest{StartDatetime: start})
+	require.ErrorContains(t, err, "only the event organizers can clone the event")
+
+	// next month's edition
+	next := start.AddDate(0, 1, 0)
+	offset := next.Sub(start)
+	clone, err := eventService.CloneEvent(organizer.ID, source.ID, &domain.CloneEventRequest{StartDatetime: next})
+	require.NoError(t, err)
+	require.NotEqual(t, source.ID, clone.ID)
+	require.NotEqual(t, source.Slug, clone.Slug)
+	require.Equal(t, domain.EventStatusDraft, clone.Status)
+
+	stored, err := eventRepo.GetByID(clone.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Monthly Meetup", stored.Title)
+	require.Equal(t, "Talks and pizza", stored.Description)
+	require.Equal(t, 50, stored.Capacity)
+	require.Equal(t, domain.EventVisibilityUnlisted, stored.Visibility)
+	require.True(t, stored.EndDatetime.Equal(next.Add(2*time.Hour)))
+	require.True(t, stored.RegistrationOpensAt.Equal(opens.Add(offset)))
+	require.Len(t, stored.Tags, 2)
+
+	ticketTypes, err := ticketRepo.GetByEvent(clone.ID)
+	require.NoError(t, err)
+	require.Len(t, ticketTypes, 2)
+	var clonedVIP domain.TicketType
+	for _, ticketType := range ticketTypes {
+		require.Zero(t, ticketType.Sold)
+		if ticketType.Name == "VIP" {
+			clonedVIP = ticketType
+		}
+	}
+	require.Equal(t, int64(5000), clonedVIP.PriceCents)
+	require.True(t, clonedVIP.SalesEnd.Equal(salesEnd.Add(offset)))
+
+	promos, err := promoRepo.GetByEvent(clone.ID)
+	require.NoError(t, err)
+	require.Len(t, promos, 1)
+	require.Equal(t, "VIPHALF", promos[0].Code)
+	require.Len(t, promos[0].TicketTypes, 1)
+	require.Equal(t, clonedVIP.ID, promos[0].TicketTypes[0].ID)
+
+	policy, err := policyRepo.GetByEvent(clone.ID)
+	require.NoError(t, err)
+	require.Equal(t, 24, policy.CutoffHours)
+	form, err := formRepo.GetByEvent(clone.ID)
+	require.NoError(t, err)
+	require.Len(t, form.Questions, 1)
+
+	// registrations stay with the source
+	registrants, err := regService.GetEventRegistrants(organizer.ID, clone.ID, "")
+	require.NoError(t, err)
+	require.Empty(t, registrants)
+	registrants, err = regService.GetEventRegistrants(organizer.ID, source.ID, "")
+	require.NoError(t, err)
+	require.Len(t, registrants, 1)
+}
+
+func TestEventTemplates_CRUDAndCreateEvent(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	templateService := service.NewEventTemplateService(repository.NewEventTemplateRepository(db), eventService)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	other := createTestUser(t, db, "other@example.com")
+
+	noTransfers := false
+	template, err := templateService.CreateTemplate(organizer.ID, &domain.CreateEventTemplateRequest{
+		Name:            "Monthly meetup",
+		Title:           "Go Meetup",
+		Location:        "Hall",
+		Capacity:        40,
+		DurationMinutes: 90,
+		Tags:            []string{"Go", "go", "meetup"},
+		AllowTransfers:  &noTransfers,
+		Visibility:      domain.EventVisibilityPrivate,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "meetup"}, template.Tags)
+
+	_, err = templateService.CreateTemplate(organizer.ID, &domain.CreateEventTemplateRequest{
+		Name: "Broken", Title: "Go Meetup", Location: "Hall", Capacity: 40, DurationMinutes: 90, Visibility: "secret",
+	})
+	require.ErrorContains(t, err, "visibility must be one of")
+
+	// templates are private to their organizer
+	_, err = templateService.GetTemplate(other.ID, template.ID)
+	require.ErrorContains(t, err, "event template not found")
+	require.ErrorContains(t, templateService.DeleteTemplate(other.ID, template.ID), "event template not found")
+	others, err := templateService.GetTemplates(other.ID)
+	require.NoError(t, err)
+	require.Empty(t, others)
+
+	capacity := 60
+	updated, err := templateService.UpdateTemplate(organizer.ID, template.ID, &domain.UpdateEventTemplateRequest{Capacity: &capacity})
+	require.NoError(t, err)
+	require.Equal(t, 60, updated.Capacity)
+	require.Equal(t, []string{"go", "meetup"}, updated.Tags)
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	event, err := templateService.CreateEvent(organizer.ID, template.ID, &domain.CreateEventFromTemplateRequest{StartDatetime: start, Title: "Go Meetup: June"})
+	require.NoError(t, err)
+	require.Equal(t, "Go Meetup: June", event.Title)
+	require.Equal(t, domain.EventStatusDraft, event.Status)
+	require.Equal(t, 60, event.Capacity)
+	require.False(t, event.AllowTransfers)
+	require.Equal(t, domain.EventVisibilityPrivate, event.Visibility)
+	require.NotEmpty(t, event.Slug)
+	require.True(t, event.EndDatetime.Equal(start.Add(90*time.Minute)))
+	require.Len(t, event.Tags, 2)
+
+	_, err = templateService.CreateEvent(other.ID, template.ID, &domain.CreateEventFromTemplateRequest{StartDatetime: start})
+	require.ErrorContains(t, err, "event template not found")
+
+	require.NoError(t, templateService.DeleteTemplate(organizer.ID, template.ID))
+	templates, err := templateService.GetTemplates(organizer.ID)
+	require.NoError(t, err)
+	require.Empty(t, templates)
+}
@@ -143,7 +143,7 @@ func TestDeleteEvent_Success(t *testing.T) {
 		t.Fatalf("Delete error: %v", err)
 	}
 
-	_, err = svc.GetEventByID(event.ID)
+	_, err = svc.GetEventByID(event.ID, "", "")
 	if err == nil {
 		t.Error("Expected error when getting deleted event, got nil")
 	}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUpdate_IfMatchAndCapacityCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	r := gin.New()
+	handler.NewEventHandler(r, eventService, middleware.Auth("test-secret"), middleware.OptionalAuth("test-secret"))
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	event := insertEventDirectly(t, db, organizer.ID)
+	token, err := jwt.GenerateToken(organizer.ID, organizer.Email, organizer.Role, "test-secret", time.Hour)
+	require.NoError(t, err)
+
+	update := func(ifMatch string, body map[string]interface{}) (*httptest.ResponseRecorder, string) {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPut, "/events/"+event.ID, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var resp struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp.Error.Code
+	}
+
+	// reads hand out the version as the ETag
+	req := httptest.NewRequest(http.MethodGet, "/events/"+event.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	w, code := update("", map[string]interface{}{"title": "No precondition"})
+	require.Equal(t, http.StatusPreconditionRequired, w.Code)
+	require.Equal(t, "PRECONDITION_REQUIRED", code)
+
+	w, _ = update(`"1"`, map[string]interface{}{"title": "First edit"})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	// a second client still holding version 1 doesn't overwrite the first edit
+	w, code = update(`"1"`, map[string]interface{}{"title": "Stale edit", "tags": []string{"stale"}})
+	require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	require.Equal(t, domain.ErrEventVersionMismatch.Code, code)
+	w, _ = update("not-a-version", map[string]interface{}{"title": "Stale edit"})
+	require.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	stored, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, "First edit", stored.Title)
+	require.Equal(t, int64(2), stored.Version)
+	require.Empty(t, stored.Tags, "the tags of a rejected edit are not saved")
+
+	// status changes count as changes too
+	require.NoError(t, eventService.PublishEvent(organizer.ID, event.ID))
+	w, _ = update(`W/"2"`, map[string]interface{}{"title": "Stale edit"})
+	require.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		attendee := createTestUser(t, db, email)
+		require.NoError(t, db.Create(&domain.Registration{UserID: attendee.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken()}).Error)
+	}
+
+	w, code = update("*", map[string]interface{}{"capacity": 2})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Equal(t, domain.ErrCapacityBelowSeatsTaken.Code, code)
+
+	w, _ = update("*", map[string]interface{}{"capacity": 3})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"4"`, w.Header().Get("ETag"))
+}
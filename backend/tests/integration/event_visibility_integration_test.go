@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventVisibility_ListingShareLinkAndAccess(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), nil)
+	invitationService := service.NewInvitationService(repository.NewEventInvitationRepository(db), eventRepo,
+		repository.NewUserRepository(db), notificationService)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	start := time.Now().Add(48 * time.Hour)
+	create := func(title, visibility, accessCode string) *domain.Event {
+		event, err := eventService.CreateEvent(organizerID, &domain.CreateEventRequest{
+			Title:         title,
+			Location:      "Hall",
+			StartDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			Capacity:      10,
+			Visibility:    visibility,
+			AccessCode:    accessCode,
+		})
+		require.NoError(t, err)
+		require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+		return event
+	}
+
+	public := create("Public Meetup", "", "")
+	unlisted := create("Friends & Family", domain.EventVisibilityUnlisted, "")
+	private := create("Board Meeting", domain.EventVisibilityPrivate, "letmein")
+	require.Equal(t, domain.EventVisibilityPublic, public.Visibility)
+	require.Regexp(t, `^friends-family-[0-9a-f]{10}$`, unlisted.Slug)
+
+	// listings only show public events, except to their organizer
+	listed, err := eventService.GetAllEvents("")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, public.ID, listed[0].ID)
+
+	res, err := eventService.GetEvents(&domain.EventQueryRequest{ViewerID: organizerID})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 3)
+
+	// unlisted: only through the share link
+	_, err = eventService.GetEventByID(unlisted.ID, "", "")
+	require.ErrorContains(t, err, "event not found")
+	shared, err := eventService.GetEventBySlug(unlisted.Slug, "", "")
+	require.NoError(t, err)
+	require.Equal(t, unlisted.ID, shared.ID)
+
+	// private: organizer, access code or invitation
+	_, err = eventService.GetEventByID(private.ID, "", "")
+	require.ErrorContains(t, err, "event not found")
+	_, err = eventService.GetEventBySlug(private.Slug, "", "")
+	require.ErrorContains(t, err, "event not found")
+	_, err = eventService.GetEventByID(private.ID, organizerID, "")
+	require.NoError(t, err)
+	_, err = eventService.GetEventByID(private.ID, "", "letmein")
+	require.NoError(t, err)
+
+	invitee := createTestUser(t, db, "guest@example.com")
+	stranger := createTestUser(t, db, "stranger@example.com")
+	codeHolder := createTestUser(t, db, "code@example.com")
+
+	_, err = regService.RegisterUser(stranger.ID, private.ID, nil)
+	require.ErrorIs(t, err, domain.ErrInvitationRequired)
+	_, err = regService.RegisterUser(codeHolder.ID, private.ID, &domain.RegisterRequest{AccessCode: "letmein"})
+	require.NoError(t, err)
+	// registrants keep access without the code
+	_, err = eventService.GetEventByID(private.ID, codeHolder.ID, "")
+	require.NoError(t, err)
+
+	_, err = invitationService.InviteByEmail(stranger.ID, private.ID, &domain.CreateInvitationsRequest{Emails: []string{"x@example.com"}})
+	require.ErrorContains(t, err, "only the event organizer")
+
+	result, err := invitationService.InviteByEmail(organizerID, private.ID, &domain.CreateInvitationsRequest{
+		Emails: []string{"Guest@Example.com", "guest@example.com", "new@example.com"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Invited, 2)
+	require.Empty(t, result.AlreadyInvited)
+
+	result, err = invitationService.InviteByEmail(organizerID, private.ID, &domain.CreateInvitationsRequest{
+		Emails: []string{"new@example.com", "other@example.com"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Invited, 1)
+	require.Equal(t, []string{"new@example.com"}, result.AlreadyInvited)
+
+	notifications, err := notificationService.GetUserNotifications(invitee.ID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Event invitation", notifications[0].Title)
+
+	_, err = eventService.GetEventByID(private.ID, invitee.ID, "")
+	require.NoError(t, err)
+
+	invitations, err := invitationService.GetInvitations(organizerID, private.ID)
+	require.NoError(t, err)
+	require.Equal(t, "letmein", invitations.AccessCode)
+	require.Len(t, invitations.Invitations, 3)
+
+	// revoking removes access for invitees that have not registered
+	var revoked string
+	for _, invitation := range invitations.Invitations {
+		if invitation.Email == invitee.Email {
+			revoked = invitation.ID
+		}
+	}
+	require.NoError(t, invitationService.RevokeInvitation(organizerID, private.ID, revoked))
+	_, err = regService.RegisterUser(invitee.ID, private.ID, nil)
+	require.ErrorIs(t, err, domain.ErrInvitationRequired)
+
+	_, err = invitationService.InviteByEmail(organizerID, private.ID, &domain.CreateInvitationsRequest{Emails: []string{invitee.Email}})
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(invitee.ID, private.ID, nil)
+	require.NoError(t, err)
+}
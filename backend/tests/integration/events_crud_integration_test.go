@@ -33,6 +33,22 @@ func setupEventServiceTest(t *testing.T) (*gorm.DB, *service.EventService, func(
 		&userTestModel{},
 		&eventTestModel{},
 		&registrationTestModel{},
+		&categoryTestModel{},
+		&tagTestModel{},
+		&eventTagTestModel{},
+		&ticketTypeTestModel{},
+		&orderTestModel{},
+		&promoCodeTestModel{},
+		&promoCodeTicketTypeTestModel{},
+		&promoRedemptionTestModel{},
+		&cancellationPolicyTestModel{},
+		&eventInvitationTestModel{},
+		&eventMemberTestModel{},
+		&organizationTestModel{},
+		&organizationMemberTestModel{},
+		&eventStatusChangeTestModel{},
+		&eventRevisionTestModel{},
+		&sessionTestModel{},
 	); err != nil {
 		t.Fatalf("Failed to migrate tables: %v", err)
 	}
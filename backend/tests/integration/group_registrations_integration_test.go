@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRegistrations_AllOrNothingWithGuestSeats(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	regRepo := repository.NewRegistrationRepository(db)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("capacity", 4).Error)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	_, err := regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{
+		Quantity: 2,
+		Guests:   []domain.GuestRequest{{Name: "A"}, {Name: "B"}},
+	})
+	require.ErrorContains(t, err, "too many guests")
+
+	// the booking user's seat plus two guests, one of them named
+	booker := uuid.NewString()
+	reg, err := regService.RegisterUser(booker, event.ID, &domain.RegisterRequest{
+		Quantity: 3,
+		Guests:   []domain.GuestRequest{{Name: "Alice", Email: "alice@example.com"}},
+	})
+	require.NoError(t, err)
+	require.False(t, reg.IsGuest)
+	require.NotNil(t, reg.GroupID)
+	require.Len(t, reg.Guests, 2)
+	require.Equal(t, "Alice", reg.Guests[0].GuestName)
+	require.True(t, reg.Guests[1].IsGuest)
+	require.Equal(t, *reg.GroupID, *reg.Guests[1].GroupID)
+
+	count, err := regRepo.CountByEvent(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	// a booking that doesn't fit is rejected as a whole
+	other := uuid.NewString()
+	_, err = regService.RegisterUser(other, event.ID, &domain.RegisterRequest{Quantity: 2})
+	require.ErrorContains(t, err, "not enough seats left (1 available)")
+	count, err = regRepo.CountByEvent(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	// guests get their own check-in
+	require.NoError(t, regService.CheckInSeat(organizerID, event.ID, reg.Guests[0].ID))
+	checkedIn, err := regRepo.GetByID(event.ID, reg.Guests[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusCheckedIn, checkedIn.Status)
+	own, err := regRepo.GetByUserAndEvent(booker, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, reg.ID, own.ID)
+	require.Equal(t, domain.RegistrationStatusConfirmed, own.Status)
+
+	// a single guest seat can be cancelled, freeing it for others
+	_, err = regService.CancelGuestSeat(other, event.ID, reg.Guests[1].ID)
+	require.ErrorContains(t, err, "guest seat not found")
+	cancelled, err := regService.CancelGuestSeat(booker, event.ID, reg.Guests[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusCancelled, cancelled.Status)
+
+	_, err = regService.RegisterUser(other, event.ID, &domain.RegisterRequest{Quantity: 2})
+	require.NoError(t, err)
+
+	// cancelling the booking releases the user's seat and the remaining guest seats
+	cancelledBooking, err := regService.CancelRegistration(booker, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusCancelled, cancelledBooking.Status)
+	require.Len(t, cancelledBooking.Guests, 1)
+	require.Equal(t, reg.Guests[0].ID, cancelledBooking.Guests[0].ID)
+
+	count, err = regRepo.CountByEvent(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestGroupRegistrations_PaidBookingSharesOneOrder(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+	paymentService := service.NewPaymentService(orderRepo, payment.NewFakeProvider("test-secret", ""), 15*time.Minute)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo,
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), paymentService, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+	_, err := service.NewTicketService(ticketRepo, eventRepo).CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "General", PriceCents: 2000, Quantity: 3,
+	})
+	require.NoError(t, err)
+
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{Quantity: 4})
+	require.ErrorContains(t, err, `not enough "General" tickets left (3 available)`)
+
+	userID := uuid.NewString()
+	reg, err := regService.RegisterUser(userID, event.ID, &domain.RegisterRequest{Quantity: 2})
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusPendingPayment, reg.Status)
+	require.Equal(t, 2, reg.Order.Quantity)
+	require.Equal(t, int64(4000), reg.Order.AmountCents)
+	require.Equal(t, reg.Order.ID, *reg.Guests[0].OrderID)
+
+	_, err = regService.CancelGuestSeat(userID, event.ID, reg.Guests[0].ID)
+	require.ErrorContains(t, err, "cancel the whole booking instead")
+
+	_, err = paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+
+	// the guest seat is refunded on its own under the default policy
+	guest, err := regService.CancelGuestSeat(userID, event.ID, reg.Guests[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2000), guest.RefundCents)
+
+	order, err := orderRepo.GetByID(reg.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusPaid, order.Status)
+	require.Equal(t, int64(2000), order.RefundedCents)
+
+	_, err = regService.CancelRegistration(userID, event.ID)
+	require.NoError(t, err)
+	order, err = orderRepo.GetByID(reg.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusRefunded, order.Status)
+	require.Equal(t, int64(4000), order.RefundedCents)
+}
+
+func TestGroupRegistrations_TicketTypeLimitsSeatsPerOrder(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo,
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+	_, err := service.NewTicketService(ticketRepo, eventRepo).CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "Duo", Quantity: 10, MaxPerOrder: 2,
+	})
+	require.NoError(t, err)
+
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{Quantity: 3})
+	require.ErrorContains(t, err, `at most 2 "Duo" ticket(s) can be booked at once`)
+
+	reg, err := regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{Quantity: 2})
+	require.NoError(t, err)
+	require.Len(t, reg.Guests, 1)
+}
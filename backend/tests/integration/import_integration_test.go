@@ -0,0 +1,228 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type importTestEnv struct {
+	t             *testing.T
+	db            *gorm.DB
+	router        *gin.Engine
+	importService *service.ImportService
+	eventService  *service.EventService
+}
+
+func setupImportTest(t *testing.T) *importTestEnv {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	importService := service.NewImportService(repository.NewImportJobRepository(db), eventService, eventRepo,
+		repository.NewRegistrationRepository(db), repository.NewTicketTypeRepository(db), repository.NewUserRepository(db), nil)
+
+	r := gin.New()
+	handler.NewEventHandler(r, eventService, middleware.Auth("test-secret"), middleware.OptionalAuth("test-secret"))
+	handler.NewImportHandler(r, importService, middleware.Auth("test-secret"))
+	return &importTestEnv{t: t, db: db, router: r, importService: importService, eventService: eventService}
+}
+
+// upload sends a CSV file as the request body
+func (env *importTestEnv) upload(user *domain.User, path, csv string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(csv))
+	req.Header.Set("Content-Type", "text/csv")
+	return env.serve(user, req)
+}
+
+func (env *importTestEnv) serve(user *domain.User, req *http.Request) *httptest.ResponseRecorder {
+	token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+	require.NoError(env.t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+// finishedJob waits for the imports in the background and returns the final state of the job
+func (env *importTestEnv) finishedJob(user *domain.User, w *httptest.ResponseRecorder) *domain.ImportJob {
+	require.Equal(env.t, http.StatusAccepted, w.Code, w.Body.String())
+	var accepted struct {
+		Data domain.ImportJob `json:"data"`
+	}
+	require.NoError(env.t, json.Unmarshal(w.Body.Bytes(), &accepted))
+	require.Equal(env.t, domain.ImportStatusPending, accepted.Data.Status)
+
+	env.importService.Wait()
+	w = env.serve(user, httptest.NewRequest(http.MethodGet, "/events/imports/"+accepted.Data.ID, nil))
+	require.Equal(env.t, http.StatusOK, w.Code)
+	var resp struct {
+		Data domain.ImportJob `json:"data"`
+	}
+	require.NoError(env.t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(env.t, resp.Data.FinishedAt)
+	return &resp.Data
+}
+
+func decodeImportReport(t *testing.T, w *httptest.ResponseRecorder) domain.ImportReport {
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data domain.ImportReport `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestImport_Events(t *testing.T) {
+	env := setupImportTest(t)
+	organizer := createTestUser(t, env.db, "organizer@example.com")
+	outsider := createTestUser(t, env.db, "outsider@example.com")
+
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	at := func(d time.Duration) string { return start.Add(d).Format(time.RFC3339) }
+	valid := "title,start_datetime,end_datetime,location,capacity,tags,visibility\n" +
+		"Go Meetup," + at(0) + "," + at(2*time.Hour) + ",Hall A,50,go;meetup,\n" +
+		"\"Rust, Intro\"," + at(24*time.Hour) + "," + at(26*time.Hour) + ",Hall B,20,,unlisted\n"
+	invalid := valid +
+		"Backwards," + at(2*time.Hour) + "," + at(0) + ",Hall C,10,,\n" +
+		"Big Room," + at(0) + "," + at(time.Hour) + ",Hall D,lots,,\n"
+	countEvents := func() int64 {
+		var n int64
+		require.NoError(t, env.db.Model(&domain.Event{}).Count(&n).Error)
+		return n
+	}
+
+	// the dry run reports every invalid row, with Event.Validate's message
+	report := decodeImportReport(t, env.upload(organizer, "/events/import?dry_run=true", invalid))
+	require.Equal(t, 4, report.TotalRows)
+	require.Equal(t, 2, report.ValidRows)
+	require.Len(t, report.Errors, 2)
+	require.Equal(t, 4, report.Errors[0].Row)
+	require.Contains(t, report.Errors[0].Message, "end time must be after start time")
+	require.Equal(t, 5, report.Errors[1].Row)
+	require.Contains(t, report.Errors[1].Message, "capacity must be a whole number")
+	require.Zero(t, countEvents())
+
+	// an import with invalid rows imports nothing
+	job := env.finishedJob(organizer, env.upload(organizer, "/events/import", invalid))
+	require.Equal(t, domain.ImportStatusFailed, job.Status)
+	require.Equal(t, 2, job.FailedRows)
+	require.Zero(t, job.ImportedRows)
+	require.Zero(t, countEvents())
+
+	// only the user who started a job can follow it
+	w := env.serve(outsider, httptest.NewRequest(http.MethodGet, "/events/imports/"+job.ID, nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	job = env.finishedJob(organizer, env.upload(organizer, "/events/import", valid))
+	require.Equal(t, domain.ImportStatusCompleted, job.Status)
+	require.Equal(t, 2, job.TotalRows)
+	require.Equal(t, 2, job.ProcessedRows)
+	require.Equal(t, 2, job.ImportedRows)
+	require.Empty(t, job.Errors)
+	require.Len(t, job.CreatedIDs, 2)
+
+	event, err := env.eventService.GetEventByID(job.CreatedIDs[0], organizer.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, "Go Meetup", event.Title)
+	require.Equal(t, domain.EventStatusDraft, event.Status)
+	require.Equal(t, domain.EventVisibilityPublic, event.Visibility)
+	require.NotEmpty(t, event.Slug)
+	require.True(t, start.Equal(event.StartDatetime))
+	require.Len(t, event.Tags, 2)
+	history, err := env.eventService.GetHistory(organizer.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventRevisionCreated, history[0].Action)
+
+	event, err = env.eventService.GetEventByID(job.CreatedIDs[1], organizer.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, "Rust, Intro", event.Title)
+	require.Equal(t, domain.EventVisibilityUnlisted, event.Visibility)
+
+	// problems with the file itself are rejected up front
+	w = env.upload(organizer, "/events/import", "title,start_datetime,location,capacity,venue\n")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `unknown column \"venue\"`)
+	w = env.upload(organizer, "/events/import", "title,start_datetime,end_datetime,location\n")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `required column \"capacity\" is missing`)
+	w = env.upload(organizer, "/events/import", "title,start_datetime,end_datetime,location,capacity\n")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "no rows")
+}
+
+func TestImport_Registrants(t *testing.T) {
+	env := setupImportTest(t)
+	organizer := createTestUser(t, env.db, "organizer@example.com")
+	outsider := createTestUser(t, env.db, "outsider@example.com")
+	registered := createTestUser(t, env.db, "registered@example.com")
+	ada := createTestUser(t, env.db, "Ada@Example.com")
+	createTestUser(t, env.db, "grace@example.com")
+
+	event := insertEventDirectly(t, env.db, organizer.ID)
+	require.NoError(t, env.db.Table("events").Where("id = ?", event.ID).Update("capacity", 2).Error)
+	require.NoError(t, env.db.Create(&domain.Registration{
+		UserID: registered.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken(),
+	}).Error)
+	path := "/events/" + event.ID + "/registrants/import"
+
+	report := decodeImportReport(t, env.upload(organizer, path+"?dry_run=true",
+		"email\nregistered@example.com\nada@example.com\nnobody@example.com\nADA@example.com\nnot-an-email\ngrace@example.com\n"))
+	require.Equal(t, 6, report.TotalRows)
+	require.Equal(t, 1, report.ValidRows)
+	messages := map[int]string{}
+	for _, rowError := range report.Errors {
+		messages[rowError.Row] = rowError.Message
+	}
+	require.Contains(t, messages[2], "already registered")
+	require.Contains(t, messages[4], "no user has the email nobody@example.com")
+	require.Contains(t, messages[5], "already on row 3")
+	require.Contains(t, messages[6], "not a valid address")
+	// one seat was left, and ada took it
+	require.Contains(t, messages[7], "event is full")
+
+	w := env.upload(outsider, path, "email\nada@example.com\n")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "import registrants")
+
+	// multipart uploads work too
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "attendees.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("email,ticket_type\nada@example.com,\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	job := env.finishedJob(organizer, env.serve(organizer, req))
+	require.Equal(t, domain.ImportStatusCompleted, job.Status)
+	require.Equal(t, 1, job.ImportedRows)
+	require.Equal(t, event.ID, *job.EventID)
+
+	registration, err := repository.NewRegistrationRepository(env.db).GetByUserAndEvent(ada.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.CreatedIDs[0], registration.ID)
+	require.Equal(t, domain.RegistrationStatusConfirmed, registration.Status)
+
+	// the event is full now: the validation pass fails the job
+	job = env.finishedJob(organizer, env.upload(organizer, path, "email\ngrace@example.com\n"))
+	require.Equal(t, domain.ImportStatusFailed, job.Status)
+	require.Equal(t, []domain.ImportRowError{{Row: 2, Message: "event is full (capacity reached)"}}, job.Errors)
+}
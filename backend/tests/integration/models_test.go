@@ -8,18 +8,30 @@ import (
 
 // SQLITE-совместимая модель events
 type eventTestModel struct {
-	ID            string `gorm:"primaryKey"`
-	OrganizerID   string `gorm:"index"`
-	Title         string
-	Description   string
-	Location      string
-	StartDatetime time.Time
-	EndDatetime   time.Time
-	Capacity      int
-	Status        string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	ID                   string `gorm:"primaryKey"`
+	OrganizerID          string `gorm:"index"`
+	Title                string
+	Description          string
+	Location             string
+	StartDatetime        time.Time
+	EndDatetime          time.Time
+	Capacity             int
+	Status               string
+	CategoryID           *string `gorm:"index"`
+	RequiresApproval     bool
+	AllowTransfers       bool
+	RegistrationOpensAt  *time.Time
+	RegistrationClosesAt *time.Time
+	Visibility           string `gorm:"default:public"`
+	Slug                 string
+	AccessCode           string
+	OrganizationID       *string `gorm:"index"`
+	PublishAt            *time.Time
+	PublishClaimedUntil  *time.Time
+	Version              int64 `gorm:"not null;default:1"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	DeletedAt            gorm.DeletedAt `gorm:"index"`
 }
 
 func (eventTestModel) TableName() string {
@@ -37,10 +49,412 @@ type userTestModel struct {
 
 // SQLITE registrations
 type registrationTestModel struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string
+	EventID      string
+	TicketTypeID *string
+	OrderID      *string
+	Status       string
+	RefundCents  int64
+	CancelledAt  *time.Time
+	ReviewedBy   *string
+	ReviewedAt   *time.Time
+	ReviewReason string
+	Answers      *string
+	GroupID      *string
+	IsGuest      bool
+	GuestName    *string
+	GuestEmail   *string
+	TicketToken  *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt
+}
+
+func (registrationTestModel) TableName() string {
+	return "registrations"
+}
+
+// SQLITE categories
+type categoryTestModel struct {
+	ID          string `gorm:"primaryKey"`
+	ParentID    *string
+	Name        string
+	Slug        string `gorm:"uniqueIndex"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (categoryTestModel) TableName() string {
+	return "categories"
+}
+
+// SQLITE tags
+type tagTestModel struct {
 	ID        string `gorm:"primaryKey"`
-	UserID    string
-	EventID   string
+	Name      string `gorm:"uniqueIndex"`
+	CreatedAt time.Time
+}
+
+func (tagTestModel) TableName() string {
+	return "tags"
+}
+
+// SQLITE event_tags join table
+type eventTagTestModel struct {
+	EventID string `gorm:"primaryKey"`
+	TagID   string `gorm:"primaryKey"`
+}
+
+func (eventTagTestModel) TableName() string {
+	return "event_tags"
+}
+
+// SQLITE ticket_types
+type ticketTypeTestModel struct {
+	ID          string `gorm:"primaryKey"`
+	EventID     string `gorm:"index"`
+	Name        string
+	Description string
+	PriceCents  int64
+	Currency    string
+	Quantity    int
+	SalesStart  *time.Time
+	SalesEnd    *time.Time
+	MaxPerOrder int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (ticketTypeTestModel) TableName() string {
+	return "ticket_types"
+}
+
+// SQLITE orders
+type orderTestModel struct {
+	ID                string `gorm:"primaryKey"`
+	UserID            string `gorm:"index"`
+	EventID           string `gorm:"index"`
+	TicketTypeID      string
+	Quantity          int
+	AmountCents       int64
+	DiscountCents     int64
+	PromoCodeID       *string
+	RefundedCents     int64
+	RefundIssuedCents int64
+	Currency          string
+	Status            string
+	Provider          string
+	ProviderIntentID  *string `gorm:"uniqueIndex"`
+	ExpiresAt         time.Time
+	PaidAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (orderTestModel) TableName() string {
+	return "orders"
+}
+
+// SQLITE promo_codes
+type promoCodeTestModel struct {
+	ID             string `gorm:"primaryKey"`
+	EventID        string `gorm:"index"`
+	Code           string
+	DiscountType   string
+	DiscountValue  int64
+	MaxRedemptions *int
+	PerUserLimit   int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+func (promoCodeTestModel) TableName() string {
+	return "promo_codes"
+}
+
+// SQLITE promo_code_ticket_types join table
+type promoCodeTicketTypeTestModel struct {
+	PromoCodeID  string `gorm:"primaryKey"`
+	TicketTypeID string `gorm:"primaryKey"`
+}
+
+func (promoCodeTicketTypeTestModel) TableName() string {
+	return "promo_code_ticket_types"
+}
+
+// SQLITE promo_redemptions
+type promoRedemptionTestModel struct {
+	ID             string `gorm:"primaryKey"`
+	PromoCodeID    string `gorm:"index"`
+	UserID         string
+	RegistrationID string
+	OrderID        *string
+	DiscountCents  int64
+	CreatedAt      time.Time
+}
+
+func (promoRedemptionTestModel) TableName() string {
+	return "promo_redemptions"
+}
+
+// SQLITE cancellation_policies
+type cancellationPolicyTestModel struct {
+	EventID           string `gorm:"primaryKey"`
+	AllowCancellation bool
+	CutoffHours       int
+	RefundTiers       string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (cancellationPolicyTestModel) TableName() string {
+	return "cancellation_policies"
+}
+
+// SQLITE registration_forms
+type registrationFormTestModel struct {
+	EventID              string `gorm:"primaryKey"`
+	Questions            string
+	AnswersEditableUntil *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (registrationFormTestModel) TableName() string {
+	return "registration_forms"
+}
+
+// SQLITE registration_transfers
+type registrationTransferTestModel struct {
+	ID             string `gorm:"primaryKey"`
+	RegistrationID string `gorm:"index"`
+	EventID        string `gorm:"index"`
+	FromUserID     string
+	ToEmail        string `gorm:"index"`
+	ToUserID       *string
+	Status         string
+	ExpiresAt      time.Time
+	RespondedAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (registrationTransferTestModel) TableName() string {
+	return "registration_transfers"
+}
+
+// SQLITE event_invitations
+type eventInvitationTestModel struct {
+	ID        string `gorm:"primaryKey"`
+	EventID   string `gorm:"uniqueIndex:idx_event_invitations_event_email"`
+	Email     string `gorm:"uniqueIndex:idx_event_invitations_event_email"`
+	InvitedBy string
+	CreatedAt time.Time
+}
+
+func (eventInvitationTestModel) TableName() string {
+	return "event_invitations"
+}
+
+// SQLITE event_members
+type eventMemberTestModel struct {
+	ID        string `gorm:"primaryKey"`
+	EventID   string `gorm:"uniqueIndex:idx_event_members_event_user"`
+	UserID    string `gorm:"uniqueIndex:idx_event_members_event_user"`
+	Role      string
+	AddedBy   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (eventMemberTestModel) TableName() string {
+	return "event_members"
+}
+
+// SQLITE organizations
+type organizationTestModel struct {
+	ID          string `gorm:"primaryKey"`
+	Name        string
+	Slug        string `gorm:"uniqueIndex"`
+	Description string
+	CreatedBy   *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (organizationTestModel) TableName() string {
+	return "organizations"
+}
+
+// SQLITE organization_members
+type organizationMemberTestModel struct {
+	ID             string `gorm:"primaryKey"`
+	OrganizationID string `gorm:"uniqueIndex:idx_organization_members_org_user"`
+	UserID         string `gorm:"uniqueIndex:idx_organization_members_org_user"`
+	Role           string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (organizationMemberTestModel) TableName() string {
+	return "organization_members"
+}
+
+// SQLITE event_status_changes
+type eventStatusChangeTestModel struct {
+	ID         string `gorm:"primaryKey"`
+	EventID    string `gorm:"index"`
+	FromStatus string
+	ToStatus   string
+	ChangedBy  *string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+func (eventStatusChangeTestModel) TableName() string {
+	return "event_status_changes"
+}
+
+// SQLITE event_revisions
+type eventRevisionTestModel struct {
+	ID         string `gorm:"primaryKey"`
+	EventID    string `gorm:"index"`
+	Version    int64
+	Action     string
+	ActorID    *string
+	Changes    string
+	RevertedTo *int64
+	CreatedAt  time.Time
+}
+
+func (eventRevisionTestModel) TableName() string {
+	return "event_revisions"
+}
+
+// SQLITE import_jobs
+type importJobTestModel struct {
+	ID            string `gorm:"primaryKey"`
+	Kind          string
+	EventID       *string
+	UserID        string `gorm:"index"`
+	Status        string
+	TotalRows     int
+	ProcessedRows int
+	ImportedRows  int
+	FailedRows    int
+	Errors        string
+	CreatedIDs    string
+	Error         string
+	CreatedAt     time.Time
+	FinishedAt    *time.Time
+}
+
+func (importJobTestModel) TableName() string {
+	return "import_jobs"
+}
+
+// SQLITE event_templates
+type eventTemplateTestModel struct {
+	ID               string `gorm:"primaryKey"`
+	OrganizerID      string `gorm:"index"`
+	Name             string
+	Title            string
+	Description      string
+	Location         string
+	Capacity         int
+	DurationMinutes  int
+	CategoryID       *string
+	Tags             string
+	RequiresApproval bool
+	AllowTransfers   bool
+	Visibility       string
+	OrganizationID   *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (eventTemplateTestModel) TableName() string {
+	return "event_templates"
+}
+
+// SQLITE speakers
+type speakerTestModel struct {
+	ID        string `gorm:"primaryKey"`
+	EventID   string `gorm:"index"`
+	Name      string
+	Headline  string
+	Bio       string
+	PhotoURL  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt
+}
+
+func (speakerTestModel) TableName() string {
+	return "speakers"
+}
+
+// SQLITE sessions
+type sessionTestModel struct {
+	ID            string `gorm:"primaryKey"`
+	EventID       string `gorm:"index"`
+	Title         string
+	Description   string
+	Track         string
+	Room          string
+	StartDatetime time.Time
+	EndDatetime   time.Time
+	Capacity      *int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (sessionTestModel) TableName() string {
+	return "sessions"
+}
+
+// SQLITE session_speakers join table
+type sessionSpeakerTestModel struct {
+	SessionID string `gorm:"primaryKey"`
+	SpeakerID string `gorm:"primaryKey"`
+}
+
+func (sessionSpeakerTestModel) TableName() string {
+	return "session_speakers"
+}
+
+// SQLITE session_registrations
+type sessionRegistrationTestModel struct {
+	ID        string `gorm:"primaryKey"`
+	SessionID string `gorm:"uniqueIndex:idx_session_registrations_session_user"`
+	UserID    string `gorm:"uniqueIndex:idx_session_registrations_session_user"`
+	CreatedAt time.Time
+}
+
+func (sessionRegistrationTestModel) TableName() string {
+	return "session_registrations"
+}
+
+// SQLITE event_files
+type eventFileTestModel struct {
+	ID           string `gorm:"primaryKey"`
+	EventID      string `gorm:"index"`
+	Kind         string
+	Name         string
+	ContentType  string
+	Size         int64
+	StorageKey   string
+	ThumbnailKey string
+	UploadedBy   *string
+	CreatedAt    time.Time
+}
+
+func (eventFileTestModel) TableName() string {
+	return "event_files"
 }
@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_OwnEventsAndTransferOwnership(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	orgService := service.NewOrganizationService(repository.NewOrganizationRepository(db), userRepo)
+	memberService := service.NewMemberService(repository.NewEventMemberRepository(db), eventRepo, eventService, userRepo,
+		service.NewNotificationService(repository.NewNotificationRepository(db), nil))
+
+	manager := createTestUser(t, db, "manager@example.com")
+	admin := createTestUser(t, db, "admin@example.com")
+	member := createTestUser(t, db, "member@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+
+	org, err := orgService.CreateOrganization(admin.ID, &domain.CreateOrganizationRequest{Name: "Acme Events"})
+	require.NoError(t, err)
+	require.Equal(t, "acme-events", org.Slug)
+	_, err = orgService.CreateOrganization(outsider.ID, &domain.CreateOrganizationRequest{Name: "ACME events!"})
+	require.ErrorContains(t, err, "already taken")
+
+	_, err = orgService.AddMember(admin.ID, org.Slug, &domain.AddOrganizationMemberRequest{Email: manager.Email, Role: domain.OrganizationRoleMember})
+	require.NoError(t, err)
+	_, err = orgService.AddMember(admin.ID, org.Slug, &domain.AddOrganizationMemberRequest{Email: member.Email, Role: domain.OrganizationRoleMember})
+	require.NoError(t, err)
+	_, err = orgService.AddMember(member.ID, org.Slug, &domain.AddOrganizationMemberRequest{Email: outsider.Email, Role: domain.OrganizationRoleMember})
+	require.ErrorContains(t, err, "only organization admins")
+	_, err = orgService.AddMember(admin.ID, org.Slug, &domain.AddOrganizationMemberRequest{Email: admin.Email, Role: domain.OrganizationRoleMember})
+	require.ErrorContains(t, err, "at least one admin")
+
+	start := time.Now().Add(48 * time.Hour)
+	create := func(userID string, orgID *string, visibility string) (*domain.Event, error) {
+		return eventService.CreateEvent(userID, &domain.CreateEventRequest{
+			Title:          "Quarterly Meetup",
+			Location:       "Hall",
+			StartDatetime:  start,
+			EndDatetime:    start.Add(2 * time.Hour),
+			Capacity:       10,
+			Visibility:     visibility,
+			OrganizationID: orgID,
+		})
+	}
+	_, err = create(outsider.ID, &org.ID, "")
+	require.ErrorContains(t, err, "only members of the organization")
+
+	public, err := create(manager.ID, &org.ID, "")
+	require.NoError(t, err)
+	private, err := create(manager.ID, &org.ID, domain.EventVisibilityPrivate)
+	require.NoError(t, err)
+
+	// org admins manage all of the organization's events, members only view them
+	require.NoError(t, eventService.PublishEvent(admin.ID, public.ID))
+	require.NoError(t, eventService.PublishEvent(admin.ID, private.ID))
+	_, err = eventService.GetEventByID(private.ID, member.ID, "")
+	require.NoError(t, err)
+	require.ErrorContains(t, eventService.Cancel(member.ID, private.ID), "only the event organizers")
+	_, err = eventService.GetEventByID(private.ID, outsider.ID, "")
+	require.ErrorContains(t, err, "event not found")
+
+	// and can register for the private event without an invitation, like its team
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+	_, err = regService.RegisterUser(admin.ID, private.ID, nil)
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(member.ID, private.ID, nil)
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(outsider.ID, private.ID, nil)
+	require.ErrorIs(t, err, domain.ErrInvitationRequired)
+
+	// the public profile lists upcoming public events only
+	profile, err := orgService.GetProfile(org.Slug)
+	require.NoError(t, err)
+	require.Equal(t, org.ID, profile.Organization.ID)
+	require.Len(t, profile.Events, 1)
+	require.Equal(t, public.ID, profile.Events[0].ID)
+
+	// the manager leaves: the organization keeps the event
+	require.NoError(t, orgService.RemoveMember(manager.ID, org.Slug, manager.ID))
+	_, err = memberService.TransferOwnership(admin.ID, private.ID, &domain.TransferEventOwnershipRequest{Email: member.Email})
+	require.NoError(t, err)
+	transferred, err := eventService.GetEventByID(private.ID, member.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, member.ID, transferred.OrganizerID)
+	require.Nil(t, transferred.OrganizationID)
+	history, err := eventService.GetHistory(member.ID, private.ID)
+	require.NoError(t, err)
+	last := history[len(history)-1]
+	require.Equal(t, domain.EventRevisionTransferred, last.Action)
+	require.Equal(t, transferred.Version, last.Version)
+	require.Equal(t, &admin.ID, last.ActorID)
+	require.Equal(t, []domain.FieldChange{
+		{Field: "organizer_id", Old: manager.ID, New: member.ID},
+		{Field: "organization_id", Old: org.ID, New: nil},
+	}, last.Changes)
+	require.ErrorContains(t, eventService.Cancel(admin.ID, private.ID), "only the event organizers")
+
+	// and back to the organization
+	_, err = memberService.TransferOwnership(member.ID, private.ID, &domain.TransferEventOwnershipRequest{OrganizationID: org.ID})
+	require.NoError(t, err)
+	require.NoError(t, eventService.Cancel(admin.ID, private.ID))
+	_, err = memberService.TransferOwnership(outsider.ID, public.ID, &domain.TransferEventOwnershipRequest{Email: outsider.Email})
+	require.ErrorContains(t, err, "only the event owners")
+
+	orgs, err := orgService.GetUserOrganizations(member.ID)
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+}
@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type paymentTestEnv struct {
+	db             *gorm.DB
+	provider       *payment.FakeProvider
+	paymentService *service.PaymentService
+	regService     *service.RegistrationService
+	ticketService  *service.TicketService
+	eventID        string
+	ticketType     *domain.TicketType
+}
+
+// setupPaymentTest creates a published event with a single paid ticket type of the given stock
+func setupPaymentTest(t *testing.T, quantity int) *paymentTestEnv {
+	t.Helper()
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	provider := payment.NewFakeProvider("test-secret", "")
+	paymentService := service.NewPaymentService(repository.NewOrderRepository(db), provider, 15*time.Minute)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	ticketType, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "Standard", PriceCents: 2500, Currency: "eur", Quantity: quantity,
+	})
+	require.NoError(t, err)
+
+	return &paymentTestEnv{
+		db:             db,
+		provider:       provider,
+		paymentService: paymentService,
+		regService:     service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo, repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), paymentService, nil, nil),
+		ticketService:  ticketService,
+		eventID:        event.ID,
+		ticketType:     ticketType,
+	}
+}
+
+func (env *paymentTestEnv) registrationStatus(t *testing.T, id string) string {
+	t.Helper()
+	var status string
+	require.NoError(t, env.db.Table("registrations").Where("id = ?", id).Pluck("status", &status).Error)
+	return status
+}
+
+func TestPayments_CheckoutConfirmedByWebhook(t *testing.T) {
+	env := setupPaymentTest(t, 5)
+	userID := uuid.NewString()
+
+	reg, err := env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusPendingPayment, reg.Status)
+	require.NotNil(t, reg.Order)
+	require.Equal(t, int64(2500), reg.Order.AmountCents)
+	require.Equal(t, "EUR", reg.Order.Currency)
+	require.NotEmpty(t, reg.Order.ClientSecret)
+
+	// the pending seat counts against stock and blocks a second registration
+	ticketTypes, err := env.ticketService.GetTicketTypes(env.eventID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), ticketTypes[0].Sold)
+	_, err = env.regService.RegisterUser(userID, env.eventID, nil)
+	require.ErrorContains(t, err, "already registered")
+
+	// an unsigned webhook is rejected
+	payload, _, err := env.provider.BuildWebhook(*reg.Order.ProviderIntentID, payment.EventPaymentSucceeded)
+	require.NoError(t, err)
+	require.ErrorIs(t, env.paymentService.HandleWebhook(payload, "t=1,v1=deadbeef"), payment.ErrInvalidSignature)
+	require.Equal(t, domain.RegistrationStatusPendingPayment, env.registrationStatus(t, reg.ID))
+
+	order, err := env.paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusPaid, order.Status)
+	require.NotNil(t, order.PaidAt)
+	require.Equal(t, domain.RegistrationStatusConfirmed, env.registrationStatus(t, reg.ID))
+
+	// webhook deliveries are idempotent
+	payload, signature, err := env.provider.BuildWebhook(*reg.Order.ProviderIntentID, payment.EventPaymentSucceeded)
+	require.NoError(t, err)
+	require.NoError(t, env.paymentService.HandleWebhook(payload, signature))
+	require.Equal(t, domain.RegistrationStatusConfirmed, env.registrationStatus(t, reg.ID))
+
+	_, err = env.paymentService.SimulatePayment(uuid.NewString(), reg.Order.ID, true)
+	require.ErrorContains(t, err, "order not found")
+}
+
+func TestPayments_DeclinedPaymentReleasesSeat(t *testing.T) {
+	env := setupPaymentTest(t, 1)
+	userID := uuid.NewString()
+
+	reg, err := env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+
+	_, err = env.regService.RegisterUser(uuid.NewString(), env.eventID, nil)
+	require.ErrorContains(t, err, "sold out")
+
+	order, err := env.paymentService.SimulatePayment(userID, reg.Order.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusFailed, order.Status)
+	require.Equal(t, domain.RegistrationStatusCancelled, env.registrationStatus(t, reg.ID))
+
+	// the seat is available again, also to the same user
+	_, err = env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+}
+
+func TestPayments_ExpiredHoldsAreReleased(t *testing.T) {
+	env := setupPaymentTest(t, 1)
+	userID := uuid.NewString()
+
+	reg, err := env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+
+	expired, err := env.paymentService.ExpireHolds(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, expired, "hold has not lapsed yet")
+
+	require.NoError(t, env.db.Table("orders").Where("id = ?", reg.Order.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	expired, err = env.paymentService.ExpireHolds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+	require.Equal(t, domain.RegistrationStatusExpired, env.registrationStatus(t, reg.ID))
+
+	other, err := env.regService.RegisterUser(uuid.NewString(), env.eventID, nil)
+	require.NoError(t, err, "expired hold frees the seat")
+
+	// paying the expired order afterwards is refunded instead of confirming the seat
+	order, err := env.paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusExpired, order.Status)
+	require.Equal(t, domain.RegistrationStatusExpired, env.registrationStatus(t, reg.ID))
+	_, err = env.provider.Refund(context.Background(), *reg.Order.ProviderIntentID, 1)
+	require.ErrorIs(t, err, payment.ErrRefundExceeds, "the full amount was refunded")
+
+	require.Equal(t, domain.RegistrationStatusPendingPayment, env.registrationStatus(t, other.ID))
+}
+
+func TestPayments_LatePaymentIsRefundedOnce(t *testing.T) {
+	env := setupPaymentTest(t, 1)
+	userID := uuid.NewString()
+
+	reg, err := env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+	require.NoError(t, env.db.Table("orders").Where("id = ?", reg.Order.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	order, err := env.paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusExpired, order.Status)
+	require.Equal(t, int64(2500), order.RefundedCents)
+
+	// replays of the webhook are accepted without refunding again
+	payload, signature, err := env.provider.BuildWebhook(*reg.Order.ProviderIntentID, payment.EventPaymentSucceeded)
+	require.NoError(t, err)
+	require.NoError(t, env.paymentService.HandleWebhook(payload, signature))
+	require.NoError(t, env.paymentService.HandleWebhook(payload, signature))
+	order, err = env.paymentService.GetOrder(reg.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), order.RefundedCents)
+	require.Zero(t, order.OutstandingRefundCents())
+}
+
+func TestPayments_OutstandingRefundsAreRetried(t *testing.T) {
+	env := setupPaymentTest(t, 1)
+	userID := uuid.NewString()
+
+	reg, err := env.regService.RegisterUser(userID, env.eventID, nil)
+	require.NoError(t, err)
+	_, err = env.paymentService.SimulatePayment(userID, reg.Order.ID, true)
+	require.NoError(t, err)
+
+	// a refund recorded on the order whose sending failed
+	require.NoError(t, env.db.Table("orders").Where("id = ?", reg.Order.ID).
+		Update("refunded_cents", 1000).Error)
+
+	refunded, err := env.paymentService.RetryRefunds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), refunded)
+	_, err = env.provider.Refund(context.Background(), *reg.Order.ProviderIntentID, 1501)
+	require.ErrorIs(t, err, payment.ErrRefundExceeds, "1000 of 2500 were refunded")
+
+	refunded, err = env.paymentService.RetryRefunds(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, refunded, "nothing left to send")
+}
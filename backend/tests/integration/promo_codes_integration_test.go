@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoCodes_DiscountsAndLimits(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	promoService := service.NewPromoService(repository.NewPromoCodeRepository(db), ticketRepo, eventRepo)
+	paymentService := service.NewPaymentService(repository.NewOrderRepository(db), payment.NewFakeProvider("test-secret", ""), 15*time.Minute)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo, repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), paymentService, promoService, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	general, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{Name: "General", PriceCents: 4000, Quantity: 10})
+	require.NoError(t, err)
+	vip, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{Name: "VIP", PriceCents: 10000, Quantity: 10})
+	require.NoError(t, err)
+
+	maxTwo := 2
+	percent, err := promoService.CreatePromoCode(organizerID, event.ID, &domain.CreatePromoCodeRequest{
+		Code: "early25", DiscountType: domain.DiscountTypePercent, DiscountValue: 25,
+		MaxRedemptions: &maxTwo, TicketTypeIDs: []string{general.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "EARLY25", percent.Code)
+
+	_, err = promoService.CreatePromoCode(organizerID, event.ID, &domain.CreatePromoCodeRequest{
+		Code: "Early25", DiscountType: domain.DiscountTypeFixed, DiscountValue: 100,
+	})
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = promoService.CreatePromoCode(uuid.NewString(), event.ID, &domain.CreatePromoCodeRequest{
+		Code: "HACK", DiscountType: domain.DiscountTypeFixed, DiscountValue: 100,
+	})
+	require.ErrorContains(t, err, "only the event organizer")
+
+	// percent discount on an allowed ticket type
+	userA := uuid.NewString()
+	reg, err := regService.RegisterUser(userA, event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "early25"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), reg.Order.AmountCents)
+	require.Equal(t, int64(1000), reg.Order.DiscountCents)
+
+	// restricted to General
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &vip.ID, PromoCode: "EARLY25"})
+	require.ErrorContains(t, err, "does not apply")
+
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "NOPE"})
+	require.ErrorContains(t, err, "invalid promo code")
+
+	userB := uuid.NewString()
+	_, err = regService.RegisterUser(userB, event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "EARLY25"})
+	require.NoError(t, err)
+
+	// max redemptions reached; the failed attempt leaves no registration behind
+	userC := uuid.NewString()
+	_, err = regService.RegisterUser(userC, event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "EARLY25"})
+	require.ErrorContains(t, err, "redemption limit")
+	_, err = regService.RegisterUser(userC, event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID})
+	require.NoError(t, err)
+
+	// cancelling releases the redemption
+	_, err = regService.CancelRegistration(userB, event.ID)
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "EARLY25"})
+	require.NoError(t, err)
+
+	stats, err := promoService.GetPromoCodeStats(organizerID, event.ID, percent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.Redemptions)
+	require.Equal(t, int64(2), stats.PendingRedemptions)
+	require.Equal(t, int64(1), stats.ReleasedRedemptions)
+	require.Equal(t, int64(2000), stats.TotalDiscountCents)
+	require.Equal(t, int64(0), *stats.Remaining)
+
+	// a full discount makes the ticket free: confirmed right away without an order
+	_, err = promoService.CreatePromoCode(organizerID, event.ID, &domain.CreatePromoCodeRequest{
+		Code: "SPEAKER", DiscountType: domain.DiscountTypeFixed, DiscountValue: 50000, PerUserLimit: 1,
+	})
+	require.NoError(t, err)
+	free, err := regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &vip.ID, PromoCode: "speaker"})
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusConfirmed, free.Status)
+	require.Nil(t, free.Order)
+
+	// deactivated codes cannot be redeemed
+	inactive := false
+	_, err = promoService.UpdatePromoCode(organizerID, event.ID, percent.ID, &domain.UpdatePromoCodeRequest{Active: &inactive})
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID, PromoCode: "EARLY25"})
+	require.ErrorContains(t, err, "not active")
+}
+
+func TestPromoCodes_ConcurrentRedemptionsRespectLimit(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	promoService := service.NewPromoService(repository.NewPromoCodeRepository(db), ticketRepo, eventRepo)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo, repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, promoService, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+	_, err := service.NewTicketService(ticketRepo, eventRepo).CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "General", PriceCents: 1000, Quantity: 10,
+	})
+	require.NoError(t, err)
+
+	limit := 3
+	promo, err := promoService.CreatePromoCode(organizerID, event.ID, &domain.CreatePromoCodeRequest{
+		Code: "FREEBIE", DiscountType: domain.DiscountTypePercent, DiscountValue: 100, MaxRedemptions: &limit,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{PromoCode: "FREEBIE"})
+		}()
+	}
+	wg.Wait()
+
+	stats, err := promoService.GetPromoCodeStats(organizerID, event.ID, promo.ID)
+	require.NoError(t, err)
+	require.LessOrEqual(t, stats.Redemptions, int64(limit))
+	require.Positive(t, stats.Redemptions)
+}
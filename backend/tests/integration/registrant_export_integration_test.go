@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/export"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrantExport_Formats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+	r := gin.New()
+	handler.NewRegistrationHandler(r, regService, middleware.Auth("test-secret"))
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+	ada := createTestUser(t, db, "ada@example.com")
+	alan := createTestUser(t, db, "alan@example.com")
+	event := insertEventDirectly(t, db, organizer.ID)
+
+	require.NoError(t, db.Create(&domain.RegistrationForm{EventID: event.ID, Questions: []domain.FormQuestion{
+		{ID: "tshirt", Label: "T-shirt size", Type: domain.QuestionTypeSingleChoice, Options: []string{"S", "M"}},
+		{ID: "topics", Label: "Topics", Type: domain.QuestionTypeMultiChoice, Options: []string{"go", "rust"}},
+	}}).Error)
+	adaSeat := &domain.Registration{
+		ID: uuid.NewString(), UserID: ada.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken(),
+		Answers: domain.FormAnswers{"tshirt": "M", "topics": []interface{}{"go", "rust"}},
+	}
+	require.NoError(t, db.Create(adaSeat).Error)
+	require.NoError(t, db.Create(&domain.Registration{
+		ID: uuid.NewString(), UserID: ada.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken(),
+		IsGuest: true, GroupID: &adaSeat.ID, GuestName: "Grace", GuestEmail: "grace@example.com",
+		CreatedAt: time.Now().Add(time.Second),
+	}).Error)
+	require.NoError(t, db.Create(&domain.Registration{
+		ID: uuid.NewString(), UserID: alan.ID, EventID: event.ID, Status: domain.RegistrationStatusCancelled, TicketToken: domain.NewTicketToken(),
+		CreatedAt: time.Now().Add(2 * time.Second),
+	}).Error)
+	require.NoError(t, regService.CheckInAttendee(organizer.ID, event.ID, ada.ID))
+
+	get := func(user *domain.User, query, accept string) *httptest.ResponseRecorder {
+		token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/events/"+event.ID+"/registrants"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// CSV, selected by format=
+	w := get(organizer, "?format=csv", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="registrants-`+event.ID+`.csv"`)
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, []string{"registration_id", "name", "email", "is_guest", "booked_by", "status", "ticket_type",
+		"registered_at", "checked_in_at", "T-shirt size", "Topics"}, rows[0])
+	require.Equal(t, []string{adaSeat.ID, ada.Name, "ada@example.com", "false", "ada@example.com", domain.RegistrationStatusCheckedIn, ""}, rows[1][:7])
+	checkedIn, err := time.Parse(time.RFC3339, rows[1][8])
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), checkedIn, time.Minute)
+	require.Equal(t, []string{"M", "go; rust"}, rows[1][9:])
+	require.Equal(t, []string{"Grace", "grace@example.com", "true", "ada@example.com", domain.RegistrationStatusConfirmed}, rows[2][1:6])
+	require.Empty(t, rows[2][8])
+	require.Equal(t, domain.RegistrationStatusCancelled, rows[3][5])
+
+	// NDJSON, selected by the Accept header, with the status filter
+	w = get(organizer, "?status=checked_in", "application/x-ndjson")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	scanner := bufio.NewScanner(w.Body)
+	var records []domain.RegistrantRecord
+	for scanner.Scan() {
+		var record domain.RegistrantRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 1)
+	require.Equal(t, adaSeat.ID, records[0].RegistrationID)
+	require.NotNil(t, records[0].CheckedInAt)
+	require.Equal(t, "M", records[0].Answers["tshirt"])
+
+	// XLSX
+	w = get(organizer, "", export.XLSXContentType)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, export.XLSXContentType, w.Header().Get("Content-Type"))
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	var sheet string
+	for _, f := range archive.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+			sheet = string(data)
+		}
+	}
+	require.Equal(t, 4, strings.Count(sheet, "<row "))
+	require.Contains(t, sheet, "grace@example.com")
+
+	// JSON stays the default
+	w = get(organizer, "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	w = get(organizer, "?format=pdf", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = get(outsider, "?format=csv", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "export registrants")
+}
@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/payment"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationApproval_ReviewFlow(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), nil)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, notificationService)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"capacity":          1,
+		"requires_approval": true,
+	}).Error)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	// applications don't consume capacity
+	first, second, third := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	applications := map[string]*domain.Registration{}
+	for _, userID := range []string{first, second, third} {
+		reg, err := regService.RegisterUser(userID, event.ID, nil)
+		require.NoError(t, err)
+		require.Equal(t, domain.RegistrationStatusPending, reg.Status)
+		applications[userID] = reg
+	}
+	_, err := regService.RegisterUser(first, event.ID, nil)
+	require.ErrorContains(t, err, "already registered (status: pending)")
+
+	_, err = regService.ApproveRegistration(first, event.ID, applications[first].ID, "")
+	require.ErrorContains(t, err, "only the event organizer")
+
+	approved, err := regService.ApproveRegistration(organizerID, event.ID, applications[first].ID, "")
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusConfirmed, approved.Status)
+	require.Equal(t, organizerID, *approved.ReviewedBy)
+	require.NotNil(t, approved.ReviewedAt)
+
+	_, err = regService.ApproveRegistration(organizerID, event.ID, applications[first].ID, "")
+	require.ErrorContains(t, err, "not pending approval")
+
+	// capacity is re-checked on approval
+	_, err = regService.ApproveRegistration(organizerID, event.ID, applications[second].ID, "")
+	require.ErrorContains(t, err, "event is full")
+
+	rejected, err := regService.RejectRegistration(organizerID, event.ID, applications[third].ID, "Invite only")
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusRejected, rejected.Status)
+	require.Equal(t, "Invite only", rejected.ReviewReason)
+
+	// a pending application can be withdrawn regardless of the cancellation policy
+	withdrawn, err := regService.CancelRegistration(second, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusCancelled, withdrawn.Status)
+
+	// both outcomes notify the applicant
+	notifications, err := notificationService.GetUserNotifications(first)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Registration approved", notifications[0].Title)
+
+	notifications, err = notificationService.GetUserNotifications(third)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Registration declined", notifications[0].Title)
+	require.Contains(t, notifications[0].Message, "Invite only")
+
+	pending, err := regService.GetEventRegistrants(organizerID, event.ID, domain.RegistrationStatusPending)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestRegistrationApproval_PaidTicketCheckoutStartsOnApproval(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+	paymentService := service.NewPaymentService(orderRepo, payment.NewFakeProvider("test-secret", ""), 15*time.Minute)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo,
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), paymentService, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("requires_approval", true).Error)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+	_, err := service.NewTicketService(ticketRepo, eventRepo).CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "General", PriceCents: 5000, Quantity: 10,
+	})
+	require.NoError(t, err)
+
+	userID := uuid.NewString()
+	reg, err := regService.RegisterUser(userID, event.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusPending, reg.Status)
+	require.Nil(t, reg.OrderID)
+
+	approved, err := regService.ApproveRegistration(organizerID, event.ID, reg.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusPendingPayment, approved.Status)
+	require.NotNil(t, approved.Order)
+	require.NotEmpty(t, approved.Order.ClientSecret)
+
+	order, err := paymentService.SimulatePayment(userID, approved.Order.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusPaid, order.Status)
+
+	confirmed, err := repository.NewRegistrationRepository(db).GetByID(event.ID, reg.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStatusConfirmed, confirmed.Status)
+}
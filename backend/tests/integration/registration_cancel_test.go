@@ -47,7 +47,7 @@ func setupCancelTestRouter(t *testing.T) *cancelTestContext {
 	regRepo := repository.NewRegistrationRepository(db)
 
 	authService := service.NewAuthService(userRepo, "test-secret", time.Hour)
-	regService := service.NewRegistrationService(regRepo, eventRepo)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db), repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
 
 	handler.NewAuthHandler(r, authService)
 	handler.NewRegistrationHandler(r, regService, middleware.Auth("test-secret"))
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationForm_AnswersAreValidatedStoredAndEditable(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	formReq := &domain.UpdateRegistrationFormRequest{
+		Questions: []domain.FormQuestion{
+			{ID: "tshirt_size", Label: "T-shirt size", Type: domain.QuestionTypeSingleChoice, Required: true, Options: []string{"S", "M", "L"}},
+			{ID: "dietary", Label: "Dietary needs", Type: domain.QuestionTypeMultiChoice, Options: []string{"vegan", "gluten_free"}},
+		},
+	}
+	_, err := regService.SetRegistrationForm(uuid.NewString(), event.ID, formReq)
+	require.ErrorContains(t, err, "only the event organizer")
+	_, err = regService.SetRegistrationForm(organizerID, event.ID, formReq)
+	require.NoError(t, err)
+
+	form, err := regService.GetRegistrationForm(event.ID)
+	require.NoError(t, err)
+	require.Len(t, form.Questions, 2)
+
+	// answers are validated on registration
+	userID := uuid.NewString()
+	_, err = regService.RegisterUser(userID, event.ID, nil)
+	require.ErrorContains(t, err, `"tshirt_size" is required`)
+
+	reg, err := regService.RegisterUser(userID, event.ID, &domain.RegisterRequest{
+		Answers: domain.FormAnswers{"tshirt_size": "M", "dietary": []interface{}{"vegan"}},
+	})
+	require.NoError(t, err)
+
+	registrants, err := regService.GetEventRegistrants(organizerID, event.ID, "all")
+	require.NoError(t, err)
+	require.Len(t, registrants, 1)
+	require.Equal(t, "M", registrants[0].Answers["tshirt_size"])
+	require.Equal(t, []interface{}{"vegan"}, registrants[0].Answers["dietary"])
+
+	// answers can be changed until the deadline
+	updated, err := regService.UpdateAnswers(userID, event.ID, domain.FormAnswers{"tshirt_size": "L"})
+	require.NoError(t, err)
+	require.Equal(t, reg.ID, updated.ID)
+	require.Equal(t, "L", updated.Answers["tshirt_size"])
+
+	_, err = regService.UpdateAnswers(userID, event.ID, domain.FormAnswers{"tshirt_size": "XXL"})
+	require.ErrorContains(t, err, "must be one of")
+
+	deadline := time.Now().Add(-time.Minute)
+	formReq.AnswersEditableUntil = &deadline
+	_, err = regService.SetRegistrationForm(organizerID, event.ID, formReq)
+	require.NoError(t, err)
+
+	_, err = regService.UpdateAnswers(userID, event.ID, domain.FormAnswers{"tshirt_size": "S"})
+	require.True(t, errors.Is(err, domain.ErrAnswersLocked))
+
+	_, err = regService.UpdateAnswers(uuid.NewString(), event.ID, domain.FormAnswers{"tshirt_size": "S"})
+	require.ErrorContains(t, err, "registration not found")
+}
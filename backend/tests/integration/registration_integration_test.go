@@ -52,7 +52,7 @@ func setupRouter(t *testing.T) *testContext {
 
 	// Creating services with REAL repositories
 	authService := service.NewAuthService(userRepo, "test-secret", time.Hour)
-	regService := service.NewRegistrationService(regRepo, eventRepo)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db), repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
 
 	// Registering handlers
 	handler.NewAuthHandler(r, authService)
@@ -78,6 +78,7 @@ func setupTables(t *testing.T, db *gorm.DB) {
         password_hash TEXT NOT NULL,
         name TEXT NOT NULL,
         role TEXT NOT NULL DEFAULT 'user',
+        strict_schedule BOOLEAN NOT NULL DEFAULT FALSE,
         created_at DATETIME,
         updated_at DATETIME,
         deleted_at DATETIME
@@ -95,6 +96,18 @@ func setupTables(t *testing.T, db *gorm.DB) {
         location TEXT NOT NULL,
         capacity INTEGER NOT NULL,
         status TEXT NOT NULL DEFAULT 'draft',
+        category_id TEXT,
+        requires_approval BOOLEAN NOT NULL DEFAULT 0,
+        allow_transfers BOOLEAN NOT NULL DEFAULT 1,
+        registration_opens_at DATETIME,
+        registration_closes_at DATETIME,
+        visibility TEXT NOT NULL DEFAULT 'public',
+        slug TEXT,
+        access_code TEXT,
+        organization_id TEXT,
+        publish_at DATETIME,
+        publish_claimed_until DATETIME,
+        version INTEGER NOT NULL DEFAULT 1,
         created_at DATETIME,
         updated_at DATETIME,
         deleted_at DATETIME
@@ -107,12 +120,28 @@ func setupTables(t *testing.T, db *gorm.DB) {
         user_id TEXT NOT NULL,
         event_id TEXT NOT NULL,
         status TEXT NOT NULL DEFAULT 'confirmed',
+        ticket_type_id TEXT,
+        order_id TEXT,
+        refund_cents INTEGER NOT NULL DEFAULT 0,
+        cancelled_at DATETIME,
+        checked_in_at DATETIME,
+        reviewed_by TEXT,
+        reviewed_at DATETIME,
+        review_reason TEXT,
+        answers TEXT,
+        group_id TEXT,
+        is_guest BOOLEAN NOT NULL DEFAULT 0,
+        guest_name TEXT,
+        guest_email TEXT,
+        ticket_token TEXT,
         registered_at DATETIME,
         created_at DATETIME,
         updated_at DATETIME,
-        deleted_at DATETIME,
-        UNIQUE(user_id, event_id)
-    );`
+        deleted_at DATETIME
+    );
+    CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_active_user_event ON registrations(user_id, event_id)
+        WHERE status IN ('pending', 'pending_payment', 'confirmed', 'checked_in') AND NOT is_guest AND deleted_at IS NULL;
+    CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_ticket_token ON registrations(ticket_token);`
 
 	if err := db.Exec(usersSQL).Error; err != nil {
 		t.Fatalf("Failed to create users table: %v", err)
@@ -123,6 +152,15 @@ func setupTables(t *testing.T, db *gorm.DB) {
 	if err := db.Exec(registrationsSQL).Error; err != nil {
 		t.Fatalf("Failed to create registrations table: %v", err)
 	}
+	if err := db.AutoMigrate(&categoryTestModel{}, &tagTestModel{}, &eventTagTestModel{}, &ticketTypeTestModel{}, &orderTestModel{},
+		&promoCodeTestModel{}, &promoCodeTicketTypeTestModel{}, &promoRedemptionTestModel{},
+		&cancellationPolicyTestModel{}, &registrationFormTestModel{}, &registrationTransferTestModel{}, &eventInvitationTestModel{},
+		&eventMemberTestModel{},
+		&organizationTestModel{}, &organizationMemberTestModel{}, &eventStatusChangeTestModel{}, &eventTemplateTestModel{},
+		&speakerTestModel{}, &sessionTestModel{}, &sessionSpeakerTestModel{}, &sessionRegistrationTestModel{}, &eventFileTestModel{},
+		&eventRevisionTestModel{}, &importJobTestModel{}); err != nil {
+		t.Fatalf("Failed to create taxonomy tables: %v", err)
+	}
 }
 
 func setupRegistrationRepo(t *testing.T, db *gorm.DB) *repository.RegistrationRepository {
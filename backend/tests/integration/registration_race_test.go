@@ -33,7 +33,7 @@ func TestRegistrationRaceCondition(t *testing.T) {
 	// Repos & Services
 	regRepo := repository.NewRegistrationRepository(db)
 	eventRepo := repository.NewEventRepository(db)
-	regService := service.NewRegistrationService(regRepo, eventRepo)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db), repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
 
 	// Generate valid UUIDs
 	eventID := uuid.NewString()
@@ -71,7 +71,7 @@ func TestRegistrationRaceCondition(t *testing.T) {
 
 		go func(uid string) {
 			defer wg.Done()
-			_, err := regService.RegisterUser(uid, eventID)
+			_, err := regService.RegisterUser(uid, eventID, nil)
 			results <- err
 		}(userID)
 	}
@@ -0,0 +1,161 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createTestUser(t *testing.T, db *gorm.DB, email string) *domain.User {
+	t.Helper()
+	user := &domain.User{ID: uuid.NewString(), Email: email, PasswordHash: "hash", Name: email, Role: "user"}
+	require.NoError(t, repository.NewUserRepository(db).Create(user))
+	return user
+}
+
+func TestRegistrationTransfer_AcceptMovesSeat(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	regRepo := repository.NewRegistrationRepository(db)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), nil)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+	transferService := service.NewTransferService(repository.NewRegistrationTransferRepository(db), regRepo, eventRepo,
+		repository.NewUserRepository(db), notificationService)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"capacity":        1,
+		"allow_transfers": true,
+	}).Error)
+	require.NoError(t, service.NewEventService(eventRepo, nil).PublishEvent(organizerID, event.ID))
+
+	sender := createTestUser(t, db, "sender@example.com")
+	recipient := createTestUser(t, db, "recipient@example.com")
+	stranger := createTestUser(t, db, "stranger@example.com")
+
+	reg, err := regService.RegisterUser(sender.ID, event.ID, nil)
+	require.NoError(t, err)
+	oldToken := reg.TicketToken
+	require.NotEmpty(t, oldToken)
+
+	_, err = transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: "Sender@example.com"})
+	require.ErrorContains(t, err, "to yourself")
+
+	transfer, err := transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: "Recipient@Example.com"})
+	require.NoError(t, err)
+	require.Equal(t, domain.TransferStatusPending, transfer.Status)
+	require.Equal(t, "recipient@example.com", transfer.ToEmail)
+	require.False(t, transfer.ExpiresAt.After(event.StartDatetime))
+
+	_, err = transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: "other@example.com"})
+	require.ErrorContains(t, err, "already pending")
+
+	// only the invited email can accept
+	_, err = transferService.AcceptTransfer(stranger.ID, transfer.ID)
+	require.ErrorContains(t, err, "transfer not found")
+
+	moved, err := transferService.AcceptTransfer(recipient.ID, transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, reg.ID, moved.ID)
+	require.Equal(t, recipient.ID, moved.UserID)
+	require.Equal(t, domain.RegistrationStatusConfirmed, moved.Status)
+	require.NotEmpty(t, moved.TicketToken)
+	require.NotEqual(t, oldToken, moved.TicketToken)
+
+	// the seat was reused: the (full) event still has exactly one seat taken
+	count, err := regRepo.CountByEvent(event.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	mine, err := regRepo.GetByUserAndEvent(sender.ID, event.ID)
+	require.NoError(t, err)
+	require.Nil(t, mine)
+
+	_, err = transferService.AcceptTransfer(recipient.ID, transfer.ID)
+	require.ErrorContains(t, err, "no longer pending")
+
+	// the organizer sees the audit trail, the sender is notified
+	_, err = transferService.GetEventTransfers(sender.ID, event.ID)
+	require.ErrorContains(t, err, "only the event organizer")
+	audit, err := transferService.GetEventTransfers(organizerID, event.ID)
+	require.NoError(t, err)
+	require.Len(t, audit, 1)
+	require.Equal(t, domain.TransferStatusAccepted, audit[0].Status)
+	require.Equal(t, recipient.ID, *audit[0].ToUserID)
+	require.NotNil(t, audit[0].RespondedAt)
+
+	notifications, err := notificationService.GetUserNotifications(sender.ID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Ticket transferred", notifications[0].Title)
+}
+
+func TestRegistrationTransfer_DisabledDeclinedAndCancelled(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	regRepo := repository.NewRegistrationRepository(db)
+	regService := service.NewRegistrationService(regRepo, eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+	transferService := service.NewTransferService(repository.NewRegistrationTransferRepository(db), regRepo, eventRepo,
+		repository.NewUserRepository(db), nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+
+	sender := createTestUser(t, db, "sender@example.com")
+	recipient := createTestUser(t, db, "recipient@example.com")
+	reg, err := regService.RegisterUser(sender.ID, event.ID, nil)
+	require.NoError(t, err)
+
+	_, err = transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: recipient.Email})
+	require.ErrorIs(t, err, domain.ErrTransfersDisabled)
+
+	enabled := true
+	_, err = eventService.UpdateEvent(organizerID, event.ID, &domain.UpdateEventRequest{AllowTransfers: &enabled})
+	require.NoError(t, err)
+
+	// declined: the seat stays with the sender
+	transfer, err := transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: recipient.Email})
+	require.NoError(t, err)
+	declined, err := transferService.DeclineTransfer(recipient.ID, transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.TransferStatusDeclined, declined.Status)
+
+	// cancelled by the sender
+	transfer, err = transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: recipient.Email})
+	require.NoError(t, err)
+	_, err = transferService.CancelTransfer(recipient.ID, transfer.ID)
+	require.ErrorContains(t, err, "transfer not found")
+	_, err = transferService.CancelTransfer(sender.ID, transfer.ID)
+	require.NoError(t, err)
+
+	// disabling transfers blocks invitations that are already out
+	transfer, err = transferService.CreateTransfer(sender.ID, reg.ID, &domain.CreateTransferRequest{Email: recipient.Email})
+	require.NoError(t, err)
+	disabled := false
+	_, err = eventService.UpdateEvent(organizerID, event.ID, &domain.UpdateEventRequest{AllowTransfers: &disabled})
+	require.NoError(t, err)
+	_, err = transferService.AcceptTransfer(recipient.ID, transfer.ID)
+	require.ErrorIs(t, err, domain.ErrTransfersDisabled)
+
+	still, err := regRepo.GetByUserAndEvent(sender.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, reg.ID, still.ID)
+	require.Equal(t, reg.TicketToken, still.TicketToken)
+
+	transfers, err := transferService.GetUserTransfers(recipient.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
+}
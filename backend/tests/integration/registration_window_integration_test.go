@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationWindow_EnforcedAndFiltered(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	start := time.Now().Add(48 * time.Hour)
+	create := func(title string, capacity int, opensAt, closesAt *time.Time) *domain.Event {
+		event, err := eventService.CreateEvent(organizerID, &domain.CreateEventRequest{
+			Title:                title,
+			Location:             "Hall",
+			StartDatetime:        start,
+			EndDatetime:          start.Add(2 * time.Hour),
+			Capacity:             capacity,
+			RegistrationOpensAt:  opensAt,
+			RegistrationClosesAt: closesAt,
+		})
+		require.NoError(t, err)
+		require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+		return event
+	}
+	later := time.Now().Add(time.Hour)
+	earlier := time.Now().Add(-time.Minute)
+
+	notOpen := create("Not open yet", 10, &later, nil)
+	open := create("Open", 10, nil, nil)
+	full := create("Full", 1, nil, nil)
+	closed := create("Closed", 10, nil, &earlier)
+
+	_, err := regService.RegisterUser(uuid.NewString(), notOpen.ID, nil)
+	require.ErrorIs(t, err, domain.ErrRegistrationNotOpen)
+
+	_, err = regService.RegisterUser(uuid.NewString(), closed.ID, nil)
+	require.ErrorIs(t, err, domain.ErrRegistrationClosed)
+
+	_, err = regService.RegisterUser(uuid.NewString(), full.ID, nil)
+	require.NoError(t, err)
+
+	got, err := eventService.GetEventByID(full.ID, "", "")
+	require.NoError(t, err)
+	require.Equal(t, domain.RegistrationStateFull, got.RegistrationState)
+
+	for state, want := range map[string]string{
+		domain.RegistrationStateNotOpen: notOpen.ID,
+		domain.RegistrationStateOpen:    open.ID,
+		domain.RegistrationStateFull:    full.ID,
+		domain.RegistrationStateClosed:  closed.ID,
+	} {
+		res, err := eventService.GetEvents(&domain.EventQueryRequest{RegistrationState: state})
+		require.NoError(t, err)
+		require.Len(t, res.Events, 1, state)
+		require.Equal(t, want, res.Events[0].ID, state)
+		require.Equal(t, state, res.Events[0].RegistrationState)
+	}
+
+	_, err = eventService.GetEvents(&domain.EventQueryRequest{RegistrationState: "soon"})
+	require.ErrorContains(t, err, "registration_state must be one of")
+}
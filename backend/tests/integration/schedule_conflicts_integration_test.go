@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleConflicts_WarnStrictModeAndConflictsList(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, repository.NewTicketTypeRepository(db),
+		repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+	userService := service.NewUserService(repository.NewUserRepository(db))
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	publish := func(title string, from, to time.Duration) *domain.Event {
+		event := insertEventDirectly(t, db, organizer.ID)
+		require.NoError(t, db.Model(event).Updates(map[string]interface{}{
+			"title": title, "status": domain.EventStatusPublished,
+			"start_datetime": start.Add(from), "end_datetime": start.Add(to),
+		}).Error)
+		return event
+	}
+	morning := publish("Morning Talk", 0, 2*time.Hour)
+	brunch := publish("Brunch", time.Hour, 3*time.Hour)
+	afternoon := publish("Afternoon Talk", 3*time.Hour, 4*time.Hour)
+	lunch := publish("Lunch", 90*time.Minute, 150*time.Minute)
+
+	reg, err := regService.RegisterUser(attendee.ID, morning.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, reg.Conflicts)
+
+	// overlapping registrations go through with a warning; back to back is no conflict
+	reg, err = regService.RegisterUser(attendee.ID, brunch.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, reg.Conflicts, 1)
+	require.Equal(t, morning.ID, reg.Conflicts[0].EventID)
+	reg, err = regService.RegisterUser(attendee.ID, afternoon.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, reg.Conflicts)
+
+	overlaps, err := regService.GetScheduleConflicts(attendee.ID)
+	require.NoError(t, err)
+	require.Len(t, overlaps, 1)
+	require.Equal(t, morning.ID, overlaps[0].First.EventID)
+	require.Equal(t, brunch.ID, overlaps[0].Second.EventID)
+
+	// strict mode blocks them
+	strict := true
+	user, err := userService.UpdateMe(attendee.ID, &domain.UpdateUserRequest{StrictSchedule: &strict})
+	require.NoError(t, err)
+	require.True(t, user.StrictSchedule)
+	_, err = regService.RegisterUser(attendee.ID, lunch.ID, nil)
+	require.ErrorIs(t, err, domain.ErrScheduleConflict)
+	require.ErrorContains(t, err, `"Morning Talk"`)
+
+	// cancelled registrations leave the schedule
+	_, err = regService.CancelRegistration(attendee.ID, morning.ID)
+	require.NoError(t, err)
+	_, err = regService.RegisterUser(attendee.ID, lunch.ID, nil)
+	require.ErrorContains(t, err, `"Brunch"`)
+	_, err = regService.CancelRegistration(attendee.ID, brunch.ID)
+	require.NoError(t, err)
+	reg, err = regService.RegisterUser(attendee.ID, lunch.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, reg.Conflicts)
+
+	overlaps, err = regService.GetScheduleConflicts(attendee.ID)
+	require.NoError(t, err)
+	require.Empty(t, overlaps)
+}
@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPublishing_PublishesDueDraftsOnce(t *testing.T) {
+	db := setupFileDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.Notification{}))
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), nil)
+	// two replicas running the scheduler
+	schedulerA := service.NewPublishScheduler(eventService, notificationService)
+	schedulerB := service.NewPublishScheduler(service.NewEventService(repository.NewEventRepository(db), nil), notificationService)
+
+	organizerID := uuid.NewString()
+	start := time.Now().Add(48 * time.Hour)
+	past := time.Now().Add(-time.Minute)
+	_, err := eventService.CreateEvent(organizerID, &domain.CreateEventRequest{
+		Title: "Launch Party", Location: "Roof", StartDatetime: start, EndDatetime: start.Add(time.Hour), Capacity: 10,
+		PublishAt: &past,
+	})
+	require.ErrorContains(t, err, "publish_at must be in the future")
+
+	soon := time.Now().Add(time.Hour)
+	event, err := eventService.CreateEvent(organizerID, &domain.CreateEventRequest{
+		Title: "Launch Party", Location: "Roof", StartDatetime: start, EndDatetime: start.Add(time.Hour), Capacity: 10,
+		PublishAt: &soon,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, event.PublishAt)
+
+	other := insertEventDirectly(t, db, organizerID)
+	_, err = eventService.SchedulePublish(uuid.NewString(), other.ID, soon)
+	require.ErrorContains(t, err, "only the event organizers")
+	_, err = eventService.SchedulePublish(organizerID, other.ID, soon)
+	require.NoError(t, err)
+	require.NoError(t, eventService.CancelScheduledPublish(organizerID, other.ID))
+	require.ErrorContains(t, eventService.CancelScheduledPublish(organizerID, other.ID), "not scheduled")
+
+	// nothing due yet
+	published, err := schedulerA.PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+
+	require.NoError(t, db.Table("events").Where("id IN ?", []string{event.ID, other.ID}).Update("publish_at", past).Error)
+	require.NoError(t, db.Table("events").Where("id = ?", other.ID).Update("status", domain.EventStatusPublished).Error)
+
+	published, err = schedulerA.PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+	published, err = schedulerB.PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+
+	got, err := eventService.GetEventByID(event.ID, "", "")
+	require.NoError(t, err)
+	require.Equal(t, domain.EventStatusPublished, got.Status)
+	require.Nil(t, got.PublishAt)
+
+	history, err := eventService.GetStatusHistory(organizerID, event.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	notifications, err := notificationService.GetUserNotifications(organizerID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Event published", notifications[0].Title)
+
+	// published events can't be scheduled
+	_, err = eventService.SchedulePublish(organizerID, event.ID, soon)
+	require.ErrorContains(t, err, "only draft events")
+}
+
+func TestScheduledPublishing_SurvivesReplicaCrash(t *testing.T) {
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	scheduler := service.NewPublishScheduler(eventService, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("publish_at", past).Error)
+
+	// a replica claims the event and dies before publishing it
+	claimed, err := eventService.ClaimDuePublications(5*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	// the schedule is kept, but others leave the event alone while the lease runs
+	published, err := scheduler.PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+	got, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PublishAt)
+	require.Equal(t, domain.EventStatusDraft, got.Status)
+
+	// once the lease has expired the event is published after all
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("publish_claimed_until", past).Error)
+	published, err = scheduler.PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+	got, err = eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventStatusPublished, got.Status)
+	require.Nil(t, got.PublishAt)
+	require.Nil(t, got.PublishClaimedUntil)
+}
+
+func TestScheduledPublishing_DropScheduledPublish(t *testing.T) {
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	soon := time.Now().Add(30 * time.Minute)
+	_, err := eventService.SchedulePublish(organizerID, event.ID, soon)
+	require.NoError(t, err)
+
+	scheduled, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.NoError(t, eventService.DropScheduledPublish(scheduled))
+
+	got, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.PublishAt)
+	require.Equal(t, scheduled.Version+1, got.Version)
+
+	history, err := eventService.GetHistory(organizerID, event.ID)
+	require.NoError(t, err)
+	last := history[len(history)-1]
+	require.Equal(t, domain.EventRevisionScheduled, last.Action)
+	require.Nil(t, last.ActorID)
+}
+
+func TestScheduledPublishing_SkipsDraftsRescheduledOrTakenOver(t *testing.T) {
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("publish_at", past).Error)
+
+	// the organizer moves the schedule after the scheduler claimed the event
+	claimed, err := eventService.ClaimDuePublications(5*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	_, err = eventService.SchedulePublish(organizerID, event.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	published, err := eventService.PublishScheduled(&claimed[0])
+	require.NoError(t, err)
+	require.False(t, published)
+	got, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventStatusDraft, got.Status)
+	require.NotNil(t, got.PublishAt)
+
+	// a replica whose lease expired and was taken over publishes nothing and leaves the new lease alone
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("publish_at", past).Error)
+	stale, err := eventService.ClaimDuePublications(5*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.NoError(t, db.Table("events").Where("id = ?", event.ID).Update("publish_claimed_until", past).Error)
+	current, err := eventService.ClaimDuePublications(5*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+
+	published, err = eventService.PublishScheduled(&stale[0])
+	require.NoError(t, err)
+	require.False(t, published)
+	require.NoError(t, eventService.ReleasePublication(&stale[0]))
+	got, err = eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PublishClaimedUntil)
+
+	published, err = eventService.PublishScheduled(&current[0])
+	require.NoError(t, err)
+	require.True(t, published)
+}
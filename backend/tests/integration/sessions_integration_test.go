@@ -0,0 +1,176 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions_AgendaRoomConflictsAndSeats(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	sessionService := service.NewSessionService(repository.NewSessionRepository(db), eventRepo, eventService)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+	latecomer := createTestUser(t, db, "latecomer@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	event, err := eventService.CreateEvent(organizer.ID, &domain.CreateEventRequest{
+		Title:         "GopherCon",
+		Location:      "Convention Center",
+		StartDatetime: start,
+		EndDatetime:   start.Add(8 * time.Hour),
+		Capacity:      100,
+	})
+	require.NoError(t, err)
+
+	alice, err := sessionService.AddSpeaker(organizer.ID, event.ID, &domain.CreateSpeakerRequest{Name: "Alice", Bio: "Go team"})
+	require.NoError(t, err)
+	bob, err := sessionService.AddSpeaker(organizer.ID, event.ID, &domain.CreateSpeakerRequest{Name: "Bob"})
+	require.NoError(t, err)
+	_, err = sessionService.AddSpeaker(outsider.ID, event.ID, &domain.CreateSpeakerRequest{Name: "Mallory"})
+	require.ErrorContains(t, err, "only the event organizers can manage the agenda")
+
+	one := 1
+	keynote, err := sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Keynote", Room: "Main Hall", StartDatetime: start, EndDatetime: start.Add(time.Hour), SpeakerIDs: []string{alice.ID, bob.ID},
+	})
+	require.NoError(t, err)
+	workshop, err := sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Workshop", Room: "Room B", StartDatetime: start, EndDatetime: start.Add(2 * time.Hour), Capacity: &one, SpeakerIDs: []string{bob.ID},
+	})
+	require.NoError(t, err)
+
+	// the same room can't host two sessions at once; back to back is fine
+	_, err = sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Panel", Room: "main hall", StartDatetime: start.Add(30 * time.Minute), EndDatetime: start.Add(90 * time.Minute),
+	})
+	require.ErrorIs(t, err, domain.ErrSessionRoomConflict)
+	require.ErrorContains(t, err, `"Keynote"`)
+	panel, err := sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Panel", Room: "Main Hall", StartDatetime: start.Add(time.Hour), EndDatetime: start.Add(2 * time.Hour),
+	})
+	require.NoError(t, err)
+	earlier := start.Add(30 * time.Minute)
+	_, err = sessionService.UpdateSession(organizer.ID, event.ID, panel.ID, &domain.UpdateSessionRequest{StartDatetime: &earlier})
+	require.ErrorIs(t, err, domain.ErrSessionRoomConflict)
+
+	_, err = sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "After party", StartDatetime: start.Add(7 * time.Hour), EndDatetime: start.Add(9 * time.Hour),
+	})
+	require.ErrorContains(t, err, "within the event")
+	_, err = sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Mystery", StartDatetime: start, EndDatetime: start.Add(time.Hour), SpeakerIDs: []string{"unknown"},
+	})
+	require.ErrorContains(t, err, "speaker not found")
+
+	require.NoError(t, eventService.PublishEvent(organizer.ID, event.ID))
+
+	agenda, err := sessionService.GetAgenda(event.ID, "", "")
+	require.NoError(t, err)
+	require.Len(t, agenda.Sessions, 3)
+	require.Equal(t, keynote.ID, agenda.Sessions[0].ID)
+	require.Len(t, agenda.Sessions[0].Speakers, 2)
+	require.Equal(t, "Alice", agenda.Sessions[0].Speakers[0].Name)
+	require.Len(t, agenda.Speakers, 2)
+
+	// only attendees of the event book seats, and only in sessions with a capacity
+	_, err = sessionService.RegisterForSession(attendee.ID, event.ID, workshop.ID)
+	require.ErrorContains(t, err, "only attendees of the event")
+	for _, user := range []*domain.User{attendee, latecomer} {
+		require.NoError(t, db.Create(&domain.Registration{UserID: user.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken()}).Error)
+	}
+	_, err = sessionService.RegisterForSession(attendee.ID, event.ID, keynote.ID)
+	require.ErrorContains(t, err, "does not take registrations")
+	_, err = sessionService.RegisterForSession(attendee.ID, event.ID, workshop.ID)
+	require.NoError(t, err)
+	_, err = sessionService.RegisterForSession(attendee.ID, event.ID, workshop.ID)
+	require.ErrorContains(t, err, "already registered")
+	_, err = sessionService.RegisterForSession(latecomer.ID, event.ID, workshop.ID)
+	require.ErrorIs(t, err, domain.ErrSessionFull)
+
+	agenda, err = sessionService.GetAgenda(event.ID, "", "")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), agenda.Sessions[1].Registered)
+
+	// capacity can't drop below the seats taken; freeing the seat lets the latecomer in
+	two := 2
+	_, err = sessionService.UpdateSession(organizer.ID, event.ID, workshop.ID, &domain.UpdateSessionRequest{Capacity: &two})
+	require.NoError(t, err)
+	_, err = sessionService.RegisterForSession(latecomer.ID, event.ID, workshop.ID)
+	require.NoError(t, err)
+	_, err = sessionService.UpdateSession(organizer.ID, event.ID, workshop.ID, &domain.UpdateSessionRequest{Capacity: &one})
+	require.ErrorContains(t, err, "2 seats already taken")
+	require.NoError(t, sessionService.CancelSessionRegistration(attendee.ID, event.ID, workshop.ID))
+	require.ErrorContains(t, sessionService.CancelSessionRegistration(attendee.ID, event.ID, workshop.ID), "not registered")
+
+	// removing a speaker takes them off their sessions
+	require.NoError(t, sessionService.RemoveSpeaker(organizer.ID, event.ID, bob.ID))
+	agenda, err = sessionService.GetAgenda(event.ID, "", "")
+	require.NoError(t, err)
+	require.Len(t, agenda.Sessions[0].Speakers, 1)
+	require.Empty(t, agenda.Sessions[1].Speakers)
+
+	// clones carry the agenda along, without the seats
+	clone, err := eventService.CloneEvent(organizer.ID, event.ID, &domain.CloneEventRequest{StartDatetime: start.AddDate(0, 1, 0)})
+	require.NoError(t, err)
+	cloned, err := sessionService.GetAgenda(clone.ID, organizer.ID, "")
+	require.NoError(t, err)
+	require.Len(t, cloned.Sessions, 3)
+	require.True(t, cloned.Sessions[0].StartDatetime.Equal(clone.StartDatetime))
+	require.Len(t, cloned.Sessions[0].Speakers, 1)
+	require.NotEqual(t, alice.ID, cloned.Sessions[0].Speakers[0].ID)
+	require.Zero(t, cloned.Sessions[1].Registered)
+
+	require.NoError(t, sessionService.RemoveSession(organizer.ID, event.ID, workshop.ID))
+	agenda, err = sessionService.GetAgenda(event.ID, "", "")
+	require.NoError(t, err)
+	require.Len(t, agenda.Sessions, 2)
+}
+
+func TestSessions_EventDatesKeepTheAgendaInside(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	sessionService := service.NewSessionService(repository.NewSessionRepository(db), eventRepo, eventService)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	event, err := eventService.CreateEvent(organizer.ID, &domain.CreateEventRequest{
+		Title: "GopherCon", Location: "Convention Center", StartDatetime: start, EndDatetime: start.Add(8 * time.Hour), Capacity: 100,
+	})
+	require.NoError(t, err)
+	_, err = sessionService.AddSession(organizer.ID, event.ID, &domain.CreateSessionRequest{
+		Title: "Closing", StartDatetime: start.Add(6 * time.Hour), EndDatetime: start.Add(7 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// shortening the event past the last session is rejected
+	shorter := start.Add(4 * time.Hour)
+	_, err = eventService.UpdateEvent(organizer.ID, event.ID, &domain.UpdateEventRequest{EndDatetime: &shorter})
+	require.ErrorIs(t, err, domain.ErrSessionsOutsideEvent)
+	require.ErrorContains(t, err, `"Closing"`)
+	got, err := eventRepo.GetByID(event.ID)
+	require.NoError(t, err)
+	require.True(t, got.EndDatetime.Equal(start.Add(8*time.Hour)))
+
+	// so is moving the event away from its sessions
+	later, laterEnd := start.Add(24*time.Hour), start.Add(32*time.Hour)
+	_, err = eventService.UpdateEvent(organizer.ID, event.ID, &domain.UpdateEventRequest{StartDatetime: &later, EndDatetime: &laterEnd})
+	require.ErrorIs(t, err, domain.ErrSessionsOutsideEvent)
+
+	// dates that still hold the agenda are fine
+	tighter := start.Add(7 * time.Hour)
+	updated, err := eventService.UpdateEvent(organizer.ID, event.ID, &domain.UpdateEventRequest{EndDatetime: &tighter})
+	require.NoError(t, err)
+	require.True(t, updated.EndDatetime.Equal(tighter))
+}
@@ -0,0 +1,145 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats_EventAndOrganizerDashboards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	eventRepo := repository.NewEventRepository(db)
+	r := gin.New()
+	handler.NewStatsHandler(r, service.NewStatsService(repository.NewStatsRepository(db), eventRepo, nil), middleware.Auth("test-secret"))
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+
+	// An event that took place last week, and one coming up
+	day := func(offset int) time.Time {
+		return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, offset).Add(12 * time.Hour)
+	}
+	past := insertEventDirectly(t, db, organizer.ID)
+	require.NoError(t, db.Model(past).Updates(map[string]interface{}{
+		"start_datetime": day(-7), "end_datetime": day(-7).Add(2 * time.Hour), "capacity": 4,
+	}).Error)
+	upcoming := insertEventDirectly(t, db, organizer.ID)
+
+	register := func(event *domain.Event, status string, createdAt time.Time, cancelledAt *time.Time) {
+		user := createTestUser(t, db, uuid.NewString()+"@example.com")
+		require.NoError(t, db.Create(&domain.Registration{
+			ID: uuid.NewString(), UserID: user.ID, EventID: event.ID, Status: status, TicketToken: domain.NewTicketToken(),
+			CreatedAt: createdAt, CancelledAt: cancelledAt,
+		}).Error)
+	}
+	cancelledAt := day(-9)
+	register(past, domain.RegistrationStatusCheckedIn, day(-10), nil)
+	register(past, domain.RegistrationStatusConfirmed, day(-10), nil)
+	register(past, domain.RegistrationStatusConfirmed, day(-9), nil)
+	register(past, domain.RegistrationStatusCancelled, day(-10), &cancelledAt)
+	register(past, domain.RegistrationStatusPending, day(-8), nil)
+	register(past, domain.RegistrationStatusRejected, day(-8), nil)
+	register(upcoming, domain.RegistrationStatusConfirmed, day(0), nil)
+
+	get := func(user *domain.User, path string) *httptest.ResponseRecorder {
+		token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder, dest interface{}) {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.NoError(t, json.Unmarshal(body.Data, dest))
+	}
+	date := func(offset int) string { return day(offset).Format("2006-01-02") }
+
+	var stats domain.EventStats
+	decode(get(organizer, "/events/"+past.ID+"/stats"), &stats)
+	require.Equal(t, past.ID, stats.EventID)
+	require.Equal(t, domain.RegistrationStats{
+		Capacity: 4, Registrations: 4, SeatsTaken: 3, Cancellations: 1, Confirmed: 2, CheckedIn: 1,
+		CheckInRate: 33.3, NoShows: 2, FillPercentage: 75, PendingApprovals: 1,
+	}, stats.RegistrationStats)
+	require.Equal(t, []domain.DailyRegistrations{
+		{Date: date(-10), Registrations: 3},
+		{Date: date(-9), Registrations: 1, Cancellations: 1},
+	}, stats.Daily)
+
+	// Confirmed seats of an event that hasn't ended are not no-shows
+	decode(get(organizer, "/events/"+upcoming.ID+"/stats"), &stats)
+	require.Equal(t, int64(1), stats.Confirmed)
+	require.Zero(t, stats.NoShows)
+	require.Equal(t, 10.0, stats.FillPercentage)
+
+	w := get(outsider, "/events/"+past.ID+"/stats")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "view event stats")
+
+	// All events of the organizer
+	var totals domain.OrganizerStats
+	decode(get(organizer, "/organizers/me/stats"), &totals)
+	require.Equal(t, int64(2), totals.Events)
+	require.Equal(t, int64(14), totals.Capacity)
+	require.Equal(t, int64(5), totals.Registrations)
+	require.Equal(t, int64(4), totals.SeatsTaken)
+	require.Equal(t, int64(2), totals.NoShows)
+	require.Equal(t, 25.0, totals.CheckInRate)
+	require.Len(t, totals.Daily, 3)
+	require.Equal(t, domain.DailyRegistrations{Date: date(0), Registrations: 1}, totals.Daily[2])
+
+	// Only the events starting within the days, both included
+	decode(get(organizer, "/organizers/me/stats?from="+date(-7)+"&to="+date(-7)), &totals)
+	require.Equal(t, int64(1), totals.Events)
+	require.Equal(t, int64(4), totals.Registrations)
+	require.NotNil(t, totals.From)
+	decode(get(organizer, "/organizers/me/stats?from="+date(-6)), &totals)
+	require.Equal(t, int64(1), totals.Events)
+	require.Equal(t, int64(1), totals.Registrations)
+
+	// Someone without events gets empty stats
+	decode(get(outsider, "/organizers/me/stats"), &totals)
+	require.Zero(t, totals.Events)
+	require.Empty(t, totals.Daily)
+
+	require.Equal(t, http.StatusBadRequest, get(organizer, "/organizers/me/stats?from=last-week").Code)
+	w = get(organizer, "/organizers/me/stats?from="+date(0)+"&to="+date(-1))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "to must not be before from")
+
+	// events run with an event team or an organization count as well
+	teammate := createTestUser(t, db, "teammate@example.com")
+	require.NoError(t, db.Create(&domain.EventMember{ID: uuid.NewString(), EventID: upcoming.ID, UserID: teammate.ID, Role: domain.EventRoleCoOrganizer, AddedBy: organizer.ID}).Error)
+	decode(get(teammate, "/organizers/me/stats"), &totals)
+	require.Equal(t, int64(1), totals.Events)
+	require.Equal(t, int64(1), totals.Registrations)
+
+	orgMember := createTestUser(t, db, "orgmember@example.com")
+	org := &domain.Organization{ID: uuid.NewString(), Name: "Acme", Slug: "acme", CreatedBy: &organizer.ID}
+	require.NoError(t, db.Create(org).Error)
+	require.NoError(t, db.Create(&domain.OrganizationMember{ID: uuid.NewString(), OrganizationID: org.ID, UserID: orgMember.ID, Role: domain.OrganizationRoleMember}).Error)
+	require.NoError(t, db.Model(past).Update("organization_id", org.ID).Error)
+	decode(get(orgMember, "/organizers/me/stats"), &totals)
+	require.Equal(t, int64(1), totals.Events)
+	require.Equal(t, int64(4), totals.Registrations)
+}
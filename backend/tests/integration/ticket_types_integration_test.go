@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupFileDB creates a per-test sqlite file DB with the registration schema,
+// so registration tests don't share tables with the in-memory DB of other tests
+func setupFileDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		sqlite.Open(filepath.Join(t.TempDir(), "test.db")),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)},
+	)
+	require.NoError(t, err)
+	setupTables(t, db)
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestTicketTypes_PerTierStockAndEventCapacity(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo, repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+
+	// event capacity is 10: VIP has 1 seat, General has 20 (capped by the event)
+	vip, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "VIP", PriceCents: 0, Quantity: 1,
+	})
+	require.NoError(t, err)
+	general, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "General", Quantity: 20,
+	})
+	require.NoError(t, err)
+
+	_, err = ticketService.CreateTicketType(uuid.NewString(), event.ID, &domain.CreateTicketTypeRequest{Name: "Hack", Quantity: 1})
+	require.Error(t, err, "only the organizer can add ticket types")
+
+	// with several tiers the ticket type must be chosen
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, nil)
+	require.ErrorContains(t, err, "ticket_type_id is required")
+
+	reg, err := regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &vip.ID})
+	require.NoError(t, err)
+	require.Equal(t, vip.ID, *reg.TicketTypeID)
+
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &vip.ID})
+	require.ErrorContains(t, err, "sold out")
+
+	for i := 0; i < 9; i++ {
+		_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID})
+		require.NoError(t, err)
+	}
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, &domain.RegisterRequest{TicketTypeID: &general.ID})
+	require.ErrorContains(t, err, "event is full")
+
+	ticketTypes, err := ticketService.GetTicketTypes(event.ID)
+	require.NoError(t, err)
+	require.Len(t, ticketTypes, 2)
+	require.Equal(t, "General", ticketTypes[0].Name)
+	require.Equal(t, int64(9), ticketTypes[0].Sold)
+	require.Equal(t, int64(1), ticketTypes[1].Sold)
+
+	tooLow := 5
+	_, err = ticketService.UpdateTicketType(organizerID, event.ID, general.ID, &domain.UpdateTicketTypeRequest{Quantity: &tooLow})
+	require.ErrorContains(t, err, "already sold")
+
+	// the check counts the seats sold when saving, not when the tier was read
+	require.Zero(t, general.Sold)
+	general.Quantity = tooLow
+	require.ErrorContains(t, ticketRepo.Update(general), "the 9 tickets already sold")
+	general.Quantity = 9
+	require.NoError(t, ticketRepo.Update(general))
+	require.Equal(t, int64(9), general.Sold)
+}
+
+func TestTicketTypes_SalesWindow(t *testing.T) {
+	db := setupFileDB(t)
+
+	eventRepo := repository.NewEventRepository(db)
+	eventService := service.NewEventService(eventRepo, nil)
+	ticketRepo := repository.NewTicketTypeRepository(db)
+	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	regService := service.NewRegistrationService(repository.NewRegistrationRepository(db), eventRepo, ticketRepo, repository.NewCancellationPolicyRepository(db), repository.NewRegistrationFormRepository(db), nil, nil, nil)
+
+	organizerID := uuid.NewString()
+	event := insertEventDirectly(t, db, organizerID)
+	require.NoError(t, eventService.PublishEvent(organizerID, event.ID))
+
+	opens := time.Now().Add(time.Hour)
+	_, err := ticketService.CreateTicketType(organizerID, event.ID, &domain.CreateTicketTypeRequest{
+		Name: "Early Bird", Quantity: 5, SalesStart: &opens,
+	})
+	require.NoError(t, err)
+
+	// single tier is selected implicitly
+	_, err = regService.RegisterUser(uuid.NewString(), event.ID, nil)
+	require.ErrorContains(t, err, "have not started")
+}
@@ -0,0 +1,291 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+	"github.com/Fixsbreaker/event-hub/backend/internal/handler"
+	"github.com/Fixsbreaker/event-hub/backend/internal/middleware"
+	"github.com/Fixsbreaker/event-hub/backend/internal/repository"
+	"github.com/Fixsbreaker/event-hub/backend/internal/service"
+	"github.com/Fixsbreaker/event-hub/backend/internal/storage"
+	"github.com/Fixsbreaker/event-hub/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTrash_ListAndRestoreEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	eventService := service.NewEventService(repository.NewEventRepository(db), nil)
+	r := gin.New()
+	handler.NewEventHandler(r, eventService, middleware.Auth("test-secret"), middleware.OptionalAuth("test-secret"))
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	outsider := createTestUser(t, db, "outsider@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+	event := insertEventDirectly(t, db, organizer.ID)
+	kept := insertEventDirectly(t, db, organizer.ID)
+	require.NoError(t, db.Create(&domain.Registration{UserID: attendee.ID, EventID: event.ID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken()}).Error)
+
+	require.NoError(t, eventService.DeleteEvent(organizer.ID, event.ID))
+	_, err := eventService.GetEventByID(event.ID, organizer.ID, "")
+	require.Error(t, err)
+
+	// the trash route is not taken for an event ID
+	get := func(user *domain.User, path string) *httptest.ResponseRecorder {
+		token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	w := get(organizer, "/events/trash")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.TrashedEvent `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, event.ID, resp.Data[0].ID)
+	require.WithinDuration(t, time.Now(), resp.Data[0].DeletedAt, time.Minute)
+	require.Equal(t, http.StatusOK, get(organizer, "/events/"+kept.ID).Code)
+
+	w = get(outsider, "/events/trash")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Empty(t, resp.Data)
+
+	_, err = eventService.RestoreEvent(outsider.ID, event.ID)
+	require.ErrorContains(t, err, "not found in the trash")
+	_, err = eventService.RestoreEvent(organizer.ID, kept.ID)
+	require.ErrorContains(t, err, "not found in the trash")
+
+	restored, err := eventService.RestoreEvent(organizer.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, event.ID, restored.ID)
+	_, err = eventService.GetEventByID(event.ID, organizer.ID, "")
+	require.NoError(t, err)
+
+	var registrations int64
+	require.NoError(t, db.Model(&domain.Registration{}).Where("event_id = ?", event.ID).Count(&registrations).Error)
+	require.Equal(t, int64(1), registrations)
+
+	history, err := eventService.GetHistory(organizer.ID, event.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventRevisionRestored, history[len(history)-1].Action)
+	require.Equal(t, restored.Version, history[len(history)-1].Version)
+
+	trash, err := eventService.GetTrash(organizer.ID)
+	require.NoError(t, err)
+	require.Empty(t, trash)
+}
+
+func TestTrash_ListsEventsTheUserMayRestore(t *testing.T) {
+	db := setupFileDB(t)
+	eventService := service.NewEventService(repository.NewEventRepository(db), nil)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	coOwner := createTestUser(t, db, "coowner@example.com")
+	coOrganizer := createTestUser(t, db, "coorganizer@example.com")
+	orgAdmin := createTestUser(t, db, "orgadmin@example.com")
+	orgMember := createTestUser(t, db, "orgmember@example.com")
+
+	org := &domain.Organization{ID: uuid.NewString(), Name: "Acme", Slug: "acme", CreatedBy: &orgAdmin.ID}
+	require.NoError(t, db.Create(org).Error)
+	for user, role := range map[*domain.User]string{orgAdmin: domain.OrganizationRoleAdmin, orgMember: domain.OrganizationRoleMember} {
+		require.NoError(t, db.Create(&domain.OrganizationMember{ID: uuid.NewString(), OrganizationID: org.ID, UserID: user.ID, Role: role}).Error)
+	}
+
+	teamEvent := insertEventDirectly(t, db, organizer.ID)
+	for user, role := range map[*domain.User]string{coOwner: domain.EventRoleOwner, coOrganizer: domain.EventRoleCoOrganizer} {
+		require.NoError(t, db.Create(&domain.EventMember{ID: uuid.NewString(), EventID: teamEvent.ID, UserID: user.ID, Role: role, AddedBy: organizer.ID}).Error)
+	}
+	orgEvent := insertEventDirectly(t, db, organizer.ID)
+	require.NoError(t, db.Model(orgEvent).Update("organization_id", org.ID).Error)
+	require.NoError(t, eventService.DeleteEvent(organizer.ID, teamEvent.ID))
+	require.NoError(t, eventService.DeleteEvent(organizer.ID, orgEvent.ID))
+
+	listed := func(user *domain.User) []string {
+		trash, err := eventService.GetTrash(user.ID)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, event := range trash {
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+	require.ElementsMatch(t, []string{teamEvent.ID, orgEvent.ID}, listed(organizer))
+	require.Equal(t, []string{teamEvent.ID}, listed(coOwner))
+	require.Equal(t, []string{orgEvent.ID}, listed(orgAdmin))
+	require.Empty(t, listed(coOrganizer))
+	require.Empty(t, listed(orgMember))
+
+	// whoever sees an event in the trash may restore it, and nobody else
+	_, err := eventService.RestoreEvent(coOrganizer.ID, teamEvent.ID)
+	require.ErrorContains(t, err, "not found in the trash")
+	_, err = eventService.RestoreEvent(coOwner.ID, teamEvent.ID)
+	require.NoError(t, err)
+	_, err = eventService.RestoreEvent(orgAdmin.ID, orgEvent.ID)
+	require.NoError(t, err)
+}
+
+func TestTrash_AdminRestoresUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupFileDB(t)
+	userRepo := repository.NewUserRepository(db)
+	r := gin.New()
+	handler.NewUserHandler(r, service.NewUserService(userRepo), middleware.Auth("test-secret"))
+
+	admin := createTestUser(t, db, "admin@example.com")
+	require.NoError(t, userRepo.UpdateRole(admin.ID, "admin"))
+	admin.Role = "admin"
+	member := createTestUser(t, db, "member@example.com")
+	deleted := createTestUser(t, db, "deleted@example.com")
+	require.NoError(t, userRepo.Delete(deleted.ID))
+
+	call := func(user *domain.User, method, path string) *httptest.ResponseRecorder {
+		token, err := jwt.GenerateToken(user.ID, user.Email, user.Role, "test-secret", time.Hour)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusForbidden, call(member, http.MethodGet, "/users/trash").Code)
+	require.Equal(t, http.StatusForbidden, call(member, http.MethodPost, "/users/"+deleted.ID+"/restore").Code)
+
+	w := call(admin, http.MethodGet, "/users/trash")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.TrashedUser `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, deleted.ID, resp.Data[0].ID)
+	require.NotContains(t, w.Body.String(), "password")
+
+	require.Equal(t, http.StatusOK, call(admin, http.MethodPost, "/users/"+deleted.ID+"/restore").Code)
+	require.Equal(t, http.StatusNotFound, call(admin, http.MethodPost, "/users/"+deleted.ID+"/restore").Code)
+	_, err := userRepo.GetByID(deleted.ID)
+	require.NoError(t, err)
+}
+
+func TestTrash_PurgeAfterRetention(t *testing.T) {
+	db := setupFileDB(t)
+	store, err := storage.NewLocalStore(t.TempDir(), "http://files.test", "storage-secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+	purger := service.NewTrashPurger(repository.NewTrashRepository(db), store, 30*24*time.Hour)
+	userRepo := repository.NewUserRepository(db)
+
+	organizer := createTestUser(t, db, "organizer@example.com")
+	attendee := createTestUser(t, db, "attendee@example.com")
+	register := func(userID, eventID string) {
+		require.NoError(t, db.Create(&domain.Registration{UserID: userID, EventID: eventID, Status: domain.RegistrationStatusConfirmed, TicketToken: domain.NewTicketToken()}).Error)
+	}
+	expire := func(table, id string) {
+		require.NoError(t, db.Table(table).Where("id = ?", id).Update("deleted_at", time.Now().Add(-31*24*time.Hour)).Error)
+	}
+
+	expired := insertEventDirectly(t, db, organizer.ID)
+	recent := insertEventDirectly(t, db, organizer.ID)
+	register(attendee.ID, expired.ID)
+	register(attendee.ID, recent.ID)
+	require.NoError(t, store.Put(ctx, "events/"+expired.ID+"/cover.png", strings.NewReader("png"), 3, "image/png"))
+	require.NoError(t, db.Create(&domain.EventFile{
+		ID: uuid.NewString(), EventID: expired.ID, Kind: domain.EventFileKindCover, Name: "cover.png", ContentType: "image/png",
+		Size: 3, StorageKey: "events/" + expired.ID + "/cover.png", UploadedBy: &organizer.ID,
+	}).Error)
+	require.NoError(t, db.Delete(&domain.Event{}, "id = ?", expired.ID).Error)
+	require.NoError(t, db.Delete(&domain.Event{}, "id = ?", recent.ID).Error)
+	expire("events", expired.ID)
+
+	// a deleted account goes with the events it organizes, even live ones, and all their registrations
+	gone := createTestUser(t, db, "gone@example.com")
+	goneEvent := insertEventDirectly(t, db, gone.ID)
+	register(gone.ID, recent.ID)
+	register(attendee.ID, goneEvent.ID)
+	require.NoError(t, userRepo.Delete(gone.ID))
+	expire("users", gone.ID)
+
+	purged, err := purger.PurgeExpired(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged.Events)
+	require.Equal(t, int64(1), purged.Users)
+	require.Equal(t, int64(3), purged.Registrations)
+
+	count := func(query *gorm.DB) int64 {
+		var n int64
+		require.NoError(t, query.Count(&n).Error)
+		return n
+	}
+	require.Zero(t, count(db.Unscoped().Model(&domain.Event{}).Where("id IN ?", []string{expired.ID, goneEvent.ID})))
+	require.Equal(t, int64(1), count(db.Unscoped().Model(&domain.Event{}).Where("id = ?", recent.ID)))
+	require.Equal(t, int64(1), count(db.Model(&domain.Registration{}).Where("event_id = ?", recent.ID)))
+	require.Zero(t, count(db.Unscoped().Model(&domain.User{}).Where("id = ?", gone.ID)))
+	_, err = store.Get(ctx, "events/"+expired.ID+"/cover.png")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// nothing left to purge
+	purged, err = purger.PurgeExpired(ctx)
+	require.NoError(t, err)
+	require.Zero(t, purged.Events+purged.Users+purged.Registrations)
+}
+
+func TestTrash_PurgedUserHandsOverOrganizationEvents(t *testing.T) {
+	db := setupFileDB(t)
+	trashRepo := repository.NewTrashRepository(db)
+	userRepo := repository.NewUserRepository(db)
+
+	gone := createTestUser(t, db, "gone@example.com")
+	admin := createTestUser(t, db, "admin@example.com")
+	org := &domain.Organization{ID: uuid.NewString(), Name: "Acme", Slug: "acme", CreatedBy: &gone.ID}
+	require.NoError(t, db.Create(org).Error)
+	for _, user := range []*domain.User{gone, admin} {
+		require.NoError(t, db.Create(&domain.OrganizationMember{ID: uuid.NewString(), OrganizationID: org.ID, UserID: user.ID, Role: domain.OrganizationRoleAdmin}).Error)
+	}
+
+	orgEvent := insertEventDirectly(t, db, gone.ID)
+	require.NoError(t, db.Model(orgEvent).Update("organization_id", org.ID).Error)
+	file := &domain.EventFile{
+		ID: uuid.NewString(), EventID: orgEvent.ID, Kind: domain.EventFileKindCover, Name: "cover.png", ContentType: "image/png",
+		Size: 3, StorageKey: "events/" + orgEvent.ID + "/cover.png", UploadedBy: &gone.ID,
+	}
+	require.NoError(t, db.Create(file).Error)
+	ownEvent := insertEventDirectly(t, db, gone.ID)
+	require.NoError(t, userRepo.Delete(gone.ID))
+
+	purged, err := trashRepo.PurgeUser(gone.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged.Users)
+	require.Equal(t, int64(1), purged.Events, "only the event outside the organization")
+	require.Empty(t, purged.FileKeys)
+
+	var event domain.Event
+	require.NoError(t, db.Where("id = ?", orgEvent.ID).First(&event).Error)
+	require.Equal(t, admin.ID, event.OrganizerID)
+	require.Equal(t, orgEvent.Version+1, event.Version)
+	require.NoError(t, db.Where("id = ?", file.ID).First(&domain.EventFile{}).Error)
+	require.NoError(t, db.Where("id = ?", org.ID).First(&domain.Organization{}).Error)
+	require.ErrorIs(t, db.Unscoped().Where("id = ?", ownEvent.ID).First(&domain.Event{}).Error, gorm.ErrRecordNotFound)
+
+	// the last admin of an organization is kept until someone can take its events over
+	require.NoError(t, userRepo.Delete(admin.ID))
+	_, err = trashRepo.PurgeUser(admin.ID)
+	require.ErrorContains(t, err, "no other admin")
+	require.NoError(t, db.Unscoped().Where("id = ?", admin.ID).First(&domain.User{}).Error)
+	require.NoError(t, db.Where("id = ?", orgEvent.ID).First(&domain.Event{}).Error)
+}
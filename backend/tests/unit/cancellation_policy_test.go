@@ -0,0 +1,73 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestCancellationPolicy_Evaluate(t *testing.T) {
+	now := time.Now()
+	policy := &domain.CancellationPolicy{
+		AllowCancellation: true,
+		CutoffHours:       24,
+		RefundTiers: []domain.RefundTier{
+			{HoursBefore: 48, RefundPercent: 50},
+			{HoursBefore: 168, RefundPercent: 100},
+		},
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		untilStart  time.Duration
+		wantPercent int
+		wantErr     error
+	}{
+		{"two weeks before", 14 * 24 * time.Hour, 100, nil},
+		{"three days before", 72 * time.Hour, 50, nil},
+		{"between cutoff and last tier", 30 * time.Hour, 0, nil},
+		{"within cutoff", 12 * time.Hour, 0, domain.ErrCancellationCutoffPassed},
+		{"after start", -time.Hour, 0, domain.ErrEventAlreadyStarted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := policy.Evaluate(now.Add(tt.untilStart), now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Evaluate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate() unexpected error = %v", err)
+			}
+			if quote.RefundPercent != tt.wantPercent {
+				t.Errorf("RefundPercent = %d, want %d", quote.RefundPercent, tt.wantPercent)
+			}
+		})
+	}
+
+	policy.AllowCancellation = false
+	if _, err := policy.Evaluate(now.Add(30*24*time.Hour), now); !errors.Is(err, domain.ErrCancellationNotAllowed) {
+		t.Errorf("Evaluate() error = %v, want %v", err, domain.ErrCancellationNotAllowed)
+	}
+}
+
+func TestCancellationPolicy_Validate(t *testing.T) {
+	invalid := []*domain.CancellationPolicy{
+		{AllowCancellation: true, CutoffHours: -1},
+		{AllowCancellation: true, RefundTiers: []domain.RefundTier{{HoursBefore: 24, RefundPercent: 120}}},
+		{AllowCancellation: true, RefundTiers: []domain.RefundTier{{HoursBefore: 24, RefundPercent: 50}, {HoursBefore: 24, RefundPercent: 20}}},
+	}
+	for i, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("policy %d: expected validation error", i)
+		}
+	}
+}
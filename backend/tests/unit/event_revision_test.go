@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestDiffEvents(t *testing.T) {
+	start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	before := &domain.Event{
+		Title:         "Meetup",
+		StartDatetime: start,
+		EndDatetime:   start.Add(2 * time.Hour),
+		Capacity:      50,
+		Tags:          []domain.Tag{{Name: "go"}},
+		Visibility:    domain.EventVisibilityPublic,
+		Status:        domain.EventStatusDraft,
+		AccessCode:    "first-code",
+	}
+
+	created := domain.DiffEvents(nil, before)
+	fields := make([]string, len(created))
+	for i, change := range created {
+		fields[i] = change.Field
+	}
+	want := []string{"title", "start_datetime", "end_datetime", "capacity", "tags", "visibility", "access_code", "status"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("expected creation to list %v, got %v", want, fields)
+	}
+
+	after := *before
+	after.StartDatetime = start.Add(time.Hour).In(time.FixedZone("UTC+2", 2*3600))
+	after.Capacity = 80
+	after.Tags = []domain.Tag{{Name: "meetup"}, {Name: "go"}}
+	after.AccessCode = "second-code"
+
+	changes := domain.DiffEvents(before, &after)
+	wantChanges := []domain.FieldChange{
+		{Field: "start_datetime", Old: "2030-05-01T09:00:00Z", New: "2030-05-01T10:00:00Z"},
+		{Field: "capacity", Old: float64(50), New: float64(80)},
+		{Field: "tags", Old: []any{"go"}, New: []any{"go", "meetup"}},
+		{Field: "access_code", Old: "********", New: "********"},
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Fatalf("expected %+v, got %+v", wantChanges, changes)
+	}
+
+	// the same state in another time zone or with reordered tags is no change
+	same := *before
+	same.StartDatetime = start.In(time.FixedZone("UTC-5", -5*3600))
+	if changes := domain.DiffEvents(before, &same); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestRestoreFields_RoundTripsThroughJSON(t *testing.T) {
+	start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	opens := start.Add(-48 * time.Hour)
+	category := "c0ffee00-0000-0000-0000-000000000000"
+	original := &domain.Event{
+		Title:               "Meetup",
+		Description:         "Monthly",
+		Location:            "Hall A",
+		StartDatetime:       start,
+		EndDatetime:         start.Add(2 * time.Hour),
+		Capacity:            50,
+		CategoryID:          &category,
+		Tags:                []domain.Tag{{Name: "go"}},
+		AllowTransfers:      true,
+		RegistrationOpensAt: &opens,
+		Visibility:          domain.EventVisibilityUnlisted,
+		Status:              domain.EventStatusDraft,
+	}
+
+	// values are read back from the database as JSON
+	data, err := json.Marshal(domain.EventFieldValues(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Fatal(err)
+	}
+
+	edited := &domain.Event{
+		Title:         "Renamed",
+		StartDatetime: start.Add(24 * time.Hour),
+		EndDatetime:   start.Add(26 * time.Hour),
+		Capacity:      10,
+		Visibility:    domain.EventVisibilityPublic,
+		Status:        domain.EventStatusPublished,
+		AccessCode:    "kept-code",
+	}
+	if err := edited.RestoreFields(values); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	if changes := domain.DiffEvents(original, edited); len(changes) != 2 ||
+		changes[0].Field != "access_code" || changes[1].Field != "status" {
+		t.Errorf("expected only the access code and status to be left alone, got %+v", changes)
+	}
+	if edited.Status != domain.EventStatusPublished || edited.AccessCode != "kept-code" {
+		t.Errorf("status and access code must be kept, got %q/%q", edited.Status, edited.AccessCode)
+	}
+
+	values["capacity"] = "fifty"
+	if err := edited.RestoreFields(values); err == nil {
+		t.Error("expected an error for a malformed value")
+	}
+}
@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestEvent_CheckTransition(t *testing.T) {
+	now := time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		from    string
+		to      string
+		started bool
+		allowed bool
+	}{
+		{domain.EventStatusDraft, domain.EventStatusPublished, false, true},
+		{domain.EventStatusDraft, domain.EventStatusCancelled, false, true},
+		{domain.EventStatusDraft, domain.EventStatusPostponed, false, false},
+		{domain.EventStatusDraft, domain.EventStatusCompleted, true, false},
+		{domain.EventStatusPublished, domain.EventStatusPublished, false, false},
+		{domain.EventStatusPublished, domain.EventStatusPostponed, false, true},
+		{domain.EventStatusPublished, domain.EventStatusCompleted, true, true},
+		{domain.EventStatusPublished, domain.EventStatusCompleted, false, false},
+		{domain.EventStatusPublished, domain.EventStatusCancelled, false, true},
+		{domain.EventStatusPostponed, domain.EventStatusPublished, false, true},
+		{domain.EventStatusPostponed, domain.EventStatusCompleted, true, false},
+		{domain.EventStatusPostponed, domain.EventStatusCancelled, false, true},
+		{domain.EventStatusCompleted, domain.EventStatusCancelled, true, false},
+		{domain.EventStatusCancelled, domain.EventStatusPublished, false, false},
+		{domain.EventStatusCancelled, domain.EventStatusCancelled, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			start := now.Add(time.Hour)
+			if tt.started {
+				start = now.Add(-time.Hour)
+			}
+			event := &domain.Event{Status: tt.from, StartDatetime: start, EndDatetime: start.Add(2 * time.Hour)}
+
+			err := event.CheckTransition(tt.to, now)
+			if tt.allowed && err != nil {
+				t.Fatalf("expected transition to be allowed, got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, domain.ErrInvalidStatusTransition) {
+				t.Fatalf("expected ErrInvalidStatusTransition, got %v", err)
+			}
+		})
+	}
+}
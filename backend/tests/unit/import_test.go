@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestParseEventImport(t *testing.T) {
+	file := "\ufeffTitle, Capacity ,Start_Datetime,end_datetime,location,tags,allow_transfers,publish_at\n" +
+		"\"Meetup, Spring\",40,2030-05-01T18:00:00Z,2030-05-01T20:00:00+02:00,Hall A, go ; ;meetup ,false,\n" +
+		"Hi,10,2030-05-01T18:00:00Z,2030-05-01T20:00:00Z,Hall A,,,\n" +
+		"Workshop,10,May 1st,2030-05-01T20:00:00Z,Hall A,,,\n" +
+		"Short row,5\n"
+
+	rows, err := domain.ParseEventImport(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.Err != nil || first.Row != 2 {
+		t.Fatalf("expected row 2 to parse, got row %d: %v", first.Row, first.Err)
+	}
+	req := first.Request
+	if req.Title != "Meetup, Spring" || req.Capacity != 40 || req.Location != "Hall A" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if !req.EndDatetime.Equal(time.Date(2030, 5, 1, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected end time %s", req.EndDatetime)
+	}
+	if !reflect.DeepEqual(req.Tags, []string{"go", "meetup"}) {
+		t.Errorf("unexpected tags %v", req.Tags)
+	}
+	if req.AllowTransfers == nil || *req.AllowTransfers || req.PublishAt != nil {
+		t.Errorf("expected transfers off and no publish time, got %v, %v", req.AllowTransfers, req.PublishAt)
+	}
+
+	for i, want := range []string{"title must be at least 3 characters", "start_datetime must be an RFC 3339 time", "location is required"} {
+		row := rows[i+1]
+		if row.Err == nil || !strings.Contains(row.Err.Error(), want) {
+			t.Errorf("row %d: expected error %q, got %v", row.Row, want, row.Err)
+		}
+	}
+}
+
+func TestParseImport_FileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{"empty", "", "the file is empty"},
+		{"unknown column", "email,name\n", `unknown column "name"`},
+		{"duplicate column", "email,Email\n", `column "email" appears twice`},
+		{"missing column", "ticket_type\n", `required column "email" is missing`},
+		{"too many rows", "email\n" + strings.Repeat("a@example.com\n", domain.MaxImportRows+1), "more than 1000 rows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.ParseRegistrantImport(strings.NewReader(tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	rows, err := domain.ParseRegistrantImport(strings.NewReader("Email,ticket_type\n Ada@Example.com ,VIP\n"))
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("unexpected result: %v, %v", rows, err)
+	}
+	if rows[0].Email != "ada@example.com" || rows[0].TicketType != "VIP" || rows[0].Err != nil {
+		t.Errorf("unexpected row %+v", rows[0])
+	}
+}
@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func newTestForm(t *testing.T) *domain.RegistrationForm {
+	t.Helper()
+
+	form := &domain.RegistrationForm{
+		Questions: []domain.FormQuestion{
+			{ID: "company", Label: "Company", Type: domain.QuestionTypeText},
+			{ID: "tshirt_size", Label: "T-shirt size", Type: domain.QuestionTypeSingleChoice, Required: true, Options: []string{"S", "M", "L"}},
+			{ID: "dietary", Label: "Dietary needs", Type: domain.QuestionTypeMultiChoice, Options: []string{"vegan", "gluten_free"}},
+			{ID: "years", Label: "Years of experience", Type: domain.QuestionTypeNumber},
+			{ID: "newsletter", Label: "Subscribe?", Type: domain.QuestionTypeBoolean, Required: true},
+		},
+	}
+	if err := form.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return form
+}
+
+func TestRegistrationForm_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		question domain.FormQuestion
+		wantErr  string
+	}{
+		{"invalid id", domain.FormQuestion{ID: "T-Shirt", Label: "Size", Type: domain.QuestionTypeText}, "question id"},
+		{"missing label", domain.FormQuestion{ID: "size", Label: " ", Type: domain.QuestionTypeText}, "needs a label"},
+		{"unknown type", domain.FormQuestion{ID: "size", Label: "Size", Type: "date"}, "invalid type"},
+		{"choice without options", domain.FormQuestion{ID: "size", Label: "Size", Type: domain.QuestionTypeSingleChoice}, "at least one option"},
+		{"duplicate options", domain.FormQuestion{ID: "size", Label: "Size", Type: domain.QuestionTypeMultiChoice, Options: []string{"S", "S"}}, "duplicate option"},
+		{"options on text", domain.FormQuestion{ID: "size", Label: "Size", Type: domain.QuestionTypeText, Options: []string{"S"}}, "cannot have options"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &domain.RegistrationForm{Questions: []domain.FormQuestion{tt.question}}
+			err := form.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	form := &domain.RegistrationForm{Questions: []domain.FormQuestion{
+		{ID: "size", Label: "Size", Type: domain.QuestionTypeText},
+		{ID: "size", Label: "Other size", Type: domain.QuestionTypeText},
+	}}
+	if err := form.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate question id") {
+		t.Errorf("Validate() error = %v, want duplicate question id", err)
+	}
+}
+
+func TestRegistrationForm_ValidateAnswers(t *testing.T) {
+	form := newTestForm(t)
+
+	answers, err := form.ValidateAnswers(domain.FormAnswers{
+		"company":     "  Acme  ",
+		"tshirt_size": "M",
+		"dietary":     []interface{}{"vegan", "vegan"},
+		"years":       float64(4),
+		"newsletter":  false,
+	})
+	if err != nil {
+		t.Fatalf("ValidateAnswers() error = %v", err)
+	}
+	if answers["company"] != "Acme" {
+		t.Errorf("company = %v, want trimmed text", answers["company"])
+	}
+	if dietary := answers["dietary"].([]string); len(dietary) != 1 {
+		t.Errorf("dietary = %v, want duplicates removed", dietary)
+	}
+
+	tests := []struct {
+		name    string
+		answers domain.FormAnswers
+		wantErr string
+	}{
+		{"missing required", domain.FormAnswers{"newsletter": true}, `"tshirt_size" is required`},
+		{"blank required boolean", domain.FormAnswers{"tshirt_size": "S", "newsletter": nil}, `"newsletter" is required`},
+		{"unknown question", domain.FormAnswers{"tshirt_size": "S", "newsletter": true, "age": float64(30)}, "unknown question"},
+		{"invalid choice", domain.FormAnswers{"tshirt_size": "XXL", "newsletter": true}, "must be one of"},
+		{"invalid multi choice", domain.FormAnswers{"tshirt_size": "S", "newsletter": true, "dietary": []interface{}{"keto"}}, "must be among"},
+		{"number as text", domain.FormAnswers{"tshirt_size": "S", "newsletter": true, "years": "four"}, "must be a number"},
+		{"too long text", domain.FormAnswers{"tshirt_size": "S", "newsletter": true, "company": strings.Repeat("a", domain.MaxTextAnswerLength+1)}, "at most"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := form.ValidateAnswers(tt.answers)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateAnswers() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistrationForm_AnswersEditable(t *testing.T) {
+	now := time.Now()
+	start := now.Add(48 * time.Hour)
+	form := newTestForm(t)
+
+	if !form.AnswersEditable(start, now) {
+		t.Error("answers should be editable until the event starts")
+	}
+	if form.AnswersEditable(start, start) {
+		t.Error("answers should be locked once the event starts")
+	}
+
+	deadline := now.Add(-time.Minute)
+	form.AnswersEditableUntil = &deadline
+	if form.AnswersEditable(start, now) {
+		t.Error("answers should be locked after the deadline")
+	}
+}
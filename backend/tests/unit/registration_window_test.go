@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestEvent_ComputeRegistrationState(t *testing.T) {
+	now := time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name       string
+		status     string
+		opensAt    *time.Time
+		closesAt   *time.Time
+		start      time.Duration
+		seatsTaken int64
+		want       string
+	}{
+		{"no window, before start", "published", nil, nil, time.Hour, 0, domain.RegistrationStateOpen},
+		{"no window, started", "published", nil, nil, -time.Hour, 0, domain.RegistrationStateClosed},
+		{"draft", "draft", nil, nil, time.Hour, 0, domain.RegistrationStateClosed},
+		{"cancelled", "cancelled", nil, nil, time.Hour, 0, domain.RegistrationStateClosed},
+		{"opens later", "published", at(time.Hour), nil, 48 * time.Hour, 0, domain.RegistrationStateNotOpen},
+		{"opened", "published", at(-time.Hour), nil, 48 * time.Hour, 0, domain.RegistrationStateOpen},
+		{"closed early", "published", nil, at(-time.Minute), 48 * time.Hour, 0, domain.RegistrationStateClosed},
+		{"late registration after start", "published", nil, at(time.Hour), -time.Hour, 0, domain.RegistrationStateOpen},
+		{"full", "published", nil, nil, time.Hour, 10, domain.RegistrationStateFull},
+		{"full but closed", "published", nil, at(-time.Minute), time.Hour, 10, domain.RegistrationStateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &domain.Event{
+				Status:               tt.status,
+				Capacity:             10,
+				StartDatetime:        now.Add(tt.start),
+				EndDatetime:          now.Add(tt.start + 4*time.Hour),
+				RegistrationOpensAt:  tt.opensAt,
+				RegistrationClosesAt: tt.closesAt,
+			}
+			if got := event.ComputeRegistrationState(tt.seatsTaken, now); got != tt.want {
+				t.Errorf("ComputeRegistrationState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvent_CheckRegistrationWindow(t *testing.T) {
+	now := time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC)
+	opens, closes := now.Add(time.Hour), now.Add(2*time.Hour)
+	event := &domain.Event{
+		StartDatetime:        now.Add(24 * time.Hour),
+		EndDatetime:          now.Add(26 * time.Hour),
+		RegistrationOpensAt:  &opens,
+		RegistrationClosesAt: &closes,
+	}
+
+	if err := event.CheckRegistrationWindow(now); !errors.Is(err, domain.ErrRegistrationNotOpen) {
+		t.Errorf("before opening: error = %v, want REGISTRATION_NOT_OPEN", err)
+	}
+	if err := event.CheckRegistrationWindow(opens); err != nil {
+		t.Errorf("at opening: error = %v, want nil", err)
+	}
+	if err := event.CheckRegistrationWindow(closes); !errors.Is(err, domain.ErrRegistrationClosed) {
+		t.Errorf("at closing: error = %v, want REGISTRATION_CLOSED", err)
+	}
+}
+
+func TestEvent_ValidateRegistrationWindow(t *testing.T) {
+	start := time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		opensAt  *time.Time
+		closesAt *time.Time
+		wantErr  bool
+	}{
+		{"no window", nil, nil, false},
+		{"opens before start", at(-time.Hour), nil, false},
+		{"opens after start without close", at(time.Minute), nil, true},
+		{"closes before opening", at(-time.Hour), at(-2 * time.Hour), true},
+		{"closes during the event", nil, at(time.Hour), false},
+		{"closes after the event", nil, at(3 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &domain.Event{
+				Title:                "Event",
+				Capacity:             10,
+				StartDatetime:        start,
+				EndDatetime:          start.Add(2 * time.Hour),
+				RegistrationOpensAt:  tt.opensAt,
+				RegistrationClosesAt: tt.closesAt,
+			}
+			if err := event.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestFindScheduleOverlaps(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2030, 5, 1, hour, 0, 0, 0, time.UTC)
+	}
+	// deliberately out of order
+	schedule := []domain.Event{
+		{ID: "late", StartDatetime: at(15), EndDatetime: at(16)},
+		{ID: "conference", StartDatetime: at(9), EndDatetime: at(17)},
+		{ID: "breakfast", StartDatetime: at(8), EndDatetime: at(9)},
+		{ID: "lunch", StartDatetime: at(12), EndDatetime: at(13)},
+	}
+
+	overlaps := domain.FindScheduleOverlaps(schedule)
+	want := [][2]string{{"conference", "lunch"}, {"conference", "late"}}
+	if len(overlaps) != len(want) {
+		t.Fatalf("expected %d overlaps, got %d: %+v", len(want), len(overlaps), overlaps)
+	}
+	for i, pair := range want {
+		if overlaps[i].First.EventID != pair[0] || overlaps[i].Second.EventID != pair[1] {
+			t.Errorf("overlap %d: expected %v, got %s/%s", i, pair, overlaps[i].First.EventID, overlaps[i].Second.EventID)
+		}
+	}
+
+	conflicts := domain.ScheduleConflicts(&schedule[1], schedule)
+	if len(conflicts) != 2 {
+		t.Errorf("expected the conference to conflict with 2 events, got %+v", conflicts)
+	}
+}
@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fixsbreaker/event-hub/backend/internal/domain"
+)
+
+func TestSession_RoomConflict(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2030, 5, 1, hour, minute, 0, 0, time.UTC)
+	}
+	existing := []domain.Session{
+		{ID: "keynote", Room: "Main Hall", StartDatetime: at(9, 0), EndDatetime: at(10, 0)},
+		{ID: "workshop", Room: "Lab", StartDatetime: at(10, 0), EndDatetime: at(12, 0)},
+	}
+
+	tests := []struct {
+		name     string
+		session  domain.Session
+		conflict string
+	}{
+		{"same room overlapping", domain.Session{ID: "new", Room: "main hall", StartDatetime: at(9, 30), EndDatetime: at(10, 30)}, "keynote"},
+		{"same room enclosing", domain.Session{ID: "new", Room: "Lab", StartDatetime: at(9, 0), EndDatetime: at(13, 0)}, "workshop"},
+		{"same room back to back", domain.Session{ID: "new", Room: "Main Hall", StartDatetime: at(10, 0), EndDatetime: at(11, 0)}, ""},
+		{"other room same time", domain.Session{ID: "new", Room: "Lab", StartDatetime: at(9, 0), EndDatetime: at(10, 0)}, ""},
+		{"no room", domain.Session{ID: "new", StartDatetime: at(9, 0), EndDatetime: at(10, 0)}, ""},
+		{"the session itself", domain.Session{ID: "keynote", Room: "Main Hall", StartDatetime: at(9, 15), EndDatetime: at(10, 0)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := tt.session.RoomConflict(existing)
+			switch {
+			case tt.conflict == "" && other != nil:
+				t.Errorf("expected no conflict, got %s", other.ID)
+			case tt.conflict != "" && (other == nil || other.ID != tt.conflict):
+				t.Errorf("expected a conflict with %s, got %v", tt.conflict, other)
+			}
+		})
+	}
+}
+
+func TestSession_Validate(t *testing.T) {
+	start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	event := &domain.Event{StartDatetime: start, EndDatetime: start.Add(8 * time.Hour)}
+	zero := 0
+
+	tests := []struct {
+		name    string
+		session domain.Session
+		valid   bool
+	}{
+		{"within the event", domain.Session{Title: "Keynote", StartDatetime: start, EndDatetime: start.Add(time.Hour)}, true},
+		{"missing title", domain.Session{Title: "  ", StartDatetime: start, EndDatetime: start.Add(time.Hour)}, false},
+		{"ends before it starts", domain.Session{Title: "Keynote", StartDatetime: start.Add(time.Hour), EndDatetime: start}, false},
+		{"starts before the event", domain.Session{Title: "Breakfast", StartDatetime: start.Add(-time.Hour), EndDatetime: start.Add(time.Hour)}, false},
+		{"ends after the event", domain.Session{Title: "Party", StartDatetime: start.Add(7 * time.Hour), EndDatetime: start.Add(9 * time.Hour)}, false},
+		{"zero capacity", domain.Session{Title: "Keynote", StartDatetime: start, EndDatetime: start.Add(time.Hour), Capacity: &zero}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Validate(event)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}